@@ -329,6 +329,20 @@ func EncodeBlockParams(params *BlockParams) ([]byte, error) {
 	return b, nil
 }
 
+// DecodeBlockParams performs the solidity `abi.decode` for the given encoded blockParams.
+func DecodeBlockParams(b []byte) (*BlockParams, error) {
+	args, err := blockParamsComponentsArgs.Unpack(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to abi.decode block params, %w", err)
+	}
+
+	params, ok := abi.ConvertType(args[0], new(BlockParams)).(*BlockParams)
+	if !ok {
+		return nil, errors.New("failed to convert decoded block params")
+	}
+	return params, nil
+}
+
 // EncodeAssignmentHookInput performs the solidity `abi.encode` for the given input
 func EncodeAssignmentHookInput(input *AssignmentHookInput) ([]byte, error) {
 	b, err := assignmentHookInputArgs.Pack(input)
@@ -23,7 +23,6 @@ import (
 	"github.com/taikoxyz/taiko-client/driver/state"
 	txlistfetcher "github.com/taikoxyz/taiko-client/driver/txlist_fetcher"
 	"github.com/taikoxyz/taiko-client/internal/metrics"
-	"github.com/taikoxyz/taiko-client/internal/utils"
 	eventIterator "github.com/taikoxyz/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	txListValidator "github.com/taikoxyz/taiko-client/pkg/txlist_validator"
@@ -253,8 +252,13 @@ func (s *Syncer) onBlockProposed(
 		}
 	}
 
-	if txListBytes, err = utils.Decompress(txListBytes); err != nil {
-		return fmt.Errorf("failed to decompress tx list bytes: %w", err)
+	if txListBytes, err = rpc.DecompressTxList(txListBytes); err != nil {
+		if !errors.Is(err, rpc.ErrDecompressedTooLarge) {
+			return fmt.Errorf("failed to decompress tx list bytes: %w", err)
+		}
+		// A too large transactions list is invalid, so an empty L2 block will be inserted instead.
+		log.Info("Decompressed transactions list too large", "blockID", event.BlockId)
+		txListBytes = []byte{}
 	}
 
 	// If the transactions list is invalid, we simply insert an empty L2 block.
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/sha256"
 
-	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -30,8 +29,9 @@ func (d *BlobFetcher) Fetch(
 	_ *types.Transaction,
 	meta *bindings.TaikoDataBlockMetadata,
 ) ([]byte, error) {
-	if !meta.BlobUsed {
-		return nil, errBlobUnused
+	blobHashes, offset, length, err := blobParamsFromMeta(meta)
+	if err != nil {
+		return nil, err
 	}
 
 	// Fetch the L1 block sidecars.
@@ -52,12 +52,9 @@ func (d *BlobFetcher) Fetch(
 		)
 
 		commitment := kzg4844.Commitment(common.FromHex(sidecar.KzgCommitment))
-		if kzg4844.CalcBlobHashV1(
-			sha256.New(),
-			&commitment,
-		) == common.BytesToHash(meta.BlobHash[:]) {
-			blob := eth.Blob(common.FromHex(sidecar.Blob))
-			return blob.ToData()
+		if kzg4844.CalcBlobHashV1(sha256.New(), &commitment) == blobHashes[0] {
+			blob := kzg4844.Blob(common.FromHex(sidecar.Blob))
+			return SliceBlobData(&blob, offset, length)
 		}
 	}
 
@@ -0,0 +1,49 @@
+package txlistdecoder
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+// BlobParamsFromProposedEvent extracts the blob reference of the given BlockProposed event, i.e. the
+// hashes of the blobs carrying the txList, and the offset and the length of the txList in the decoded
+// blob data. The current protocol's BlockProposed event references one whole blob by its hash, so the
+// offset is always zero and the length is the blob capacity, which SliceBlobData clamps to the actual
+// decoded data. An error wrapping errBlobUnused will be returned if the proposal is not made via blob.
+func BlobParamsFromProposedEvent(
+	ev *bindings.TaikoL1ClientBlockProposed,
+) (blobHashes []common.Hash, offset, length uint32, err error) {
+	return blobParamsFromMeta(&ev.Meta)
+}
+
+// blobParamsFromMeta extracts the blob reference of the given block metadata, see
+// BlobParamsFromProposedEvent.
+func blobParamsFromMeta(meta *bindings.TaikoDataBlockMetadata) ([]common.Hash, uint32, uint32, error) {
+	if !meta.BlobUsed {
+		return nil, 0, 0, fmt.Errorf("%w (blockID: %d)", errBlobUnused, meta.Id)
+	}
+	if meta.BlobHash == (common.Hash{}) {
+		return nil, 0, 0, fmt.Errorf("empty blob hash (blockID: %d)", meta.Id)
+	}
+
+	return []common.Hash{meta.BlobHash}, 0, uint32(new(rpc.PackedBlobEncoder).MaxDataSize()), nil
+}
+
+// SliceBlobData decodes the given blob fetched for a blob reference, and slices out the referenced
+// `[offset:offset+length]` data, a length beyond the end of the decoded data is clamped to it.
+func SliceBlobData(blob *kzg4844.Blob, offset, length uint32) ([]byte, error) {
+	data, err := rpc.DecodeBlob(*blob)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(offset) > uint64(len(data)) {
+		return nil, fmt.Errorf("blob data offset %d exceeds the data length %d", offset, len(data))
+	}
+
+	return data[offset:min(uint64(offset)+uint64(length), uint64(len(data)))], nil
+}
@@ -0,0 +1,67 @@
+package txlistdecoder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+)
+
+func TestBlobParamsFromProposedEvent(t *testing.T) {
+	txList := []byte("compressed txList bytes")
+	sidecar, err := rpc.MakeSidecar(txList)
+	require.Nil(t, err)
+
+	ev := &bindings.TaikoL1ClientBlockProposed{
+		BlockId:        common.Big1,
+		AssignedProver: common.HexToAddress("0x1000000000000000000000000000000000000001"),
+		LivenessBond:   big.NewInt(1e18),
+		Meta: bindings.TaikoDataBlockMetadata{
+			Id:       1,
+			L1Height: 100,
+			BlobUsed: true,
+			BlobHash: sidecar.BlobHashes()[0],
+		},
+	}
+
+	blobHashes, offset, length, err := BlobParamsFromProposedEvent(ev)
+	require.Nil(t, err)
+	require.Equal(t, sidecar.BlobHashes(), blobHashes)
+	require.Zero(t, offset)
+	require.Equal(t, uint32(new(rpc.PackedBlobEncoder).MaxDataSize()), length)
+
+	// The whole txList is referenced.
+	data, err := SliceBlobData(&sidecar.Blobs[0], offset, length)
+	require.Nil(t, err)
+	require.Equal(t, txList, data)
+
+	// Not a blob proposal.
+	ev.Meta.BlobUsed = false
+	_, _, _, err = BlobParamsFromProposedEvent(ev)
+	require.ErrorIs(t, err, errBlobUnused)
+
+	ev.Meta.BlobUsed, ev.Meta.BlobHash = true, common.Hash{}
+	_, _, _, err = BlobParamsFromProposedEvent(ev)
+	require.ErrorContains(t, err, "empty blob hash")
+}
+
+func TestSliceBlobData(t *testing.T) {
+	sidecar, err := rpc.MakeSidecar([]byte("0123456789"))
+	require.Nil(t, err)
+	blob := &sidecar.Blobs[0]
+
+	data, err := SliceBlobData(blob, 2, 3)
+	require.Nil(t, err)
+	require.Equal(t, []byte("234"), data)
+
+	data, err = SliceBlobData(blob, 10, 1)
+	require.Nil(t, err)
+	require.Empty(t, data)
+
+	_, err = SliceBlobData(blob, 11, 1)
+	require.ErrorContains(t, err, "exceeds the data length")
+}
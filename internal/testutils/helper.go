@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
@@ -24,6 +25,13 @@ import (
 	"github.com/taikoxyz/taiko-client/prover/server"
 )
 
+var (
+	proverServerProbeInitialInterval        = 100 * time.Millisecond
+	proverServerProbeTimeout                = time.Second
+	proverServerProbeJitter                 = 0.5
+	proverServerProbeMaxRetries      uint64 = 20
+)
+
 func (s *ClientTestSuite) ProposeInvalidTxListBytes(proposer Proposer) {
 	invalidTxListBytes := RandomBytes(256)
 
@@ -210,11 +218,12 @@ func (s *ClientTestSuite) ProposeValidBlock(
 }
 
 // NewTestProverServer starts a new prover server that has channel listeners to respond and react
-// to requests for capacity, which provers can call.
+// to requests for capacity, which provers can call, the returned function shuts down the server
+// and waits for the in-flight requests.
 func (s *ClientTestSuite) NewTestProverServer(
 	proverPrivKey *ecdsa.PrivateKey,
 	url *url.URL,
-) *server.ProverServer {
+) (*server.ProverServer, func()) {
 	protocolConfig, err := s.RPCClient.TaikoL1.GetConfig(nil)
 	s.Nil(err)
 
@@ -234,15 +243,49 @@ func (s *ClientTestSuite) NewTestProverServer(
 	})
 	s.Nil(err)
 
+	s.Nil(StartProverServer(srv, url))
+
+	return srv, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		s.Nil(srv.Shutdown(ctx))
+	}
+}
+
+// StartProverServer starts the given prover server at the port of the given URL, and waits till it is
+// ready, the readiness probes use HTTPS if the server has TLS enabled, and they are retried with a
+// bounded jittered exponential backoff, a fatal start error (e.g. the port is already in use) will be
+// returned immediately.
+func StartProverServer(srv *server.ProverServer, url *url.URL) error {
+	startErrCh := make(chan error, 1)
 	go func() {
 		if err := srv.Start(fmt.Sprintf(":%v", url.Port())); !errors.Is(err, http.ErrServerClosed) {
 			log.Error("Failed to start prover server", "error", err)
+			startErrCh <- err
 		}
 	}()
 
-	// Wait till the server fully started.
-	s.Nil(backoff.Retry(func() error {
-		res, err := resty.New().R().Get(url.String() + "/healthz")
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = proverServerProbeInitialInterval
+	expBackoff.RandomizationFactor = proverServerProbeJitter
+
+	probeURL := *url
+	probeURL.Path = srv.PathPrefix() + "/healthz"
+	client := resty.New().SetTimeout(proverServerProbeTimeout)
+	if srv.TLSEnabled() {
+		// The probe only checks the readiness of the local server, which might use a self-signed certificate.
+		probeURL.Scheme = "https"
+		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) // nolint: gosec
+	}
+
+	return backoff.Retry(func() error {
+		select {
+		case err := <-startErrCh:
+			return backoff.Permanent(fmt.Errorf("failed to start prover server: %w", err))
+		default:
+		}
+
+		res, err := client.R().Get(probeURL.String())
 		if err != nil {
 			return err
 		}
@@ -251,9 +294,7 @@ func (s *ClientTestSuite) NewTestProverServer(
 		}
 
 		return nil
-	}, backoff.NewExponentialBackOff()))
-
-	return srv
+	}, backoff.WithMaxRetries(expBackoff, proverServerProbeMaxRetries))
 }
 
 // RandomHash generates a random blob of data and returns it as a hash.
@@ -0,0 +1,95 @@
+package testutils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/prover/server"
+)
+
+func TestStartProverServerPortInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer l.Close()
+
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	srv, err := server.New(&server.NewProverServerOpts{ProverPrivateKey: privKey, MaxExpiry: time.Hour})
+	require.Nil(t, err)
+	defer func() { _ = srv.Shutdown(context.Background()) }()
+
+	endpoint, err := url.Parse(fmt.Sprintf("http://%s", l.Addr()))
+	require.Nil(t, err)
+
+	startedAt := time.Now()
+	require.ErrorContains(t, StartProverServer(srv, endpoint), "failed to start prover server")
+	require.Less(t, time.Since(startedAt), 5*time.Second)
+}
+
+// writeSelfSignedCert writes a self-signed TLS certificate and its private key for localhost to the given
+// directory, and returns their paths.
+func writeSelfSignedCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	require.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile
+}
+
+func TestStartProverServerTLS(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	srv, err := server.New(&server.NewProverServerOpts{
+		ProverPrivateKey: privKey,
+		MaxExpiry:        time.Hour,
+		TLSCertFile:      certFile,
+		TLSKeyFile:       keyFile,
+	})
+	require.Nil(t, err)
+	require.True(t, srv.TLSEnabled())
+	defer func() { _ = srv.Shutdown(context.Background()) }()
+
+	port, err := freeport.GetFreePort()
+	require.Nil(t, err)
+	endpoint, err := url.Parse(fmt.Sprintf("http://localhost:%v", port))
+	require.Nil(t, err)
+
+	require.Nil(t, StartProverServer(srv, endpoint))
+}
@@ -30,6 +30,7 @@ type ClientTestSuite struct {
 	ProverEndpoints     []*url.URL
 	AddressManager      *bindings.AddressManager
 	proverServer        *server.ProverServer
+	shutdownProver      func()
 }
 
 func (s *ClientTestSuite) SetupTest() {
@@ -70,7 +71,7 @@ func (s *ClientTestSuite) SetupTest() {
 	s.Nil(err)
 
 	s.ProverEndpoints = []*url.URL{LocalRandomProverEndpoint()}
-	s.proverServer = s.NewTestProverServer(l1ProverPrivKey, s.ProverEndpoints[0])
+	s.proverServer, s.shutdownProver = s.NewTestProverServer(l1ProverPrivKey, s.ProverEndpoints[0])
 
 	balance, err := rpcCli.TaikoToken.BalanceOf(nil, crypto.PubkeyToAddress(l1ProverPrivKey.PublicKey))
 	s.Nil(err)
@@ -132,7 +133,7 @@ func (s *ClientTestSuite) TearDownTest() {
 	s.RevertL1Snapshot(s.testnetL1SnapshotID)
 
 	s.Nil(rpc.SetHead(context.Background(), s.RPCClient.L2, common.Big0))
-	s.Nil(s.proverServer.Shutdown(context.Background()))
+	s.shutdownProver()
 }
 
 func (s *ClientTestSuite) SetL1Automine(automine bool) {
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CreateAccessList creates an EIP-2930 access list of the given call message by eth_createAccessList, and
+// returns it along with the gas used by the call when the access list is applied. Unlike the gethclient
+// implementation, the blob fields of the message are sent as well, so that the BLOBHASH opcode works
+// in the simulated call.
+func (c *EthClient) CreateAccessList(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+) (accessList *types.AccessList, gasUsed uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("CreateAccessList", time.Now(), &err)
+
+	args := TransactionArgs{
+		From:                 &msg.From,
+		To:                   msg.To,
+		GasPrice:             (*hexutil.Big)(msg.GasPrice),
+		MaxFeePerGas:         (*hexutil.Big)(msg.GasFeeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(msg.GasTipCap),
+		Value:                (*hexutil.Big)(msg.Value),
+		Input:                (*hexutil.Bytes)(&msg.Data),
+		BlobFeeCap:           (*hexutil.Big)(msg.BlobGasFeeCap),
+		BlobHashes:           msg.BlobHashes,
+	}
+	if msg.Gas != 0 {
+		args.Gas = (*hexutil.Uint64)(&msg.Gas)
+	}
+	if msg.AccessList != nil {
+		args.AccessList = &msg.AccessList
+	}
+
+	var result struct {
+		AccessList *types.AccessList `json:"accessList"`
+		Error      string            `json:"error,omitempty"`
+		GasUsed    hexutil.Uint64    `json:"gasUsed"`
+	}
+	if err = c.CallContext(ctxWithTimeout, &result, "eth_createAccessList", args); err != nil {
+		return nil, 0, err
+	}
+	if result.Error != "" {
+		return nil, 0, fmt.Errorf("access list call failed: %s", result.Error)
+	}
+
+	return result.AccessList, uint64(result.GasUsed), nil
+}
+
+// blobTxAccessList returns the access list attached to a blob transaction, which is AccessList if it is
+// set, otherwise the one created by CreateAccessList if AutoAccessList is set.
+func (c *EthClient) blobTxAccessList(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	fees *blobTxFees,
+	blobHashes []common.Hash,
+) (*types.AccessList, error) {
+	if c.AccessList != nil {
+		return c.AccessList, nil
+	}
+	if !c.AutoAccessList {
+		return nil, nil
+	}
+
+	accessList, _, err := c.CreateAccessList(opts.Context, ethereum.CallMsg{
+		From:          opts.From,
+		To:            &contract,
+		Gas:           opts.GasLimit,
+		GasFeeCap:     fees.GasFeeCap,
+		GasTipCap:     fees.GasTipCap,
+		Value:         opts.Value,
+		Data:          input,
+		BlobGasFeeCap: fees.BlobFeeCap,
+		BlobHashes:    blobHashes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access list: %w", err)
+	}
+
+	return accessList, nil
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSimulatedEthClient starts a simulated backend with the given genesis alloc, and connects an
+// EthClient to it through IPC.
+func newTestSimulatedEthClient(t *testing.T, alloc types.GenesisAlloc) *EthClient {
+	ipcPath := filepath.Join(t.TempDir(), "simulated.ipc")
+	backend := simulated.NewBackend(alloc, func(nodeConf *node.Config, _ *ethconfig.Config) {
+		nodeConf.IPCPath = ipcPath
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	client, err := NewEthClient(context.Background(), ipcPath, 0)
+	require.Nil(t, err)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// balanceReaderCode returns the code of a contract which reads the balances of the given accounts, each
+// of them is a cold account access, unless it is in the access list.
+func balanceReaderCode(accounts []common.Address) []byte {
+	var code []byte
+	for _, account := range accounts {
+		code = append(code, byte(vm.PUSH20))
+		code = append(code, account.Bytes()...)
+		code = append(code, byte(vm.BALANCE), byte(vm.POP))
+	}
+	return append(code, byte(vm.STOP))
+}
+
+// accessListAddresses returns the addresses in the given access list, the order of which is not specified
+// by eth_createAccessList.
+func accessListAddresses(accessList types.AccessList) []common.Address {
+	addresses := make([]common.Address, 0, len(accessList))
+	for _, tuple := range accessList {
+		addresses = append(addresses, tuple.Address)
+	}
+	return addresses
+}
+
+func TestCreateBlobTxAccessList(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	var (
+		from     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		accounts []common.Address
+	)
+	for i := 0; i < 5; i++ {
+		accounts = append(accounts, common.BigToAddress(big.NewInt(int64(0x2000+i))))
+	}
+	client := newTestSimulatedEthClient(t, types.GenesisAlloc{
+		from:     {Balance: new(big.Int).Exp(common.Big2, big.NewInt(100), nil)},
+		contract: {Code: balanceReaderCode(accounts)},
+	})
+
+	accessList, _, err := client.CreateAccessList(context.Background(), ethereum.CallMsg{From: from, To: &contract})
+	require.Nil(t, err)
+	require.ElementsMatch(t, accounts, accessListAddresses(*accessList))
+
+	// The access list reduces the estimated gas.
+	gas, err := client.EstimateGas(context.Background(), ethereum.CallMsg{From: from, To: &contract})
+	require.Nil(t, err)
+	gasWithAccessList, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:       from,
+		To:         &contract,
+		AccessList: *accessList,
+	})
+	require.Nil(t, err)
+	require.Less(t, gasWithAccessList, gas)
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, client.ChainID)
+	require.Nil(t, err)
+	opts.Context = context.Background()
+	sidecar, err := MakeSidecar([]byte("access list"))
+	require.Nil(t, err)
+
+	blobTx, err := client.CreateBlobTx(opts, contract, nil, sidecar)
+	require.Nil(t, err)
+	require.Empty(t, blobTx.AccessList)
+
+	// The access list is generated and attached to the blob transaction.
+	client.AutoAccessList = true
+	autoBlobTx, err := client.CreateBlobTx(opts, contract, nil, sidecar)
+	require.Nil(t, err)
+	require.ElementsMatch(t, accounts, accessListAddresses(autoBlobTx.AccessList))
+	require.Less(t, autoBlobTx.Gas, blobTx.Gas)
+
+	// The explicitly given access list takes precedence.
+	client.AccessList = &types.AccessList{{Address: accounts[0], StorageKeys: []common.Hash{}}}
+	explicitBlobTx, err := client.CreateBlobTx(opts, contract, nil, sidecar)
+	require.Nil(t, err)
+	require.Equal(t, *client.AccessList, explicitBlobTx.AccessList)
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	// Request urls.
+	finalityCheckpointsRequestURL = "eth/v1/beacon/states/head/finality_checkpoints"
+	blockRequestURL               = "eth/v2/beacon/blocks/%s"
+	// slotsPerEpoch is the number of slots in an epoch of the Ethereum beacon chain.
+	slotsPerEpoch uint64 = 32
+	// defaultFinalityStallEpochs is the default number of epochs WaitForFinalized tolerates without
+	// the finalized epoch making progress, a healthy chain finalizes a new epoch every epoch.
+	defaultFinalityStallEpochs uint64 = 3
+	// ErrFinalityStalled is returned by WaitForFinalized when the finalized epoch makes no progress
+	// within the stall timeout, i.e. the chain is not finalizing.
+	ErrFinalityStalled = errors.New("beacon chain finality stalled")
+)
+
+// FinalityCheckpointsResponse is the response of the beacon API finality checkpoints request.
+type FinalityCheckpointsResponse struct {
+	Data struct {
+		Finalized struct {
+			Epoch string `json:"epoch"`
+			Root  string `json:"root"`
+		} `json:"finalized"`
+	} `json:"data"`
+}
+
+// BlockExecutionPayloadResponse is the part of the beacon API block response which carries the
+// execution block number.
+type BlockExecutionPayloadResponse struct {
+	Data struct {
+		Message struct {
+			Body struct {
+				ExecutionPayload struct {
+					BlockNumber string `json:"block_number"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// FinalizedBlockNumber returns the current finalized epoch, and the number of the L1 execution block
+// included in the finalized checkpoint block, both are zero if nothing is finalized yet.
+func (c *BeaconClient) FinalizedBlockNumber(ctx context.Context) (epoch uint64, number uint64, err error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+	defer cancel()
+
+	resBytes, err := c.Get(ctxWithTimeout, finalityCheckpointsRequestURL)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var checkpoints *FinalityCheckpointsResponse
+	if err := json.Unmarshal(resBytes, &checkpoints); err != nil {
+		return 0, 0, err
+	}
+	if epoch, err = strconv.ParseUint(checkpoints.Data.Finalized.Epoch, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid finalized epoch %q: %w", checkpoints.Data.Finalized.Epoch, err)
+	}
+	// The genesis checkpoint has an empty root.
+	root := checkpoints.Data.Finalized.Root
+	if common.HexToHash(root) == (common.Hash{}) {
+		return epoch, 0, nil
+	}
+
+	if resBytes, err = c.Get(ctxWithTimeout, fmt.Sprintf(blockRequestURL, root)); err != nil {
+		return 0, 0, err
+	}
+
+	var block *BlockExecutionPayloadResponse
+	if err := json.Unmarshal(resBytes, &block); err != nil {
+		return 0, 0, err
+	}
+	blockNumber := block.Data.Message.Body.ExecutionPayload.BlockNumber
+	if number, err = strconv.ParseUint(blockNumber, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid execution block number %q of block %s: %w", blockNumber, root, err)
+	}
+
+	return epoch, number, nil
+}
+
+// WaitForFinalized keeps polling the beacon finality checkpoint every FinalityPollInterval, until the
+// given L1 block is at or below the execution block of the finalized checkpoint, or the context is done.
+// It returns an error wrapping ErrFinalityStalled if the finalized epoch has not advanced for
+// FinalityStallTimeout, so that a non-finalizing chain won't block the caller forever.
+func (c *BeaconClient) WaitForFinalized(ctx context.Context, l1BlockNumber uint64) error {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	pollInterval := time.Duration(c.secondsPerSlot) * time.Second
+	if c.FinalityPollInterval != 0 {
+		pollInterval = c.FinalityPollInterval
+	}
+	stallTimeout := time.Duration(defaultFinalityStallEpochs*slotsPerEpoch*c.secondsPerSlot) * time.Second
+	if c.FinalityStallTimeout != 0 {
+		stallTimeout = c.FinalityStallTimeout
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		lastEpoch    uint64
+		lastProgress = time.Now()
+	)
+	for {
+		epoch, finalized, err := c.FinalizedBlockNumber(ctx)
+		if err != nil {
+			// Transient beacon API errors are tolerated, the stall timeout still applies.
+			log.Debug("Failed to fetch the finalized block, keep waiting", "target", l1BlockNumber, "error", err)
+		} else {
+			if finalized >= l1BlockNumber {
+				return nil
+			}
+			if epoch > lastEpoch {
+				lastEpoch, lastProgress = epoch, time.Now()
+			}
+			log.Debug("Waiting for the L1 finality", "finalized", finalized, "epoch", epoch, "target", l1BlockNumber)
+		}
+
+		if time.Since(lastProgress) >= stallTimeout {
+			return fmt.Errorf(
+				"%w: finalized epoch %d has not advanced for %s, target block %d",
+				ErrFinalityStalled,
+				lastEpoch,
+				stallTimeout,
+				l1BlockNumber,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFinalityBeaconClient starts a beacon API server whose finalized epoch is returned by the given
+// function at each finality checkpoints request, the checkpoint block of epoch n includes the execution
+// block 32 * n, and the genesis checkpoint has an empty root.
+func newTestFinalityBeaconClient(t *testing.T, finalizedEpoch func() uint64) *BeaconClient {
+	mux := newTestBeaconMux(t, nil)
+	mux.HandleFunc("/eth/v1/beacon/states/head/finality_checkpoints", func(w http.ResponseWriter, _ *http.Request) {
+		epoch := finalizedEpoch()
+		var root common.Hash
+		if epoch != 0 {
+			root = common.BigToHash(new(big.Int).SetUint64(epoch))
+		}
+		fmt.Fprintf(w, `{"data":{"finalized":{"epoch":"%d","root":"%s"}}}`, epoch, root)
+	})
+	mux.HandleFunc("/eth/v2/beacon/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		root := common.HexToHash(strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"))
+		number := root.Big().Uint64() * slotsPerEpoch
+		fmt.Fprintf(w, `{"data":{"message":{"body":{"execution_payload":{"block_number":"%d"}}}}}`, number)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	cli, err := NewBeaconClient(srv.URL, time.Second)
+	require.Nil(t, err)
+	cli.FinalityPollInterval = 10 * time.Millisecond
+
+	return cli
+}
+
+func TestFinalizedBlockNumber(t *testing.T) {
+	var epoch atomic.Uint64
+	cli := newTestFinalityBeaconClient(t, epoch.Load)
+
+	finalizedEpoch, number, err := cli.FinalizedBlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Zero(t, finalizedEpoch)
+	require.Zero(t, number)
+
+	epoch.Store(3)
+	finalizedEpoch, number, err = cli.FinalizedBlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), finalizedEpoch)
+	require.Equal(t, uint64(96), number)
+}
+
+func TestWaitForFinalized(t *testing.T) {
+	var (
+		epoch    atomic.Uint64
+		requests atomic.Uint64
+	)
+	// The finality advances one epoch at each request.
+	cli := newTestFinalityBeaconClient(t, func() uint64 {
+		requests.Add(1)
+		return epoch.Add(1)
+	})
+
+	require.Nil(t, cli.WaitForFinalized(context.Background(), 100))
+	// Block 100 is finalized with the checkpoint of epoch 4.
+	require.Equal(t, uint64(4), epoch.Load())
+	require.Equal(t, uint64(4), requests.Load())
+
+	// Returns immediately if the block is already finalized.
+	require.Nil(t, cli.WaitForFinalized(context.Background(), 64))
+	require.Equal(t, uint64(5), requests.Load())
+}
+
+func TestWaitForFinalizedStalled(t *testing.T) {
+	cli := newTestFinalityBeaconClient(t, func() uint64 { return 2 })
+	cli.FinalityStallTimeout = 50 * time.Millisecond
+
+	err := cli.WaitForFinalized(context.Background(), 100)
+	require.ErrorIs(t, err, ErrFinalityStalled)
+	require.ErrorContains(t, err, "finalized epoch 2")
+}
+
+func TestWaitForFinalizedContextErr(t *testing.T) {
+	cli := newTestFinalityBeaconClient(t, func() uint64 { return 0 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, cli.WaitForFinalized(ctx, 100), context.DeadlineExceeded)
+}
+
+func TestFinalizedBlockNumberInvalid(t *testing.T) {
+	mux := newTestBeaconMux(t, nil)
+	mux.HandleFunc("/eth/v1/beacon/states/head/finality_checkpoints", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"data":{"finalized":{"epoch":"invalid","root":"0x00"}}}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cli, err := NewBeaconClient(srv.URL, time.Second)
+	require.Nil(t, err)
+
+	_, _, err = cli.FinalizedBlockNumber(context.Background())
+	require.ErrorContains(t, err, strconv.Quote("invalid"))
+}
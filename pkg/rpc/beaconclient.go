@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prysmaticlabs/prysm/v4/api/client"
 	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
@@ -31,6 +35,12 @@ type GenesisResponse struct {
 
 type BeaconClient struct {
 	*beacon.Client
+	// FinalityPollInterval is the interval of polling the finality checkpoint in WaitForFinalized,
+	// default to one slot.
+	FinalityPollInterval time.Duration
+	// FinalityStallTimeout is the max duration WaitForFinalized keeps waiting without the finalized
+	// epoch making any progress, before giving up with ErrFinalityStalled, default to 3 epochs.
+	FinalityStallTimeout time.Duration
 
 	timeout        time.Duration
 	genesisTime    uint64
@@ -78,7 +88,12 @@ func NewBeaconClient(endpoint string, timeout time.Duration) (*BeaconClient, err
 
 	log.Info("L1 seconds per slot", "seconds", secondsPerSlot)
 
-	return &BeaconClient{cli, timeout, uint64(genesisTime), uint64(secondsPerSlot)}, nil
+	return &BeaconClient{
+		Client:         cli,
+		timeout:        timeout,
+		genesisTime:    uint64(genesisTime),
+		secondsPerSlot: uint64(secondsPerSlot),
+	}, nil
 }
 
 // GetBlobs returns the sidecars for a given slot.
@@ -97,7 +112,102 @@ func (c *BeaconClient) GetBlobs(ctx context.Context, time uint64) ([]*blob.Sidec
 		return nil, err
 	}
 
-	return sidecars.Data, json.Unmarshal(resBytes, &sidecars)
+	if err := json.Unmarshal(resBytes, &sidecars); err != nil {
+		return nil, err
+	}
+
+	return sidecars.Data, nil
+}
+
+// Blob represents a parsed blob sidecar fetched from the beacon node.
+type Blob struct {
+	Index         uint64
+	Blob          kzg4844.Blob
+	KZGCommitment kzg4844.Commitment
+	KZGProof      kzg4844.Proof
+}
+
+// GetBlobsBySlot returns the parsed blobs for a given slot, only the blobs with the given
+// indices will be returned if any indices are specified. Each blob's KZG commitment will
+// be verified against the included KZG proof.
+func (c *BeaconClient) GetBlobsBySlot(ctx context.Context, slot uint64, indices ...uint64) ([]*Blob, error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+	defer cancel()
+
+	var opts []client.ReqOption
+	if len(indices) != 0 {
+		query := url.Values{}
+		for _, index := range indices {
+			query.Add("indices", strconv.FormatUint(index, 10))
+		}
+		opts = append(opts, func(req *http.Request) { req.URL.RawQuery = query.Encode() })
+	}
+
+	resBytes, err := c.Get(ctxWithTimeout, fmt.Sprintf(sidecarsRequestURL, slot), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSidecarsResponse(slot, resBytes)
+}
+
+// parseSidecarsResponse parses the given beacon API blob sidecars response of the given slot, and verifies
+// the KZG proof of each blob.
+func parseSidecarsResponse(slot uint64, resBytes []byte) ([]*Blob, error) {
+	var sidecars *blob.SidecarsResponse
+	if err := json.Unmarshal(resBytes, &sidecars); err != nil {
+		return nil, err
+	}
+
+	var (
+		blobs = make([]*Blob, len(sidecars.Data))
+		err   error
+	)
+	for i, sidecar := range sidecars.Data {
+		if blobs[i], err = parseSidecar(sidecar); err != nil {
+			return nil, fmt.Errorf("invalid blob sidecar at slot %d: %w", slot, err)
+		}
+	}
+
+	return blobs, nil
+}
+
+// parseSidecar parses the given beacon API blob sidecar, and verifies its KZG proof.
+func parseSidecar(sidecar *blob.Sidecar) (*Blob, error) {
+	index, err := strconv.ParseUint(sidecar.Index, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index %q: %w", sidecar.Index, err)
+	}
+
+	parsed := &Blob{Index: index}
+	if err := decodeFixedHex(sidecar.Blob, parsed.Blob[:]); err != nil {
+		return nil, fmt.Errorf("invalid blob (index %d): %w", index, err)
+	}
+	if err := decodeFixedHex(sidecar.KzgCommitment, parsed.KZGCommitment[:]); err != nil {
+		return nil, fmt.Errorf("invalid KZG commitment (index %d): %w", index, err)
+	}
+	if err := decodeFixedHex(sidecar.KzgProof, parsed.KZGProof[:]); err != nil {
+		return nil, fmt.Errorf("invalid KZG proof (index %d): %w", index, err)
+	}
+
+	if err := verifyBlobProof(parsed.Blob, parsed.KZGCommitment, parsed.KZGProof); err != nil {
+		return nil, fmt.Errorf("failed to verify KZG proof (index %d): %w", index, err)
+	}
+
+	return parsed, nil
+}
+
+// decodeFixedHex decodes the given hex string into the given fixed length buffer.
+func decodeFixedHex(s string, buf []byte) error {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(buf) {
+		return fmt.Errorf("invalid length, expected: %d, actual: %d", len(buf), len(b))
+	}
+	copy(buf, b)
+	return nil
 }
 
 // timeToSlot returns the slots of the given timestamp.
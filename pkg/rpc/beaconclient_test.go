@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/blob"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testGenesisTime    uint64 = 1710000000
+	testSecondsPerSlot uint64 = 12
+)
+
+// newTestBeaconServer starts a beacon API server, which serves the given sidecars at the given slots.
+func newTestBeaconServer(t *testing.T, sidecars map[uint64][]*blob.Sidecar) *httptest.Server {
+	srv := httptest.NewServer(newTestBeaconMux(t, sidecars))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// newTestBeaconMux creates the handlers of a beacon API server, which serves the given sidecars at the
+// given slots, so that more endpoints can be added.
+func newTestBeaconMux(t *testing.T, sidecars map[uint64][]*blob.Sidecar) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"genesis_time":"%d"}}`, testGenesisTime)
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"SECONDS_PER_SLOT":"%d"}}`, testSecondsPerSlot)
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, ok := sidecars[slot]
+		if !ok {
+			http.Error(w, `{"code":404,"message":"block not found"}`, http.StatusNotFound)
+			return
+		}
+
+		if indices := r.URL.Query()["indices"]; len(indices) != 0 {
+			var filtered []*blob.Sidecar
+			for _, sidecar := range data {
+				for _, index := range indices {
+					if sidecar.Index == index {
+						filtered = append(filtered, sidecar)
+					}
+				}
+			}
+			data = filtered
+		}
+
+		require.Nil(t, json.NewEncoder(w).Encode(&blob.SidecarsResponse{Data: data}))
+	})
+
+	return mux
+}
+
+// newTestSidecars makes beacon API blob sidecars with the given data.
+func newTestSidecars(t *testing.T, data ...[]byte) []*blob.Sidecar {
+	sidecars := make([]*blob.Sidecar, len(data))
+	for i, d := range data {
+		sidecar, err := MakeSidecar(d)
+		require.Nil(t, err)
+
+		sidecars[i] = &blob.Sidecar{
+			Index:         strconv.Itoa(i),
+			Blob:          hexutil.Encode(sidecar.Blobs[0][:]),
+			KzgCommitment: hexutil.Encode(sidecar.Commitments[0][:]),
+			KzgProof:      hexutil.Encode(sidecar.Proofs[0][:]),
+		}
+	}
+
+	return sidecars
+}
+
+func TestGetBlobsBySlot(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("blob0"), []byte("blob1"))
+	srv := newTestBeaconServer(t, map[uint64][]*blob.Sidecar{1: sidecars})
+
+	cli, err := NewBeaconClient(srv.URL, time.Second)
+	require.Nil(t, err)
+
+	blobs, err := cli.GetBlobsBySlot(context.Background(), 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(blobs))
+	for i, b := range blobs {
+		require.Equal(t, uint64(i), b.Index)
+		require.Equal(t, sidecars[i].KzgCommitment, hexutil.Encode(b.KZGCommitment[:]))
+
+		data, err := DecodeBlob(b.Blob)
+		require.Nil(t, err)
+		require.Equal(t, fmt.Sprintf("blob%d", i), string(data))
+	}
+
+	// Filter by indices.
+	blobs, err = cli.GetBlobsBySlot(context.Background(), 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(blobs))
+	require.Equal(t, uint64(1), blobs[0].Index)
+
+	// Unknown slot.
+	_, err = cli.GetBlobsBySlot(context.Background(), 2)
+	require.NotNil(t, err)
+}
+
+func TestGetBlobsBySlotInvalidProof(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("blob0"), []byte("blob1"))
+	sidecars[0].KzgProof = sidecars[1].KzgProof
+	srv := newTestBeaconServer(t, map[uint64][]*blob.Sidecar{1: sidecars})
+
+	cli, err := NewBeaconClient(srv.URL, time.Second)
+	require.Nil(t, err)
+
+	_, err = cli.GetBlobsBySlot(context.Background(), 1)
+	require.ErrorContains(t, err, "failed to verify KZG proof (index 0)")
+}
+
+func TestGetBlobs(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("blob0"))
+	srv := newTestBeaconServer(t, map[uint64][]*blob.Sidecar{3: sidecars})
+
+	cli, err := NewBeaconClient(srv.URL, time.Second)
+	require.Nil(t, err)
+
+	result, err := cli.GetBlobs(context.Background(), testGenesisTime+3*testSecondsPerSlot)
+	require.Nil(t, err)
+	require.Equal(t, sidecars, result)
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlobArchiveClient fetches the blob sidecars which are past the beacon node's retention window from a blob
+// archive service, which serves them through the same `eth/v1/beacon/blob_sidecars/{slot}` endpoint as the
+// beacon API.
+type BlobArchiveClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewBlobArchiveClient creates a new BlobArchiveClient instance with the given archive URL.
+func NewBlobArchiveClient(endpoint string, timeout time.Duration) (*BlobArchiveClient, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid blob archive endpoint: %w", err)
+	}
+
+	return &BlobArchiveClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// GetBlobsBySlot returns the parsed blobs for a given slot, only the blobs with the given indices will be
+// returned if any indices are specified. Each blob's KZG commitment will be verified against the included
+// KZG proof, and ErrBlobNotFound will be returned if the archive doesn't have the slot.
+func (c *BlobArchiveClient) GetBlobsBySlot(ctx context.Context, slot uint64, indices ...uint64) ([]*Blob, error) {
+	reqURL := c.endpoint + "/" + fmt.Sprintf(sidecarsRequestURL, slot)
+	if len(indices) != 0 {
+		query := url.Values{}
+		for _, index := range indices {
+			query.Add("indices", strconv.FormatUint(index, 10))
+		}
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: slot %d is not in the blob archive", ErrBlobNotFound, slot)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected blob archive response status: %s", res.Status)
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSidecarsResponse(slot, resBytes)
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+var (
+	// naiveBlobFieldElements is the number of field elements in a blob.
+	naiveBlobFieldElements = eth.BlobSize / 32
+	// naiveBlobDataSize is the max data length of a blob encoded by NaiveBlobEncoder, the first field
+	// element is reserved for the data length, and each of the others carries 31 bytes of data.
+	naiveBlobDataSize = (naiveBlobFieldElements - 1) * 31
+)
+
+// BlobEncoder encodes arbitrary data into a blob and decodes it back, each field element of an encoded
+// blob must be less than the BLS12-381 scalar field modulus.
+type BlobEncoder interface {
+	// MaxDataSize returns the max data length a single blob can carry.
+	MaxDataSize() int
+	// Encode encodes the given data into a blob.
+	Encode(data []byte) (*kzg4844.Blob, error)
+	// Decode decodes the given blob back to the original data, an error wrapping ErrBlobInvalid
+	// will be returned if the blob was not produced by this encoder.
+	Decode(blob *kzg4844.Blob) ([]byte, error)
+}
+
+// PackedBlobEncoder is a BlobEncoder which packs 254 bits of data into each field element, it is
+// compatible with the OP Stack blob encoding, and it is the default encoder.
+type PackedBlobEncoder struct{}
+
+// MaxDataSize implements the BlobEncoder interface.
+func (e *PackedBlobEncoder) MaxDataSize() int {
+	return eth.MaxBlobDataSize
+}
+
+// Encode implements the BlobEncoder interface.
+func (e *PackedBlobEncoder) Encode(data []byte) (*kzg4844.Blob, error) {
+	if len(data) > e.MaxDataSize() {
+		return nil, fmt.Errorf("blob data length %d exceeds max %d", len(data), e.MaxDataSize())
+	}
+
+	var blob eth.Blob
+	if err := blob.FromData(data); err != nil {
+		return nil, err
+	}
+
+	return blob.KZGBlob(), nil
+}
+
+// Decode implements the BlobEncoder interface.
+func (e *PackedBlobEncoder) Decode(blob *kzg4844.Blob) ([]byte, error) {
+	data, err := (*eth.Blob)(blob).ToData()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBlobInvalid, err)
+	}
+
+	return data, nil
+}
+
+// NaiveBlobEncoder is a BlobEncoder which only uses the low 31 bytes of each field element, and leaves
+// the high byte zero, the first field element carries the big-endian data length.
+type NaiveBlobEncoder struct{}
+
+// MaxDataSize implements the BlobEncoder interface.
+func (e *NaiveBlobEncoder) MaxDataSize() int {
+	return naiveBlobDataSize
+}
+
+// Encode implements the BlobEncoder interface.
+func (e *NaiveBlobEncoder) Encode(data []byte) (*kzg4844.Blob, error) {
+	if len(data) > e.MaxDataSize() {
+		return nil, fmt.Errorf("blob data length %d exceeds max %d", len(data), e.MaxDataSize())
+	}
+
+	var blob kzg4844.Blob
+	binary.BigEndian.PutUint32(blob[28:32], uint32(len(data)))
+	for i := 1; len(data) > 0; i++ {
+		data = data[copy(blob[i*32+1:(i+1)*32], data):]
+	}
+
+	return &blob, nil
+}
+
+// Decode implements the BlobEncoder interface.
+func (e *NaiveBlobEncoder) Decode(blob *kzg4844.Blob) ([]byte, error) {
+	for i := 0; i < naiveBlobFieldElements; i++ {
+		if blob[i*32] != 0 {
+			return nil, fmt.Errorf("%w: invalid field element %d", ErrBlobInvalid, i)
+		}
+	}
+	for _, b := range blob[1:28] {
+		if b != 0 {
+			return nil, fmt.Errorf("%w: invalid length field element", ErrBlobInvalid)
+		}
+	}
+
+	length := int(binary.BigEndian.Uint32(blob[28:32]))
+	if length > e.MaxDataSize() {
+		return nil, fmt.Errorf("%w: data length %d exceeds max %d", ErrBlobInvalid, length, e.MaxDataSize())
+	}
+
+	data := make([]byte, 0, length)
+	for i := 1; len(data) < length; i++ {
+		data = append(data, blob[i*32+1:i*32+1+min(31, length-len(data))]...)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+// blsModulus is the BLS12-381 scalar field modulus.
+var blsModulus, _ = new(big.Int).SetString(
+	"52435875175126190479447740508185965837690552500527637822603658699938581184513",
+	10,
+)
+
+func TestBlobEncodersRoundTrip(t *testing.T) {
+	for _, encoder := range []BlobEncoder{new(PackedBlobEncoder), new(NaiveBlobEncoder)} {
+		for _, size := range []int{0, 1, 30, 31, 32, 1024, encoder.MaxDataSize()} {
+			for _, data := range [][]byte{make([]byte, size), bytes.Repeat([]byte{0xff}, size)} {
+				_, err := rand.Read(data[:size/2])
+				require.Nil(t, err)
+
+				blob, err := encoder.Encode(data)
+				require.Nil(t, err)
+
+				// Each field element must be less than the field modulus.
+				for i := 0; i < len(blob); i += 32 {
+					require.Equal(t, -1, new(big.Int).SetBytes(blob[i:i+32]).Cmp(blsModulus))
+				}
+				_, err = kzg4844.BlobToCommitment(*blob)
+				require.Nil(t, err)
+
+				decoded, err := DecodeBlobWithEncoder(*blob, encoder)
+				require.Nil(t, err)
+				require.Equal(t, data, decoded)
+			}
+		}
+
+		_, err := encoder.Encode(make([]byte, encoder.MaxDataSize()+1))
+		require.ErrorContains(t, err, "exceeds max")
+	}
+}
+
+func TestNaiveBlobEncoderDecodeInvalid(t *testing.T) {
+	encoder := new(NaiveBlobEncoder)
+
+	blob, err := encoder.Encode([]byte("blob"))
+	require.Nil(t, err)
+
+	// A non-zero high byte.
+	invalid := *blob
+	invalid[64] = 0x01
+	_, err = encoder.Decode(&invalid)
+	require.ErrorIs(t, err, ErrBlobInvalid)
+
+	// A data length larger than the max.
+	invalid = *blob
+	invalid[28] = 0xff
+	_, err = encoder.Decode(&invalid)
+	require.ErrorIs(t, err, ErrBlobInvalid)
+}
+
+func TestMakeSidecarWithEncoder(t *testing.T) {
+	data := bytes.Repeat([]byte{0x01}, 2*naiveBlobDataSize)
+
+	sidecar, err := MakeSidecarWithMultipleBlobsAndEncoder(data, new(NaiveBlobEncoder))
+	require.Nil(t, err)
+	require.Equal(t, 2, len(sidecar.Blobs))
+	require.Nil(t, VerifySidecar(sidecar))
+
+	var decoded []byte
+	for _, blob := range sidecar.Blobs {
+		blobData, err := DecodeBlobWithEncoder(blob, new(NaiveBlobEncoder))
+		require.Nil(t, err)
+		decoded = append(decoded, blobData...)
+	}
+	require.Equal(t, data, decoded)
+
+	sidecar, err = MakeSidecarWithEncoder([]byte("blob"), new(NaiveBlobEncoder))
+	require.Nil(t, err)
+	decoded, err = DecodeBlobWithEncoder(sidecar.Blobs[0], new(NaiveBlobEncoder))
+	require.Nil(t, err)
+	require.Equal(t, []byte("blob"), decoded)
+
+	// The blobs are not compatible between the encoders.
+	_, err = DecodeBlob(sidecar.Blobs[0])
+	require.ErrorIs(t, err, ErrBlobInvalid)
+}
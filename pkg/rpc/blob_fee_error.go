@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrBlobFeeCapTooLow is returned when a blob transaction is rejected because its blob fee cap is
+	// below the current blob base fee, use errors.As with *BlobFeeCapTooLowError to get the required
+	// minimum blob fee cap.
+	ErrBlobFeeCapTooLow = errors.New("blob fee cap too low")
+	// blobFeeCapTooLowErrors are the lowercase substrings of the known node error messages which
+	// signal that the blob fee cap of a transaction is too low.
+	blobFeeCapTooLowErrors = []string{
+		"max fee per blob gas less than block blob gas fee", // geth, reth
+		"insufficientmaxfeeperblobgas",                      // nethermind
+		"blob fee cap too low",
+		"max fee per blob gas too low",
+	}
+	// minBlobFeeCapRegexp extracts the current blob base fee from the node error messages.
+	minBlobFeeCapRegexp = regexp.MustCompile(
+		`(?i)(?:blob ?base ?fee|blob ?gas ?price|blob gas fee)\s*[:=]?\s*(\d+)`,
+	)
+)
+
+// BlobFeeCapTooLowError is the typed error of a blob transaction rejected with a too low blob fee cap.
+type BlobFeeCapTooLowError struct {
+	// BlobFeeCap is the blob fee cap of the rejected transaction.
+	BlobFeeCap *big.Int
+	// MinBlobFeeCap is the minimum blob fee cap required by the node, nil if it can't be extracted
+	// from the error message.
+	MinBlobFeeCap *big.Int
+	// Err is the original error returned by the node.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BlobFeeCapTooLowError) Error() string {
+	if e.MinBlobFeeCap == nil {
+		return fmt.Sprintf("%s: blobFeeCap %v: %v", ErrBlobFeeCapTooLow, e.BlobFeeCap, e.Err)
+	}
+	return fmt.Sprintf(
+		"%s: blobFeeCap %v, required %v: %v",
+		ErrBlobFeeCapTooLow,
+		e.BlobFeeCap,
+		e.MinBlobFeeCap,
+		e.Err,
+	)
+}
+
+// Unwrap returns both ErrBlobFeeCapTooLow and the original error.
+func (e *BlobFeeCapTooLowError) Unwrap() []error {
+	return []error{ErrBlobFeeCapTooLow, e.Err}
+}
+
+// parseBlobFeeCapTooLowErr converts the given error to a *BlobFeeCapTooLowError if it is a known blob
+// fee cap too low rejection, otherwise the error is returned as is.
+func parseBlobFeeCapTooLowErr(err error, blobFeeCap *big.Int) error {
+	if err == nil || !isBlobFeeCapTooLowErr(err) {
+		return err
+	}
+
+	typedErr := &BlobFeeCapTooLowError{BlobFeeCap: blobFeeCap, Err: err}
+	if matches := minBlobFeeCapRegexp.FindStringSubmatch(err.Error()); len(matches) == 2 {
+		if minBlobFeeCap, ok := new(big.Int).SetString(matches[1], 10); ok {
+			typedErr.MinBlobFeeCap = minBlobFeeCap
+		}
+	}
+
+	return typedErr
+}
+
+// isBlobFeeCapTooLowErr returns true if the error signals that the blob fee cap of the transaction is too low.
+func isBlobFeeCapTooLowErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range blobFeeCapTooLowErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlobFeeCapTooLowErr(t *testing.T) {
+	blobFeeCap := big.NewInt(10)
+	for _, tc := range []struct {
+		name   string
+		err    error
+		isType bool
+		min    *big.Int
+	}{
+		{
+			"geth",
+			errors.New(
+				"max fee per blob gas less than block blob gas fee: " +
+					"address 0x0000000000000000000000000000000000000001 blobGasFeeCap: 10, blobBaseFee: 42",
+			),
+			true,
+			big.NewInt(42),
+		},
+		{"geth sentinel", core.ErrBlobFeeCapTooLow, true, nil},
+		{"reth", errors.New("max fee per blob gas less than block blob gas fee"), true, nil},
+		{
+			"nethermind",
+			errors.New("InsufficientMaxFeePerBlobGas: Not enough to cover blob gas fee. BlobGasPrice: 1000000007"),
+			true,
+			big.NewInt(1000000007),
+		},
+		{"underpriced", txpool.ErrReplaceUnderpriced, false, nil},
+		{"nonce too low", core.ErrNonceTooLow, false, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parseBlobFeeCapTooLowErr(tc.err, blobFeeCap)
+			require.Equal(t, tc.isType, errors.Is(err, ErrBlobFeeCapTooLow))
+			require.ErrorIs(t, err, tc.err)
+
+			var typedErr *BlobFeeCapTooLowError
+			require.Equal(t, tc.isType, errors.As(err, &typedErr))
+			if !tc.isType {
+				return
+			}
+			require.Equal(t, blobFeeCap, typedErr.BlobFeeCap)
+			require.Equal(t, tc.min, typedErr.MinBlobFeeCap)
+		})
+	}
+
+	require.Nil(t, parseBlobFeeCapTooLowErr(nil, blobFeeCap))
+}
+
+func TestTransactBlobTxBlobFeeCapTooLow(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	opts.GasLimit = 100_000
+
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getTransactionCount:  func(common.Address) (uint64, error) { return 0, nil },
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+		sendRawTransaction: func(*types.Transaction) error {
+			return errors.New("max fee per blob gas less than block blob gas fee: blobGasFeeCap: 2, blobBaseFee: 5")
+		},
+	})
+
+	_, err = client.TransactBlobTx(opts, common.Address{}, nil, sidecar)
+	require.ErrorIs(t, err, ErrBlobFeeCapTooLow)
+
+	var typedErr *BlobFeeCapTooLowError
+	require.ErrorAs(t, err, &typedErr)
+	require.Equal(t, big.NewInt(2), typedErr.BlobFeeCap)
+	require.Equal(t, big.NewInt(5), typedErr.MinBlobFeeCap)
+}
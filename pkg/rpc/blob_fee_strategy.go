@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlobFeeStrategy is the blob fee bidding strategy of a blob transaction, it scales the computed blob fee cap,
+// so that the urgent proposals can bid higher than the others.
+type BlobFeeStrategy int
+
+const (
+	// BlobFeeStrategyNormal keeps the computed blob fee cap.
+	BlobFeeStrategyNormal BlobFeeStrategy = iota
+	// BlobFeeStrategyAggressive doubles the computed blob fee cap.
+	BlobFeeStrategyAggressive
+	// BlobFeeStrategyEconomical halves the computed blob fee cap, but never bids below the blob base fee.
+	BlobFeeStrategyEconomical
+)
+
+// blobFeeStrategyMultipliers are the multipliers of the blob fee strategies, in percentage.
+var blobFeeStrategyMultipliers = map[BlobFeeStrategy]int64{
+	BlobFeeStrategyNormal:     100,
+	BlobFeeStrategyAggressive: 200,
+	BlobFeeStrategyEconomical: 50,
+}
+
+// String implements the fmt.Stringer interface.
+func (s BlobFeeStrategy) String() string {
+	switch s {
+	case BlobFeeStrategyNormal:
+		return "normal"
+	case BlobFeeStrategyAggressive:
+		return "aggressive"
+	case BlobFeeStrategyEconomical:
+		return "economical"
+	default:
+		return "unknown"
+	}
+}
+
+// scaleBlobFeeCap scales the given blob fee cap by the multiplier of the strategy, the scaled blob fee cap is
+// no lower than the given blob base fee. An unknown strategy is treated as BlobFeeStrategyNormal.
+func (s BlobFeeStrategy) scaleBlobFeeCap(blobFeeCap *big.Int, blobBaseFee *big.Int) *big.Int {
+	multiplier, ok := blobFeeStrategyMultipliers[s]
+	if !ok {
+		multiplier = blobFeeStrategyMultipliers[BlobFeeStrategyNormal]
+	}
+
+	scaled := new(big.Int).Mul(blobFeeCap, big.NewInt(multiplier))
+	scaled.Div(scaled, big.NewInt(100))
+	if scaled.Cmp(blobBaseFee) < 0 {
+		scaled.Set(blobBaseFee)
+	}
+
+	return scaled
+}
+
+// TransactBlobTxWithStrategy creates, signs and then sends blob transactions like TransactBlobTx, with
+// the blob fee cap scaled by the given blob fee strategy.
+func (c *EthClient) TransactBlobTxWithStrategy(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+	strategy BlobFeeStrategy,
+) (*types.Transaction, error) {
+	return c.transactBlobTx(opts, contract, input, sidecar, strategy)
+}
+
+// CreateBlobTxWithStrategy creates a blob transaction like CreateBlobTx, with the blob fee cap scaled by
+// the given blob fee strategy.
+func (c *EthClient) CreateBlobTxWithStrategy(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+	strategy BlobFeeStrategy,
+) (*types.BlobTx, error) {
+	return c.createBlobTx(opts, contract, input, sidecar, strategy)
+}
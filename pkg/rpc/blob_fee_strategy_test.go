@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateBlobTxFeesStrategy(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return big.NewInt(100), nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+	})
+	opts := &bind.TransactOpts{Context: context.Background()}
+
+	// The computed blob fee cap is doubled from the blob base fee, and then scaled by the strategy.
+	for strategy, expected := range map[BlobFeeStrategy]int64{
+		BlobFeeStrategyNormal:     200,
+		BlobFeeStrategyAggressive: 400,
+		BlobFeeStrategyEconomical: 100,
+		BlobFeeStrategy(100):      200,
+	} {
+		fees, err := client.estimateBlobTxFees(opts, strategy)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(100), fees.BlobBaseFee, strategy.String())
+		assert.Equal(t, big.NewInt(expected), fees.BlobFeeCap, strategy.String())
+	}
+
+	// The economical strategy never bids below the blob base fee.
+	client.BlobFeeCapMultiplier = common.Big1
+	fees, err := client.estimateBlobTxFees(opts, BlobFeeStrategyEconomical)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), fees.BlobFeeCap)
+
+	// The scaled blob fee cap is still capped by the ceiling.
+	client.BlobFeeCapMultiplier = nil
+	client.MaxBlobFeeCap = big.NewInt(300)
+	fees, err = client.estimateBlobTxFees(opts, BlobFeeStrategyAggressive)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(300), fees.BlobFeeCap)
+}
+
+func TestTransactBlobTxWithStrategy(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return big.NewInt(10), nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+	})
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0, GasLimit: 100_000, NoSend: true}
+
+	tx, err := client.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(20), tx.BlobGasFeeCap())
+
+	tx, err = client.TransactBlobTxWithStrategy(
+		opts,
+		common.HexToAddress("0x02"),
+		nil,
+		sidecar,
+		BlobFeeStrategyAggressive,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(40), tx.BlobGasFeeCap())
+
+	blobTx, err := client.CreateBlobTxWithStrategy(
+		opts,
+		common.HexToAddress("0x02"),
+		nil,
+		sidecar,
+		BlobFeeStrategyEconomical,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), blobTx.BlobFeeCap.ToBig())
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// blobFeeHysteresisPercent is the width of the hysteresis band below the threshold in percent, the blob
+	// base fee has to drop below `threshold * (100 - blobFeeHysteresisPercent) / 100` to cross below again,
+	// to avoid flapping around the threshold.
+	blobFeeHysteresisPercent int64 = 10
+	// blobFeeEventBufferSize is the buffer size of the blob base fee events channel.
+	blobFeeEventBufferSize = 16
+)
+
+// BlobFeeEvent represents a blob base fee threshold crossing.
+type BlobFeeEvent struct {
+	// Above is true if the blob base fee has crossed above the threshold, otherwise it has crossed below.
+	Above       bool
+	BlobBaseFee *big.Int
+	Header      *types.Header
+}
+
+// WatchBlobBaseFee samples the blob base fee of each new block, and emits an event when it crosses above
+// the given threshold, or drops back below the threshold by more than the 10% hysteresis band. The watcher
+// starts in the below state, so the first sample above the threshold will be emitted, and the returned
+// channel will be closed once the given context is done.
+func (c *EthClient) WatchBlobBaseFee(ctx context.Context, threshold *big.Int) (<-chan *BlobFeeEvent, error) {
+	if threshold == nil || threshold.Sign() <= 0 {
+		return nil, errors.New("blob base fee threshold must be positive")
+	}
+
+	var (
+		headCh  = make(chan *types.Header, chainHeadBufferSize)
+		ch      = make(chan *BlobFeeEvent, blobFeeEventBufferSize)
+		sub     = SubscribeChainHead(c, headCh)
+		crosser = newBlobFeeCrosser(threshold)
+	)
+
+	go func() {
+		defer close(ch)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case head := <-headCh:
+				blobBaseFee, err := c.blobBaseFeeOf(ctx, head)
+				if err != nil {
+					log.Warn("Failed to fetch the blob base fee", "number", head.Number, "error", err)
+					continue
+				}
+				above, crossed := crosser.update(blobBaseFee)
+				if !crossed {
+					continue
+				}
+				select {
+				case ch <- &BlobFeeEvent{Above: above, BlobBaseFee: blobBaseFee, Header: head}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// blobFeeCrosser detects the threshold crossings of the blob base fee, with a hysteresis band below
+// the threshold.
+type blobFeeCrosser struct {
+	threshold *big.Int
+	lower     *big.Int
+	above     bool
+}
+
+// newBlobFeeCrosser creates a new blobFeeCrosser instance with the given threshold.
+func newBlobFeeCrosser(threshold *big.Int) *blobFeeCrosser {
+	lower := new(big.Int).Mul(threshold, big.NewInt(100-blobFeeHysteresisPercent))
+	return &blobFeeCrosser{threshold: threshold, lower: lower.Div(lower, big.NewInt(100))}
+}
+
+// update updates the state with the given blob base fee, and returns the new state and whether
+// the threshold has been crossed.
+func (c *blobFeeCrosser) update(blobBaseFee *big.Int) (bool, bool) {
+	switch {
+	case !c.above && blobBaseFee.Cmp(c.threshold) > 0:
+		c.above = true
+	case c.above && blobBaseFee.Cmp(c.lower) < 0:
+		c.above = false
+	default:
+		return c.above, false
+	}
+
+	return c.above, true
+}
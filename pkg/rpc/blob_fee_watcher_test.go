@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchBlobBaseFee(t *testing.T) {
+	var (
+		service = &testEthService{newHeads: make(chan *types.Header)}
+		client  = newTestEthClient(t, service)
+		// The blob base fees of the synthetic headers are decided by their excess blob gas.
+		threshold = eip4844.CalcBlobFee(10_000_000)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := client.WatchBlobBaseFee(ctx, threshold)
+	require.Nil(t, err)
+
+	excessBlobGases := []uint64{
+		0,
+		10_000_000, // Equal to the threshold, not crossed.
+		10_500_000, // Crossed above.
+		11_000_000,
+		9_900_000, // Within the hysteresis band, not crossed.
+		10_500_000,
+		5_000_000, // Crossed below.
+		9_900_000,
+		12_000_000, // Crossed above.
+	}
+	go func() {
+		for i := range excessBlobGases {
+			header := newTestHeader(uint64(i + 1))
+			header.ExcessBlobGas = &excessBlobGases[i]
+			select {
+			case service.newHeads <- header:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for _, expected := range []struct {
+		number uint64
+		above  bool
+	}{{3, true}, {7, false}, {9, true}} {
+		select {
+		case e := <-ch:
+			require.Equal(t, expected.number, e.Header.Number.Uint64())
+			require.Equal(t, expected.above, e.Above)
+			require.Equal(t, eip4844.CalcBlobFee(*e.Header.ExcessBlobGas), e.BlobBaseFee)
+		case <-time.After(5 * time.Second):
+			t.Fatal("blob base fee event not received")
+		}
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = client.WatchBlobBaseFee(context.Background(), big.NewInt(0))
+	require.NotNil(t, err)
+}
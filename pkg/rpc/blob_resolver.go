@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+)
+
+var (
+	// ErrBlobNotFound is returned when neither the beacon node nor the blob archive has the requested blob,
+	// e.g. it has been pruned by the beacon node since it is past the retention window.
+	ErrBlobNotFound = errors.New("blob not found")
+)
+
+// BlobResolver maps the blob versioned hashes recorded on L1 back to the blob data on the beacon chain, the
+// blobs which are no longer retained by the beacon node will be fetched from the blob archive if it is set.
+type BlobResolver struct {
+	l1      *EthClient
+	beacon  *BeaconClient
+	archive *BlobArchiveClient
+}
+
+// NewBlobResolver creates a new BlobResolver instance, the blob archive is optional.
+func NewBlobResolver(l1 *EthClient, beacon *BeaconClient, archive *BlobArchiveClient) *BlobResolver {
+	return &BlobResolver{l1: l1, beacon: beacon, archive: archive}
+}
+
+// Resolve returns the blob whose KZG commitment hashes to the given versioned hash, the blob
+// should be included in the given L1 block.
+func (r *BlobResolver) Resolve(ctx context.Context, blobHash common.Hash, l1Height uint64) (*Blob, error) {
+	header, err := r.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(l1Height))
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := r.beacon.timeToSlot(header.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := r.getBlobsBySlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Fetched blobs", "l1Height", l1Height, "slot", slot, "blobs", len(blobs))
+
+	for _, blob := range blobs {
+		if kzg4844.CalcBlobHashV1(sha256.New(), &blob.KZGCommitment) == blobHash {
+			return blob, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: blob hash %s, slot %d", ErrBlobNotFound, blobHash, slot)
+}
+
+// getBlobsBySlot fetches the blobs of the given slot from the beacon node, and falls through to the blob
+// archive if the beacon node no longer retains the slot.
+func (r *BlobResolver) getBlobsBySlot(ctx context.Context, slot uint64) ([]*Blob, error) {
+	blobs, err := r.beacon.GetBlobsBySlot(ctx, slot)
+	if err == nil {
+		return blobs, nil
+	}
+	if !errors.Is(err, client.ErrNotFound) {
+		return nil, err
+	}
+	if r.archive == nil {
+		return nil, fmt.Errorf("%w: slot %d is not retained by the beacon node", ErrBlobNotFound, slot)
+	}
+
+	log.Debug("Slot is not retained by the beacon node, fetching from the blob archive", "slot", slot)
+	return r.archive.GetBlobsBySlot(ctx, slot)
+}
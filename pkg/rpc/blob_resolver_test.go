@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/blob"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlobResolver(t *testing.T, sidecars map[uint64][]*blob.Sidecar) *BlobResolver {
+	return newTestBlobResolverWithArchive(t, sidecars, nil)
+}
+
+// newTestBlobResolverWithArchive creates a BlobResolver whose blob archive serves the given archived sidecars.
+func newTestBlobResolverWithArchive(
+	t *testing.T,
+	sidecars map[uint64][]*blob.Sidecar,
+	archived map[uint64][]*blob.Sidecar,
+) *BlobResolver {
+	beacon, err := NewBeaconClient(newTestBeaconServer(t, sidecars).URL, time.Second)
+	require.Nil(t, err)
+
+	var archive *BlobArchiveClient
+	if archived != nil {
+		// The archive serves the same blob sidecars endpoint as the beacon API.
+		archive, err = NewBlobArchiveClient(newTestBeaconServer(t, archived).URL+"/", time.Second)
+		require.Nil(t, err)
+	}
+
+	l1 := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			header := newTestHeader(uint64(number))
+			// Each L1 block is proposed at the slot with the same number.
+			header.Time = testGenesisTime + uint64(number)*testSecondsPerSlot
+			return header, nil
+		},
+	})
+
+	return NewBlobResolver(l1, beacon, archive)
+}
+
+func TestBlobResolverResolve(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("blob0"), []byte("blob1"))
+	resolver := newTestBlobResolver(t, map[uint64][]*blob.Sidecar{10: sidecars})
+
+	commitment := kzg4844.Commitment(hexutil.MustDecode(sidecars[1].KzgCommitment))
+	blobHash := kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+
+	b, err := resolver.Resolve(context.Background(), blobHash, 10)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), b.Index)
+
+	data, err := DecodeBlob(b.Blob)
+	require.Nil(t, err)
+	require.Equal(t, "blob1", string(data))
+
+	// Not included in the given L1 block.
+	_, err = resolver.Resolve(context.Background(), common.Hash{}, 10)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestBlobResolverNotRetained(t *testing.T) {
+	resolver := newTestBlobResolver(t, map[uint64][]*blob.Sidecar{})
+
+	_, err := resolver.Resolve(context.Background(), common.Hash{}, 10)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestBlobResolverArchive(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("archived"))
+	resolver := newTestBlobResolverWithArchive(
+		t,
+		map[uint64][]*blob.Sidecar{},
+		map[uint64][]*blob.Sidecar{10: sidecars},
+	)
+
+	commitment := kzg4844.Commitment(hexutil.MustDecode(sidecars[0].KzgCommitment))
+	b, err := resolver.Resolve(context.Background(), kzg4844.CalcBlobHashV1(sha256.New(), &commitment), 10)
+	require.Nil(t, err)
+	data, err := DecodeBlob(b.Blob)
+	require.Nil(t, err)
+	require.Equal(t, "archived", string(data))
+
+	// Neither the beacon node nor the archive has the slot.
+	_, err = resolver.Resolve(context.Background(), common.Hash{}, 11)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestBlobArchiveClientGetBlobsBySlot(t *testing.T) {
+	sidecars := newTestSidecars(t, []byte("blob0"), []byte("blob1"))
+	archive, err := NewBlobArchiveClient(
+		newTestBeaconServer(t, map[uint64][]*blob.Sidecar{10: sidecars}).URL,
+		time.Second,
+	)
+	require.Nil(t, err)
+
+	blobs, err := archive.GetBlobsBySlot(context.Background(), 10, 1)
+	require.Nil(t, err)
+	require.Len(t, blobs, 1)
+	require.Equal(t, uint64(1), blobs[0].Index)
+
+	// The KZG proofs served by the archive should be verified.
+	sidecars[1].KzgProof = sidecars[0].KzgProof
+	_, err = archive.GetBlobsBySlot(context.Background(), 10)
+	require.ErrorContains(t, err, "failed to verify KZG proof")
+
+	_, err = archive.GetBlobsBySlot(context.Background(), 11)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+
+	_, err = NewBlobArchiveClient("invalid", time.Second)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+var (
+	errNotBlobTx = errors.New("not a blob transaction")
+)
+
+// rpcBlobSidecar is the blob sidecar of a transaction returned by the `eth_getBlobSidecars` RPC method.
+type rpcBlobSidecar struct {
+	BlobSidecar struct {
+		Blobs       []kzg4844.Blob       `json:"blobs"`
+		Commitments []kzg4844.Commitment `json:"commitments"`
+		Proofs      []kzg4844.Proof      `json:"proofs"`
+	} `json:"blobSidecar"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// BlobSidecarByTxHash fetches the blob sidecar of the given included blob transaction from the
+// execution layer node through the `eth_getBlobSidecars` RPC method, and verifies it against the
+// transaction's blob hashes. If the node doesn't have the sidecar anymore, e.g. it has been pruned,
+// an error wrapping ErrBlobNotFound will be returned.
+func (c *EthClient) BlobSidecarByTxHash(ctx context.Context, txHash common.Hash) (*types.BlobTxSidecar, error) {
+	tx, _, err := c.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type() != types.BlobTxType {
+		return nil, fmt.Errorf("%w: %s", errNotBlobTx, txHash)
+	}
+
+	receipt, err := c.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecars, err := c.blobSidecars(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sidecars {
+		if s.TxHash != txHash {
+			continue
+		}
+
+		sidecar := &types.BlobTxSidecar{
+			Blobs:       s.BlobSidecar.Blobs,
+			Commitments: s.BlobSidecar.Commitments,
+			Proofs:      s.BlobSidecar.Proofs,
+		}
+		if err := verifySidecarOfTx(sidecar, tx); err != nil {
+			return nil, err
+		}
+
+		return sidecar, nil
+	}
+
+	return nil, fmt.Errorf("%w: transaction %s, block %s", ErrBlobNotFound, txHash, receipt.BlockHash)
+}
+
+// blobSidecars fetches all the blob sidecars included in the given block.
+func (c *EthClient) blobSidecars(ctx context.Context, blockHash common.Hash) (sidecars []*rpcBlobSidecar, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("BlobSidecars", time.Now(), &err)
+
+	err = c.CallContext(ctxWithTimeout, &sidecars, "eth_getBlobSidecars", blockHash)
+	return sidecars, err
+}
+
+// verifySidecarOfTx checks whether the given sidecar is valid, and its commitments match the blob
+// hashes of the given transaction.
+func verifySidecarOfTx(sidecar *types.BlobTxSidecar, tx *types.Transaction) error {
+	if err := VerifySidecar(sidecar); err != nil {
+		return err
+	}
+
+	blobHashes := tx.BlobHashes()
+	if len(blobHashes) != len(sidecar.Commitments) {
+		return fmt.Errorf(
+			"mismatched blobs count of transaction %s: have %d, want %d",
+			tx.Hash(),
+			len(sidecar.Commitments),
+			len(blobHashes),
+		)
+	}
+	for i, commitment := range sidecar.Commitments {
+		if hash := CommitmentToVersionedHash(commitment); hash != blobHashes[i] {
+			return fmt.Errorf("mismatched blob hash %d of transaction %s: have %s, want %s", i, tx.Hash(), hash, blobHashes[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sidecarCache is the optional LRU cache of the sidecars made by MakeSidecar, keyed by the hash of the
+// blob data, it is disabled if nil.
+var sidecarCache atomic.Pointer[lru.Cache[common.Hash, *types.BlobTxSidecar]]
+
+// SetSidecarCacheSize enables caching the sidecars made by MakeSidecar with at most the given number of
+// entries, so that retrying with the same blob data won't recompute the KZG commitments and proofs, a
+// zero size disables the cache. It is safe for concurrent use, and resets the cached sidecars.
+func SetSidecarCacheSize(maxEntries int) {
+	if maxEntries <= 0 {
+		sidecarCache.Store(nil)
+		return
+	}
+
+	sidecarCache.Store(lru.NewCache[common.Hash, *types.BlobTxSidecar](maxEntries))
+}
+
+// makeCachedSidecar makes a sidecar which only includes one blob with the given data packed by
+// PackedBlobEncoder, the sidecar cache will be used if it is enabled.
+func makeCachedSidecar(data []byte) (*types.BlobTxSidecar, error) {
+	cache := sidecarCache.Load()
+	if cache == nil {
+		return MakeSidecarWithEncoder(data, new(PackedBlobEncoder))
+	}
+
+	key := crypto.Keccak256Hash(data)
+	if sidecar, ok := cache.Get(key); ok {
+		return copySidecar(sidecar), nil
+	}
+
+	sidecar, err := MakeSidecarWithEncoder(data, new(PackedBlobEncoder))
+	if err != nil {
+		return nil, err
+	}
+	cache.Add(key, copySidecar(sidecar))
+
+	return sidecar, nil
+}
+
+// copySidecar returns a copy of the given sidecar, so that the callers can't modify the cached ones.
+func copySidecar(sidecar *types.BlobTxSidecar) *types.BlobTxSidecar {
+	return &types.BlobTxSidecar{
+		Blobs:       slices.Clone(sidecar.Blobs),
+		Commitments: slices.Clone(sidecar.Commitments),
+		Proofs:      slices.Clone(sidecar.Proofs),
+	}
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeSidecarCache(t *testing.T) {
+	SetSidecarCacheSize(2)
+	defer SetSidecarCacheSize(0)
+
+	data := bytes.Repeat([]byte{0x01}, 1024)
+	sidecar, err := MakeSidecar(data)
+	require.Nil(t, err)
+	require.Equal(t, 1, sidecarCache.Load().Len())
+
+	// A cache hit should return an equal sidecar.
+	cached, err := MakeSidecar(bytes.Clone(data))
+	require.Nil(t, err)
+	require.Equal(t, sidecar, cached)
+	require.Nil(t, VerifySidecar(cached))
+	require.Equal(t, 1, sidecarCache.Load().Len())
+
+	// Modifying the returned sidecar should not affect the cached one.
+	cached.Proofs[0][10] ^= 0xff
+	cached, err = MakeSidecar(data)
+	require.Nil(t, err)
+	require.Equal(t, sidecar, cached)
+
+	// Different data should not hit the cache.
+	other, err := MakeSidecar([]byte{0x02})
+	require.Nil(t, err)
+	require.NotEqual(t, sidecar.Commitments, other.Commitments)
+	require.Equal(t, 2, sidecarCache.Load().Len())
+
+	// The cache is bounded.
+	_, err = MakeSidecar([]byte{0x03})
+	require.Nil(t, err)
+	require.Equal(t, 2, sidecarCache.Load().Len())
+
+	SetSidecarCacheSize(0)
+	require.Nil(t, sidecarCache.Load())
+	uncached, err := MakeSidecar(data)
+	require.Nil(t, err)
+	require.Equal(t, sidecar, uncached)
+}
+
+func BenchmarkMakeSidecar(b *testing.B) {
+	data := bytes.Repeat([]byte{0x01}, 64*1024)
+
+	b.Run("uncached", func(b *testing.B) {
+		SetSidecarCacheSize(0)
+		for i := 0; i < b.N; i++ {
+			if _, err := MakeSidecar(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// The cache hits skip both BlobToCommitment and ComputeBlobProof.
+	b.Run("cached", func(b *testing.B) {
+		SetSidecarCacheSize(16)
+		defer SetSidecarCacheSize(0)
+		for i := 0; i < b.N; i++ {
+			if _, err := MakeSidecar(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
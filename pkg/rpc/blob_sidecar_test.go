@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlobSidecarClient(
+	t *testing.T,
+	tx *types.Transaction,
+	sidecars []*rpcBlobSidecar,
+) *EthClient {
+	blockHash := common.HexToHash("0x01")
+
+	return newTestEthClient(t, &testEthService{
+		getTransactionByHash: func(common.Hash) (*types.Transaction, error) { return tx.WithoutBlobTxSidecar(), nil },
+		getReceipt: func(hash common.Hash) (*types.Receipt, error) {
+			return &types.Receipt{
+				Status:    types.ReceiptStatusSuccessful,
+				TxHash:    hash,
+				BlockHash: blockHash,
+				Logs:      []*types.Log{},
+			}, nil
+		},
+		getBlobSidecars: func(hash common.Hash) ([]*rpcBlobSidecar, error) {
+			require.Equal(t, blockHash, hash)
+			return sidecars, nil
+		},
+	})
+}
+
+func newTestRPCBlobSidecar(txHash common.Hash, sidecar *types.BlobTxSidecar) *rpcBlobSidecar {
+	s := &rpcBlobSidecar{TxHash: txHash}
+	s.BlobSidecar.Blobs = sidecar.Blobs
+	s.BlobSidecar.Commitments = sidecar.Commitments
+	s.BlobSidecar.Proofs = sidecar.Proofs
+
+	return s
+}
+
+func TestBlobSidecarByTxHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+	client := newTestBlobSidecarClient(t, tx, []*rpcBlobSidecar{
+		newTestRPCBlobSidecar(common.HexToHash("0x02"), &types.BlobTxSidecar{}),
+		newTestRPCBlobSidecar(tx.Hash(), tx.BlobTxSidecar()),
+	})
+
+	sidecar, err := client.BlobSidecarByTxHash(context.Background(), tx.Hash())
+	require.Nil(t, err)
+	require.Equal(t, tx.BlobHashes(), sidecar.BlobHashes())
+
+	data, err := DecodeBlob(sidecar.Blobs[0])
+	require.Nil(t, err)
+	require.Equal(t, "blob", string(data))
+}
+
+func TestBlobSidecarByTxHashPruned(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+	client := newTestBlobSidecarClient(t, tx, nil)
+
+	_, err = client.BlobSidecarByTxHash(context.Background(), tx.Hash())
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestBlobSidecarByTxHashMismatched(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+	other, err := MakeSidecar([]byte("other blob"))
+	require.Nil(t, err)
+
+	client := newTestBlobSidecarClient(t, tx, []*rpcBlobSidecar{newTestRPCBlobSidecar(tx.Hash(), other)})
+	_, err = client.BlobSidecarByTxHash(context.Background(), tx.Hash())
+	require.ErrorContains(t, err, "mismatched blob hash 0")
+}
+
+func TestBlobSidecarByTxHashNotBlobTx(t *testing.T) {
+	tx := newTestSignedTx(t, 1)
+	client := newTestEthClient(t, &testEthService{
+		getTransactionByHash: func(common.Hash) (*types.Transaction, error) { return tx, nil },
+	})
+
+	_, err := client.BlobSidecarByTxHash(context.Background(), tx.Hash())
+	require.ErrorIs(t, err, errNotBlobTx)
+}
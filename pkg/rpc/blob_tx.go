@@ -1,52 +1,153 @@
 package rpc
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"runtime"
+	"sync/atomic"
 
-	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
 	ErrBlobInvalid = errors.New("invalid blob encoding")
+	// ErrBlobFeeCapCeilingExceeded is returned when the current blob base fee exceeds MaxBlobFeeCap, so
+	// the callers can defer sending the blob transaction until the blob fee drops.
+	ErrBlobFeeCapCeilingExceeded = errors.New("blob base fee exceeds the blob fee cap ceiling")
+	// ErrBlobTxSignerMismatch is returned when the signed blob transaction doesn't recover to the sender
+	// with the Cancun signer, e.g. it is signed by a signer of another transaction type or chain.
+	ErrBlobTxSignerMismatch = errors.New("blob transaction signer mismatch")
+	// errNoSigner is returned when there is no signer in the transact options of a transaction to be sent.
+	errNoSigner = errors.New("no signer to authorize the transaction with")
+	// MaxBlobsPerTx is the maximum number of blobs which can be carried by a single
+	// EIP-4844 transaction.
+	MaxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+	// defaultBlobFeeCapMultiplier is the default multiplier applied to the current blob fee,
+	// to leave a buffer for the excess blob gas rising before the transaction gets included.
+	defaultBlobFeeCapMultiplier = big.NewInt(2)
+	// defaultGasFeeCapMultiplier is the default multiplier applied to the base fee when
+	// calculating the gasFeeCap.
+	defaultGasFeeCapMultiplier = big.NewInt(2)
+	// defaultGasLimitMultiplier is the default multiplier applied to the estimated gas limit,
+	// to leave a safety margin for the underestimations.
+	defaultGasLimitMultiplier = 1.2
+	// sidecarParallelism is the max number of blobs processed concurrently when making a sidecar,
+	// see SetSidecarParallelism.
+	sidecarParallelism atomic.Int64
 )
 
-// TransactBlobTx creates, signs and then sends blob transactions.
+// TransactBlobTx creates, signs and then sends blob transactions, the given sidecar can carry
+// multiple blobs, see MakeSidecarWithMultipleBlobs. Since the KZG commitments and proofs are
+// computed when making the sidecar, callers can reuse the same sidecar when resubmitting
+// the transaction with different fee caps. If the transaction is rejected because of a too low blob fee
+// cap, a *BlobFeeCapTooLowError will be returned. If the given options carry no signer and NoSend is set,
+// it's a dry run, and the fully populated but unsigned transaction will be returned instead.
 func (c *EthClient) TransactBlobTx(
 	opts *bind.TransactOpts,
 	contract common.Address,
 	input []byte,
 	sidecar *types.BlobTxSidecar,
+) (*types.Transaction, error) {
+	return c.transactBlobTx(opts, contract, input, sidecar, BlobFeeStrategyNormal)
+}
+
+// transactBlobTx creates, signs and then sends blob transactions with the given blob fee strategy.
+func (c *EthClient) transactBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+	strategy BlobFeeStrategy,
 ) (*types.Transaction, error) {
 	// Sign the transaction and schedule it for execution
-	if opts.Signer == nil {
-		return nil, errors.New("no signer to authorize the transaction with")
+	if opts.Signer == nil && !opts.NoSend {
+		return nil, errNoSigner
+	}
+	if c.VerifySidecars {
+		if err := VerifySidecar(sidecar); err != nil {
+			return nil, err
+		}
 	}
 	// Create blob tx
-	blobTx, err := c.CreateBlobTx(opts, contract, input, sidecar)
+	blobTx, err := c.createBlobTx(opts, contract, input, sidecar, strategy)
 	if err != nil {
 		return nil, err
 	}
+	// The dry-run transaction is never sent.
+	if opts.Signer == nil {
+		c.releaseNonce(opts.From, blobTx.Nonce)
+		return types.NewTx(blobTx), nil
+	}
 	signedTx, err := opts.Signer(opts.From, types.NewTx(blobTx))
 	if err != nil {
+		c.releaseNonce(opts.From, blobTx.Nonce)
+		return nil, err
+	}
+	if err := c.checkBlobTxSigner(signedTx, opts.From); err != nil {
+		c.releaseNonce(opts.From, blobTx.Nonce)
 		return nil, err
 	}
 	if opts.NoSend {
 		return signedTx, nil
 	}
-	if err := c.SendTransaction(opts.Context, signedTx); err != nil {
-		return nil, err
+	if err := c.sendBlobTx(opts.Context, signedTx); err != nil {
+		c.releaseNonce(opts.From, signedTx.Nonce())
+		return nil, parseBlobFeeCapTooLowErr(err, signedTx.BlobGasFeeCap())
 	}
+	c.markNonceSent(opts.From, signedTx.Nonce())
 	return signedTx, nil
 }
 
+// sendBlobTx sends the given signed blob transaction, it is broadcast to the BroadcastEndpoints too if
+// they are set.
+func (c *EthClient) sendBlobTx(ctx context.Context, signedTx *types.Transaction) error {
+	if len(c.BroadcastEndpoints) != 0 {
+		return c.BroadcastTransaction(ctx, signedTx, nil)
+	}
+
+	return c.SendTransactionWithRetry(ctx, signedTx, nil)
+}
+
+// checkBlobTxSigner makes sure the given signed blob transaction recovers to the given sender with the
+// Cancun signer, otherwise the transaction would be rejected by the node for an invalid signature.
+func (c *EthClient) checkBlobTxSigner(signedTx *types.Transaction, from common.Address) error {
+	sender, err := types.Sender(types.NewCancunSigner(c.ChainID), signedTx)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed to recover the sender with the Cancun signer (chainID: %v): %v",
+			ErrBlobTxSignerMismatch,
+			c.ChainID,
+			err,
+		)
+	}
+	if sender != from {
+		return fmt.Errorf(
+			"%w: recovered %s with the Cancun signer (chainID: %v), expected %s, "+
+				"make sure the signer signs the blob transaction hash with types.NewCancunSigner",
+			ErrBlobTxSignerMismatch,
+			sender,
+			c.ChainID,
+			from,
+		)
+	}
+
+	return nil
+}
+
 // CreateBlobTx creates a blob transaction by given parameters.
 func (c *EthClient) CreateBlobTx(
 	opts *bind.TransactOpts,
@@ -54,84 +155,486 @@ func (c *EthClient) CreateBlobTx(
 	input []byte,
 	sidecar *types.BlobTxSidecar,
 ) (*types.BlobTx, error) {
+	return c.createBlobTx(opts, contract, input, sidecar, BlobFeeStrategyNormal)
+}
+
+// createBlobTx creates a blob transaction by given parameters and blob fee strategy.
+func (c *EthClient) createBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+	strategy BlobFeeStrategy,
+) (_ *types.BlobTx, err error) {
+	if err := checkSidecarShape(sidecar); err != nil {
+		return nil, err
+	}
+	if err := c.checkChainID(opts.Context); err != nil {
+		return nil, err
+	}
+
 	// Fetch the nonce for the account
-	var (
-		nonce *hexutil.Uint64
-		gas   *hexutil.Uint64
-	)
+	var nonce *hexutil.Uint64
 	if opts.Nonce != nil {
 		curNonce := hexutil.Uint64(opts.Nonce.Uint64())
 		nonce = &curNonce
+	} else if c.NonceTracker != nil {
+		nextNonce, nonceErr := c.NextNonce(opts.Context, opts.From)
+		if nonceErr != nil {
+			return nil, nonceErr
+		}
+		// The reserved nonce is released if the transaction can't be created.
+		defer func() {
+			if err != nil {
+				c.NonceTracker.Release(opts.From, nextNonce)
+			}
+		}()
+		curNonce := hexutil.Uint64(nextNonce)
+		nonce = &curNonce
 	}
 
 	if input == nil {
 		input = []byte{}
 	}
 
-	if opts.GasLimit != 0 {
-		gasVal := hexutil.Uint64(opts.GasLimit)
-		gas = &gasVal
+	fees, err := c.estimateBlobTxFees(opts, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Estimate the gas limit before filling the transaction, so that the transient failures can be retried.
+	estimatedGas, accessList, err := c.estimateBlobTxGas(opts, contract, input, fees, sidecar.BlobHashes())
+	if err != nil {
+		return nil, err
 	}
+	gas := hexutil.Uint64(estimatedGas)
 
 	rawTx, err := c.FillTransaction(opts.Context, &TransactionArgs{
 		From:                 &opts.From,
 		To:                   &contract,
-		Gas:                  gas,
+		Gas:                  &gas,
 		GasPrice:             (*hexutil.Big)(opts.GasPrice),
-		MaxFeePerGas:         (*hexutil.Big)(opts.GasFeeCap),
-		MaxPriorityFeePerGas: (*hexutil.Big)(opts.GasTipCap),
+		MaxFeePerGas:         (*hexutil.Big)(fees.GasFeeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(fees.GasTipCap),
 		Value:                (*hexutil.Big)(opts.Value),
 		Nonce:                nonce,
 		Data:                 (*hexutil.Bytes)(&input),
-		AccessList:           nil,
+		AccessList:           accessList,
 		ChainID:              nil,
-		BlobFeeCap:           nil,
+		BlobFeeCap:           (*hexutil.Big)(fees.BlobFeeCap),
 		BlobHashes:           sidecar.BlobHashes(),
 	})
 	if err != nil {
 		return nil, err
 	}
-
-	blobFeeCap := rawTx.BlobGasFeeCap()
-	if blobFeeCap == nil || blobFeeCap.Uint64() < params.BlobTxMinBlobGasprice {
-		blobFeeCap = new(big.Int).SetUint64(uint64(params.BlobTxMinBlobGasprice))
+	if err := c.compareChainID(rawTx.ChainId()); err != nil {
+		return nil, err
 	}
 
+	gasLimit := c.blobTxGasLimit(opts, rawTx.Gas(), fees)
+
 	return &types.BlobTx{
 		ChainID:    uint256.MustFromBig(rawTx.ChainId()),
 		Nonce:      rawTx.Nonce(),
 		GasTipCap:  uint256.MustFromBig(rawTx.GasTipCap()),
 		GasFeeCap:  uint256.MustFromBig(rawTx.GasFeeCap()),
-		Gas:        rawTx.Gas(),
+		Gas:        gasLimit,
 		To:         *rawTx.To(),
 		Value:      uint256.MustFromBig(rawTx.Value()),
 		Data:       rawTx.Data(),
 		AccessList: rawTx.AccessList(),
-		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+		BlobFeeCap: uint256.MustFromBig(fees.BlobFeeCap),
 		BlobHashes: sidecar.BlobHashes(),
 		Sidecar:    sidecar,
 	}, nil
 }
 
-// MakeSidecar makes a sidecar which only includes one blob with the given data.
-func MakeSidecar(data []byte) (*types.BlobTxSidecar, error) {
-	var blob eth.Blob
-	if err := blob.FromData(data); err != nil {
+// checkSidecarShape checks whether the given sidecar has the same number of blobs, commitments and proofs.
+func checkSidecarShape(sidecar *types.BlobTxSidecar) error {
+	if sidecar == nil || len(sidecar.Blobs) == 0 {
+		return errors.New("empty blob sidecar")
+	}
+	if len(sidecar.Blobs) != len(sidecar.Commitments) || len(sidecar.Blobs) != len(sidecar.Proofs) {
+		return fmt.Errorf(
+			"invalid blob sidecar, blobs: %d, commitments: %d, proofs: %d",
+			len(sidecar.Blobs),
+			len(sidecar.Commitments),
+			len(sidecar.Proofs),
+		)
+	}
+
+	return nil
+}
+
+// VerifySidecar checks whether the given sidecar is internally consistent, i.e. each blob's KZG proof
+// is valid against its commitment, and the blob hashes are correctly derived from the commitments.
+func VerifySidecar(sidecar *types.BlobTxSidecar) error {
+	if err := checkSidecarShape(sidecar); err != nil {
+		return err
+	}
+
+	blobHashes := sidecar.BlobHashes()
+	for i := range sidecar.Blobs {
+		if err := verifyBlobProof(sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
+			return fmt.Errorf("invalid KZG proof of blob %d: %w", i, err)
+		}
+
+		if expected := CommitmentToVersionedHash(sidecar.Commitments[i]); blobHashes[i] != expected {
+			return fmt.Errorf("invalid blob hash of blob %d: have %s, want %s", i, blobHashes[i], expected)
+		}
+	}
+
+	return nil
+}
+
+// CommitmentToVersionedHash computes the EIP-4844 versioned hash of the given KZG commitment, which is
+// the SHA-256 hash of the commitment with its first byte replaced by the blob hash version.
+func CommitmentToVersionedHash(commitment kzg4844.Commitment) common.Hash {
+	hash := common.Hash(sha256.Sum256(commitment[:]))
+	hash[0] = params.BlobTxHashVersion
+
+	return hash
+}
+
+// blobTxFees contains the estimated fees of a blob transaction, based on the latest L1 header, or the header
+// of FeeEstimationBlock if it is set.
+type blobTxFees struct {
+	// BlockGasLimit is the gas limit of the header the fees are estimated from.
+	BlockGasLimit uint64
+	BaseFee       *big.Int
+	GasTipCap     *big.Int
+	GasFeeCap     *big.Int
+	BlobBaseFee   *big.Int
+	BlobFeeCap    *big.Int
+}
+
+// estimateBlobTxFees fetches the latest L1 header, or the header of FeeEstimationBlock if it is set, and
+// estimates the fees of a blob transaction, the values which have already been set in the transact options
+// will be respected. The blob fee cap is scaled by the given blob fee strategy, and then capped by MaxBlobFeeCap
+// if it is set.
+func (c *EthClient) estimateBlobTxFees(opts *bind.TransactOpts, strategy BlobFeeStrategy) (*blobTxFees, error) {
+	header, err := c.HeaderByNumber(opts.Context, c.FeeEstimationBlock)
+	if err != nil {
 		return nil, err
 	}
 
-	sideCar := &types.BlobTxSidecar{Blobs: []kzg4844.Blob{*blob.KZGBlob()}}
-	for _, blob := range sideCar.Blobs {
-		commitment, err := kzg4844.BlobToCommitment(blob)
-		if err != nil {
-			return nil, err
+	gasTipCap, gasFeeCap, err := c.estimateGasFeeCaps(opts, header)
+	if err != nil {
+		return nil, err
+	}
+
+	blobBaseFee, err := c.blobBaseFeeOf(opts.Context, header)
+	if err != nil {
+		log.Warn("Failed to fetch the blob base fee, use the minimum blob gas price instead", "error", err)
+		blobBaseFee = new(big.Int).SetUint64(params.BlobTxMinBlobGasprice)
+	}
+
+	blobFeeCap := strategy.scaleBlobFeeCap(calcBlobFeeCap(blobBaseFee, c.BlobFeeCapMultiplier), blobBaseFee)
+	if c.MaxBlobFeeCap != nil {
+		if blobBaseFee.Cmp(c.MaxBlobFeeCap) > 0 {
+			return nil, fmt.Errorf(
+				"%w: blobBaseFee %v, maxBlobFeeCap %v",
+				ErrBlobFeeCapCeilingExceeded,
+				blobBaseFee,
+				c.MaxBlobFeeCap,
+			)
+		}
+		if blobFeeCap.Cmp(c.MaxBlobFeeCap) > 0 {
+			blobFeeCap = new(big.Int).Set(c.MaxBlobFeeCap)
 		}
-		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	}
+
+	return &blobTxFees{
+		BlockGasLimit: header.GasLimit,
+		BaseFee:       header.BaseFee,
+		GasTipCap:     gasTipCap,
+		GasFeeCap:     gasFeeCap,
+		BlobBaseFee:   blobBaseFee,
+		BlobFeeCap:    blobFeeCap,
+	}, nil
+}
+
+// estimateGasFeeCaps estimates the gasTipCap and gasFeeCap of a dynamic fee transaction based on the
+// given header, the values which have already been set in the transact options will be respected,
+// otherwise the gasTipCap is decided by the client's TipCapStrategy. The gasTipCap is raised to
+// MinGasTipCap if it is set.
+func (c *EthClient) estimateGasFeeCaps(
+	opts *bind.TransactOpts,
+	header *types.Header,
+) (*big.Int, *big.Int, error) {
+	// Leave the legacy gas price to the node.
+	if opts.GasPrice != nil {
+		return opts.GasTipCap, opts.GasFeeCap, nil
+	}
+
+	gasTipCap := opts.GasTipCap
+	if gasTipCap == nil {
+		strategy := c.TipCapStrategy
+		if strategy == nil {
+			strategy = &SuggestedTipCap{}
+		}
+
+		var err error
+		if gasTipCap, err = strategy.TipCap(opts.Context, c); err != nil {
+			return nil, nil, err
+		}
+	}
+	floored := c.MinGasTipCap != nil && gasTipCap.Cmp(c.MinGasTipCap) < 0
+	if floored {
+		gasTipCap = new(big.Int).Set(c.MinGasTipCap)
+	}
+
+	gasFeeCap := opts.GasFeeCap
+	if gasFeeCap == nil {
+		gasFeeCap = calcGasFeeCap(header.BaseFee, gasTipCap, c.GasFeeCapMultiplier)
+	} else if floored && gasFeeCap.Cmp(gasTipCap) < 0 {
+		// The gasFeeCap can't be lower than the floored gasTipCap.
+		gasFeeCap = new(big.Int).Set(gasTipCap)
+	}
+
+	return gasTipCap, gasFeeCap, nil
+}
+
+// estimateBlobTxGas returns the gas limit of the blob transaction with the given parameters before the safety
+// margin is applied, and its access list. The explicitly given gas limit is respected, otherwise it's estimated
+// with retries.
+func (c *EthClient) estimateBlobTxGas(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	fees *blobTxFees,
+	blobHashes []common.Hash,
+) (uint64, *types.AccessList, error) {
+	accessList, err := c.blobTxAccessList(opts, contract, input, fees, blobHashes)
+	if err != nil {
+		return 0, nil, err
+	}
+	if opts.GasLimit != 0 {
+		return opts.GasLimit, accessList, nil
+	}
+
+	msg := ethereum.CallMsg{
+		From:          opts.From,
+		To:            &contract,
+		GasPrice:      opts.GasPrice,
+		GasFeeCap:     fees.GasFeeCap,
+		GasTipCap:     fees.GasTipCap,
+		Value:         opts.Value,
+		Data:          input,
+		BlobGasFeeCap: fees.BlobFeeCap,
+		BlobHashes:    blobHashes,
+	}
+	if accessList != nil {
+		msg.AccessList = *accessList
+	}
+	estimated, err := c.EstimateGasWithRetry(opts.Context, msg, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	return estimated, accessList, nil
+}
+
+// blobTxGasLimit leaves a safety margin for the estimated gas limit of a blob transaction, the explicitly
+// given gas limit is respected.
+func (c *EthClient) blobTxGasLimit(opts *bind.TransactOpts, gasLimit uint64, fees *blobTxFees) uint64 {
+	if opts.GasLimit != 0 {
+		return gasLimit
+	}
+
+	return inflateGasLimit(gasLimit, c.GasLimitMultiplier, fees.BlockGasLimit)
+}
+
+// inflateGasLimit multiplies the given estimated gas limit by the given multiplier, default to 1.2, rounded up,
+// the result is clamped to the given block gas limit if it is not zero. A multiplier less than 1 is ignored.
+func inflateGasLimit(gasLimit uint64, multiplier float64, blockGasLimit uint64) uint64 {
+	if multiplier == 0 {
+		multiplier = defaultGasLimitMultiplier
+	}
+	if multiplier <= 1 {
+		return gasLimit
+	}
+
+	inflated := uint64(math.Ceil(float64(gasLimit) * multiplier))
+	if blockGasLimit != 0 && inflated > blockGasLimit {
+		inflated = max(blockGasLimit, gasLimit)
+	}
+
+	return inflated
+}
+
+// calcGasFeeCap calculates the gasFeeCap by `gasTipCap + multiplier * baseFee`.
+func calcGasFeeCap(baseFee *big.Int, gasTipCap *big.Int, multiplier *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(gasTipCap)
+	}
+
+	if multiplier == nil {
+		multiplier = defaultGasFeeCapMultiplier
+	}
+
+	return new(big.Int).Add(gasTipCap, new(big.Int).Mul(baseFee, multiplier))
+}
+
+// blobBaseFeeOf returns the blob base fee based on the excess blob gas of the given header, if the header
+// doesn't have the excess blob gas field, the `eth_blobBaseFee` RPC method will be used instead.
+func (c *EthClient) blobBaseFeeOf(ctx context.Context, header *types.Header) (*big.Int, error) {
+	if header.ExcessBlobGas != nil {
+		return eip4844.CalcBlobFee(*header.ExcessBlobGas), nil
+	}
+
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+
+	var blobBaseFee hexutil.Big
+	if err := c.CallContext(ctxWithTimeout, &blobBaseFee, "eth_blobBaseFee"); err != nil {
+		return nil, err
+	}
+
+	return blobBaseFee.ToInt(), nil
+}
+
+// calcBlobFeeCap calculates the blob fee cap by multiplying the given blob base fee by the given multiplier.
+func calcBlobFeeCap(blobBaseFee *big.Int, multiplier *big.Int) *big.Int {
+	if multiplier == nil {
+		multiplier = defaultBlobFeeCapMultiplier
+	}
+
+	return new(big.Int).Mul(blobBaseFee, multiplier)
+}
+
+// MakeSidecar makes a sidecar which only includes one blob with the given data, note that the
+// max data length is eth.MaxBlobDataSize rather than BlobBytes, because of the blob encoding overhead.
+// The sidecars of the identical data are cached if the cache is enabled by SetSidecarCacheSize.
+func MakeSidecar(data []byte) (*types.BlobTxSidecar, error) {
+	return makeCachedSidecar(data)
+}
+
+// MakeSidecarWithEncoder makes a sidecar which only includes one blob with the given data, which is
+// encoded by the given blob encoder.
+func MakeSidecarWithEncoder(data []byte, encoder BlobEncoder) (*types.BlobTxSidecar, error) {
+	blob, err := encoder.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeSidecarFromBlobs([]kzg4844.Blob{*blob})
+}
+
+// MakeSidecarWithMultipleBlobs makes a sidecar which splits the given data across as many
+// blobs as needed, at most MaxBlobsPerTx blobs will be used.
+func MakeSidecarWithMultipleBlobs(data []byte) (*types.BlobTxSidecar, error) {
+	return MakeSidecarWithMultipleBlobsAndEncoder(data, new(PackedBlobEncoder))
+}
+
+// MakeSidecarWithMultipleBlobsAndEncoder makes a sidecar which splits the given data across as many
+// blobs encoded by the given blob encoder as needed, at most MaxBlobsPerTx blobs will be used.
+func MakeSidecarWithMultipleBlobsAndEncoder(data []byte, encoder BlobEncoder) (*types.BlobTxSidecar, error) {
+	blobsCount, err := blobsCountForData(len(data), encoder)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDataSize := encoder.MaxDataSize()
+	blobs := make([]kzg4844.Blob, 0, blobsCount)
+	for i := 0; i < blobsCount; i++ {
+		blob, err := encoder.Encode(data[i*maxDataSize : min((i+1)*maxDataSize, len(data))])
 		if err != nil {
 			return nil, err
 		}
-		sideCar.Commitments = append(sideCar.Commitments, commitment)
-		sideCar.Proofs = append(sideCar.Proofs, proof)
+		blobs = append(blobs, *blob)
+	}
+
+	return makeSidecarFromBlobs(blobs)
+}
+
+// blobsCountForData returns the number of the blobs encoded by the given blob encoder which are required
+// to carry the data with the given length, at most MaxBlobsPerTx blobs can be used.
+func blobsCountForData(dataLen int, encoder BlobEncoder) (int, error) {
+	if dataLen < 0 {
+		return 0, fmt.Errorf("invalid blob data length %d", dataLen)
+	}
+
+	maxDataSize := encoder.MaxDataSize()
+	blobsCount := (dataLen + maxDataSize - 1) / maxDataSize
+	// An empty input still takes one (empty) blob, same as MakeSidecar.
+	if blobsCount == 0 {
+		blobsCount = 1
 	}
+	if blobsCount > MaxBlobsPerTx {
+		return 0, fmt.Errorf(
+			"blob data length %d exceeds max %d (%d blobs)",
+			dataLen,
+			MaxBlobsPerTx*maxDataSize,
+			MaxBlobsPerTx,
+		)
+	}
+
+	return blobsCount, nil
+}
+
+// DecodeBlob decodes the given blob back to the original data encoded by MakeSidecar, if the blob
+// was not produced by the same encoding, an error wrapping ErrBlobInvalid will be returned.
+func DecodeBlob(blob kzg4844.Blob) ([]byte, error) {
+	return DecodeBlobWithEncoder(blob, new(PackedBlobEncoder))
+}
+
+// DecodeBlobWithEncoder decodes the given blob back to the original data encoded by the given
+// blob encoder, if the blob was not produced by the same encoder, an error wrapping ErrBlobInvalid
+// will be returned.
+func DecodeBlobWithEncoder(blob kzg4844.Blob, encoder BlobEncoder) ([]byte, error) {
+	return encoder.Decode(&blob)
+}
+
+// SetSidecarParallelism sets the max number of blobs whose KZG commitments and proofs are computed
+// concurrently when making a sidecar, zero means GOMAXPROCS, and one means the blobs are processed
+// sequentially. It is safe for concurrent use.
+func SetSidecarParallelism(parallelism int) {
+	sidecarParallelism.Store(int64(max(parallelism, 0)))
+}
+
+// makeSidecarFromBlobs computes the KZG commitment and proof for each given blob, using a bounded
+// worker pool, and then assembles them into a sidecar in the order of the given blobs. The first
+// error aborts the remaining computations and is returned.
+func makeSidecarFromBlobs(blobs []kzg4844.Blob) (*types.BlobTxSidecar, error) {
+	sideCar := &types.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, len(blobs)),
+	}
+
+	parallelism := int(sidecarParallelism.Load())
+	if parallelism == 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(parallelism)
+	for i := range sideCar.Blobs {
+		i := i
+		g.Go(func() error {
+			// Another blob has failed already.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			commitment, err := blobToCommitment(sideCar.Blobs[i])
+			if err != nil {
+				return fmt.Errorf("failed to compute the commitment of blob %d: %w", i, err)
+			}
+			proof, err := computeBlobProof(sideCar.Blobs[i], commitment)
+			if err != nil {
+				return fmt.Errorf("failed to compute the proof of blob %d: %w", i, err)
+			}
+			sideCar.Commitments[i] = commitment
+			sideCar.Proofs[i] = proof
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
 	return sideCar, nil
 }
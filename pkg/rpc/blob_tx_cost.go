@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BlobTxCost represents the estimated cost of a blob transaction, all values are in wei, except for
+// the gas limit and the blob gas.
+type BlobTxCost struct {
+	GasLimit      uint64
+	GasPrice      *big.Int
+	ExecutionCost *big.Int
+	BlobGas       uint64
+	BlobBaseFee   *big.Int
+	BlobCost      *big.Int
+	Total         *big.Int
+}
+
+// BlobGasForData returns the number of the blobs required to carry the data with the given length, when
+// it is encoded by MakeSidecarWithMultipleBlobs, and the blob gas they will consume.
+func BlobGasForData(dataLen int) (blobs int, blobGas uint64, err error) {
+	return BlobGasForDataWithEncoder(dataLen, new(PackedBlobEncoder))
+}
+
+// BlobGasForDataWithEncoder returns the number of the blobs encoded by the given blob encoder required to
+// carry the data with the given length, and the blob gas they will consume.
+func BlobGasForDataWithEncoder(dataLen int, encoder BlobEncoder) (blobs int, blobGas uint64, err error) {
+	if blobs, err = blobsCountForData(dataLen, encoder); err != nil {
+		return 0, 0, err
+	}
+
+	return blobs, uint64(blobs) * params.BlobTxBlobGasPerBlob, nil
+}
+
+// EstimateBlobTxCost estimates the total cost of a blob transaction carrying the given blob data before
+// sending it, based on the current base fee, blob base fee and the gas limit the transaction would be sent with.
+func (c *EthClient) EstimateBlobTxCost(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	blobData []byte,
+) (*BlobTxCost, error) {
+	sidecar, err := MakeSidecarWithMultipleBlobs(blobData)
+	if err != nil {
+		return nil, err
+	}
+
+	estimateOpts := *opts
+	estimateOpts.Context = ctx
+	fees, err := c.estimateBlobTxFees(&estimateOpts, BlobFeeStrategyNormal)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same gas limit as the sent transaction, see createBlobTx.
+	estimatedGas, _, err := c.estimateBlobTxGas(&estimateOpts, contract, input, fees, sidecar.BlobHashes())
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := c.blobTxGasLimit(&estimateOpts, estimatedGas, fees)
+
+	var (
+		gasPrice      = effectiveGasPrice(fees.BaseFee, fees.GasTipCap, fees.GasFeeCap)
+		blobGas       = uint64(len(sidecar.Blobs)) * params.BlobTxBlobGasPerBlob
+		executionCost = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+		blobCost      = new(big.Int).Mul(fees.BlobBaseFee, new(big.Int).SetUint64(blobGas))
+	)
+
+	return &BlobTxCost{
+		GasLimit:      gasLimit,
+		GasPrice:      gasPrice,
+		ExecutionCost: executionCost,
+		BlobGas:       blobGas,
+		BlobBaseFee:   fees.BlobBaseFee,
+		BlobCost:      blobCost,
+		Total:         new(big.Int).Add(executionCost, blobCost),
+	}, nil
+}
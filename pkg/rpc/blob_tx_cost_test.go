@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateBlobTxCost(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			header := newTestHeader(1)
+			header.BaseFee = big.NewInt(7)
+			return header, nil
+		},
+		blobBaseFee:     func() (*big.Int, error) { return big.NewInt(3), nil },
+		fillTransaction: fillTestTransaction,
+		estimateGas: func(args map[string]interface{}) (uint64, error) {
+			require.Equal(t, 2, len(args["blobVersionedHashes"].([]interface{})))
+			return 50_000, nil
+		},
+	})
+
+	cost, err := client.EstimateBlobTxCost(
+		context.Background(),
+		&bind.TransactOpts{From: common.HexToAddress("0x01"), GasTipCap: big.NewInt(2)},
+		common.HexToAddress("0x02"),
+		[]byte{0x01},
+		bytes.Repeat([]byte{0xff}, eth.MaxBlobDataSize+1),
+	)
+	require.Nil(t, err)
+
+	// The estimated gas limit is inflated just like the sent transaction.
+	// gasPrice = min(gasTipCap + 2 * baseFee, baseFee + gasTipCap)
+	require.Equal(t, inflateGasLimit(50_000, 0, 0), cost.GasLimit)
+	require.Equal(t, big.NewInt(9), cost.GasPrice)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(9), new(big.Int).SetUint64(cost.GasLimit)), cost.ExecutionCost)
+	require.Equal(t, uint64(2*params.BlobTxBlobGasPerBlob), cost.BlobGas)
+	require.Equal(t, big.NewInt(3), cost.BlobBaseFee)
+	require.Equal(t, new(big.Int).SetUint64(3*2*params.BlobTxBlobGasPerBlob), cost.BlobCost)
+	require.Equal(t, new(big.Int).Add(cost.ExecutionCost, cost.BlobCost), cost.Total)
+
+	// The estimated gas limit matches the one of the created transaction.
+	client.GasLimitMultiplier = 2
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), GasTipCap: big.NewInt(2)}
+	sidecar, err := MakeSidecarWithMultipleBlobs(bytes.Repeat([]byte{0xff}, eth.MaxBlobDataSize+1))
+	require.Nil(t, err)
+	tx, err := client.CreateBlobTx(opts, common.HexToAddress("0x02"), []byte{0x01}, sidecar)
+	require.Nil(t, err)
+	cost, err = client.EstimateBlobTxCost(
+		context.Background(),
+		opts,
+		common.HexToAddress("0x02"),
+		[]byte{0x01},
+		bytes.Repeat([]byte{0xff}, eth.MaxBlobDataSize+1),
+	)
+	require.Nil(t, err)
+	require.Equal(t, uint64(100_000), cost.GasLimit)
+	require.Equal(t, tx.Gas, cost.GasLimit)
+
+	// The given gas limit should be respected.
+	cost, err = client.EstimateBlobTxCost(
+		context.Background(),
+		&bind.TransactOpts{GasTipCap: big.NewInt(2), GasLimit: 21_000},
+		common.HexToAddress("0x02"),
+		nil,
+		[]byte{0x01},
+	)
+	require.Nil(t, err)
+	require.Equal(t, uint64(21_000), cost.GasLimit)
+	require.Equal(t, uint64(params.BlobTxBlobGasPerBlob), cost.BlobGas)
+	require.Equal(t, new(big.Int).Add(cost.ExecutionCost, cost.BlobCost), cost.Total)
+}
+
+func TestBlobGasForData(t *testing.T) {
+	for _, tc := range []struct {
+		dataLen int
+		blobs   int
+	}{
+		{0, 1},
+		{1, 1},
+		{eth.MaxBlobDataSize, 1},
+		{eth.MaxBlobDataSize + 1, 2},
+		{MaxBlobsPerTx * eth.MaxBlobDataSize, MaxBlobsPerTx},
+	} {
+		blobs, blobGas, err := BlobGasForData(tc.dataLen)
+		require.Nil(t, err)
+		require.Equal(t, tc.blobs, blobs)
+		require.Equal(t, uint64(tc.blobs)*params.BlobTxBlobGasPerBlob, blobGas)
+	}
+
+	// The blobs count should match the sidecar.
+	sidecar, err := MakeSidecarWithMultipleBlobs(make([]byte, 2*eth.MaxBlobDataSize+1))
+	require.Nil(t, err)
+	blobs, blobGas, err := BlobGasForData(2*eth.MaxBlobDataSize + 1)
+	require.Nil(t, err)
+	require.Equal(t, len(sidecar.Blobs), blobs)
+	require.Equal(t, uint64(3*131072), blobGas)
+
+	_, _, err = BlobGasForData(MaxBlobsPerTx*eth.MaxBlobDataSize + 1)
+	require.ErrorContains(t, err, "exceeds max")
+	_, _, err = BlobGasForData(-1)
+	require.ErrorContains(t, err, "invalid blob data length")
+
+	// The blobs count depends on the encoder.
+	encoder := new(NaiveBlobEncoder)
+	blobs, _, err = BlobGasForDataWithEncoder(eth.MaxBlobDataSize, encoder)
+	require.Nil(t, err)
+	require.Equal(t, 2, blobs)
+	blobs, _, err = BlobGasForDataWithEncoder(encoder.MaxDataSize(), encoder)
+	require.Nil(t, err)
+	require.Equal(t, 1, blobs)
+}
@@ -0,0 +1,321 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+var (
+	defaultResubmitBlocks        uint64 = 3
+	defaultBlobTxPollingInterval        = 3 * time.Second
+	minBlobFeeBumpPercent        uint64 = 100
+	errBlobTxManagerNoSigner            = errors.New("no signer for the replacement transactions")
+)
+
+// BlobTxManagerOpts contains all options for creating a BlobTxManager instance.
+type BlobTxManagerOpts struct {
+	// ResubmitBlocks is the number of blocks to wait before resubmitting a pending transaction
+	// with bumped fees, default to 3.
+	ResubmitBlocks uint64
+	// FeeBumpPercent is the percentage to bump the fees by, default to and at least 100 to satisfy
+	// the replacement rules of the blob pool.
+	FeeBumpPercent uint64
+	// PollingInterval is the interval for checking the transaction inclusion, default to 3s.
+	PollingInterval time.Duration
+	// MaxReplacements is the optional max number of the fee-bumped replacements of a transaction, the
+	// transaction will be abandoned with ErrReplacementBudgetExhausted once it is exceeded.
+	MaxReplacements uint64
+	// MaxReplacementFee is the optional max fee in wei a replacement can cost, the transaction will be
+	// abandoned with ErrReplacementBudgetExhausted instead of being bumped beyond it.
+	MaxReplacementFee *big.Int
+}
+
+// BlobTxFuture represents the final result of a blob transaction submitted to the BlobTxManager.
+type BlobTxFuture struct {
+	done    chan struct{}
+	receipt *types.Receipt
+	err     error
+}
+
+// Wait waits until the transaction is mined or cancelled, and returns the final receipt.
+func (f *BlobTxFuture) Wait(ctx context.Context) (*types.Receipt, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-f.done:
+		return f.receipt, f.err
+	}
+}
+
+// BlobTxManager tracks the sent blob transactions by nonce, monitors their inclusion, and
+// rebroadcasts them with bumped fees at the same nonce if they are stuck for too long. Only the
+// lowest stuck nonce is bumped at a time, so that the nonces progress without gaps.
+type BlobTxManager struct {
+	client          *EthClient
+	from            common.Address
+	signer          bind.SignerFn
+	resubmitBlocks  uint64
+	feeBumpPercent  uint64
+	pollingInterval time.Duration
+	maxReplacements uint64
+	maxReplaceFee   *big.Int
+	queue           *PendingTxQueue
+}
+
+// NewBlobTxManager creates a new BlobTxManager instance, the given signer will be used
+// to sign the replacement transactions.
+func NewBlobTxManager(
+	client *EthClient,
+	from common.Address,
+	signer bind.SignerFn,
+	opts *BlobTxManagerOpts,
+) *BlobTxManager {
+	m := &BlobTxManager{
+		client:          client,
+		from:            from,
+		signer:          signer,
+		resubmitBlocks:  defaultResubmitBlocks,
+		feeBumpPercent:  minBlobFeeBumpPercent,
+		pollingInterval: defaultBlobTxPollingInterval,
+		queue:           NewPendingTxQueue(client, from),
+	}
+	if opts != nil {
+		if opts.ResubmitBlocks != 0 {
+			m.resubmitBlocks = opts.ResubmitBlocks
+		}
+		if opts.FeeBumpPercent > minBlobFeeBumpPercent {
+			m.feeBumpPercent = opts.FeeBumpPercent
+		}
+		if opts.PollingInterval != 0 {
+			m.pollingInterval = opts.PollingInterval
+		}
+		m.maxReplacements = opts.MaxReplacements
+		m.maxReplaceFee = opts.MaxReplacementFee
+	}
+
+	return m
+}
+
+// Submit sends the given signed blob transaction, and keeps rebroadcasting it with bumped fees
+// until it is mined or the given context is cancelled.
+func (m *BlobTxManager) Submit(ctx context.Context, tx *types.Transaction) *BlobTxFuture {
+	future := &BlobTxFuture{done: make(chan struct{})}
+
+	m.queue.Put(tx)
+
+	go func() {
+		defer close(future.done)
+		defer m.queue.Remove(tx.Nonce())
+
+		future.receipt, future.err = m.monitor(ctx, tx)
+	}()
+
+	return future
+}
+
+// Pending returns the currently pending transaction with the given nonce.
+func (m *BlobTxManager) Pending(nonce uint64) (*types.Transaction, bool) {
+	return m.queue.Get(nonce)
+}
+
+// monitor sends the given transaction, and waits for its inclusion, the transaction will be
+// replaced by a new one with bumped fees after every resubmitBlocks blocks, until the replacement
+// budget is exhausted. The transaction is only bumped once all the lower nonces are mined. Note that
+// the already sent transactions might still be mined after that.
+func (m *BlobTxManager) monitor(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	var (
+		sent       = []*types.Transaction{tx}
+		current    = tx
+		tracker    = NewReplacementTracker(tx, m.maxReplacements, m.maxReplaceFee)
+		sentHeight uint64
+		needBump   bool
+	)
+
+	head, err := m.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	sentHeight = head.Number.Uint64()
+
+	if err := m.send(ctx, current); err != nil {
+		if !isReplaceUnderpricedErr(err) {
+			return nil, err
+		}
+		needBump = true
+	}
+
+	ticker := time.NewTicker(m.pollingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		// Check if any of the sent transactions has been mined.
+		for _, sentTx := range sent {
+			receipt, err := m.client.TransactionReceipt(ctx, sentTx.Hash())
+			if err != nil {
+				if !errors.Is(err, ethereum.NotFound) {
+					log.Debug("Failed to fetch blob transaction receipt", "hash", sentTx.Hash(), "error", err)
+				}
+				continue
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("transaction reverted, hash: %s", sentTx.Hash())
+			}
+			return receipt, nil
+		}
+
+		head, err := m.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			log.Warn("Failed to fetch L1 head", "error", err)
+			continue
+		}
+		if !needBump && head.Number.Uint64() < sentHeight+m.resubmitBlocks {
+			continue
+		}
+		if !m.bumpable(ctx, tx.Nonce()) {
+			continue
+		}
+
+		// Resubmit the transaction with bumped fees.
+		replacement, err := m.bump(current)
+		if err != nil {
+			return nil, err
+		}
+		if err := tracker.Track(replacement); err != nil {
+			log.Warn("Abandoning the stuck blob transaction", "nonce", tx.Nonce(), "error", err)
+			return nil, err
+		}
+		current = replacement
+		sent = append(sent, replacement)
+		sentHeight = head.Number.Uint64()
+		needBump = false
+
+		m.queue.Put(replacement)
+
+		log.Info(
+			"Resubmitting blob transaction with bumped fees",
+			"nonce", replacement.Nonce(),
+			"hash", replacement.Hash(),
+			"gasTipCap", replacement.GasTipCap(),
+			"gasFeeCap", replacement.GasFeeCap(),
+			"blobFeeCap", replacement.BlobGasFeeCap(),
+			"attempts", tracker.Attempts(),
+			"feeIncrease", tracker.FeeIncrease(),
+		)
+
+		if err := m.send(ctx, replacement); err != nil {
+			if !isReplaceUnderpricedErr(err) {
+				log.Warn("Failed to resubmit blob transaction", "hash", replacement.Hash(), "error", err)
+				continue
+			}
+			// Still underpriced, bump again in the next round.
+			needBump = true
+		}
+	}
+}
+
+// bumpable checks whether the transaction of the given nonce is the lowest stuck one, after re-syncing
+// the on-chain nonce.
+func (m *BlobTxManager) bumpable(ctx context.Context, nonce uint64) bool {
+	// Avoid fetching the on-chain nonce while a lower nonce is still pending.
+	if !m.queue.IsLowest(nonce) {
+		return false
+	}
+	if err := m.queue.Sync(ctx); err != nil {
+		log.Warn("Failed to sync the pending blob transactions", "nonce", nonce, "error", err)
+		return false
+	}
+
+	return m.queue.IsLowest(nonce)
+}
+
+// send sends the given transaction, the errors which mean the transaction (or one of its
+// replacements) has been accepted will be ignored.
+func (m *BlobTxManager) send(ctx context.Context, tx *types.Transaction) error {
+	err := m.client.SendTransaction(ctx, tx)
+	if err == nil || isTxAlreadyKnownErr(err) || isNonceTooLowErr(err) {
+		return nil
+	}
+
+	log.Warn("Failed to send blob transaction", "nonce", tx.Nonce(), "hash", tx.Hash(), "error", err)
+	return err
+}
+
+// bump creates and signs a replacement of the given blob transaction with bumped fees.
+func (m *BlobTxManager) bump(tx *types.Transaction) (*types.Transaction, error) {
+	if m.signer == nil {
+		return nil, errBlobTxManagerNoSigner
+	}
+
+	replacement, err := BumpBlobTxFees(tx, int(m.feeBumpPercent))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.signer(m.from, replacement)
+}
+
+// BumpBlobTxFees returns a new unsigned replacement of the given blob transaction, with the gas tip cap,
+// gas fee cap and blob fee cap all bumped by the given percentage, rounded up. The blob pool rejects
+// the replacements which don't bump all of the three caps by at least 100%, so a smaller percentage
+// will be raised to 100. The nonce and the sidecar of the given transaction are preserved.
+func BumpBlobTxFees(tx *types.Transaction, bumpPercent int) (*types.Transaction, error) {
+	if tx.Type() != types.BlobTxType {
+		return nil, fmt.Errorf("%w: type %d", errNotBlobTx, tx.Type())
+	}
+	if bumpPercent < 0 {
+		return nil, fmt.Errorf("invalid fee bump percentage: %d", bumpPercent)
+	}
+	percent := max(uint64(bumpPercent), minBlobFeeBumpPercent)
+
+	return types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(tx.ChainId()),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  uint256.MustFromBig(bumpFee(tx.GasTipCap(), percent)),
+		GasFeeCap:  uint256.MustFromBig(bumpFee(tx.GasFeeCap(), percent)),
+		Gas:        tx.Gas(),
+		To:         *tx.To(),
+		Value:      uint256.MustFromBig(tx.Value()),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+		BlobFeeCap: uint256.MustFromBig(bumpFee(tx.BlobGasFeeCap(), percent)),
+		BlobHashes: tx.BlobHashes(),
+		Sidecar:    tx.BlobTxSidecar(),
+	}), nil
+}
+
+// bumpFee bumps the given fee by the given percentage, rounded up, the result is always
+// strictly greater than the given fee.
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, common.Big1)
+	}
+
+	return bumped
+}
+
+// isReplaceUnderpricedErr returns true if the error signals that the replacement transaction is underpriced.
+func isReplaceUnderpricedErr(err error) bool {
+	return strings.Contains(err.Error(), txpool.ErrReplaceUnderpriced.Error())
+}
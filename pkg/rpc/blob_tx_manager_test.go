@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSignedBlobTx creates a new signed blob transaction for testing.
+func newTestSignedBlobTx(t *testing.T, opts *bind.TransactOpts, nonce uint64) *types.Transaction {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      nonce,
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(200),
+		Gas:        21000,
+		BlobFeeCap: uint256.NewInt(10),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	}))
+	require.Nil(t, err)
+
+	return tx
+}
+
+func TestBumpFee(t *testing.T) {
+	require.Equal(t, big.NewInt(110), bumpFee(big.NewInt(100), 10))
+	require.Equal(t, big.NewInt(13), bumpFee(big.NewInt(11), 10))
+	require.Equal(t, big.NewInt(2), bumpFee(big.NewInt(1), 10))
+	require.Equal(t, big.NewInt(1), bumpFee(big.NewInt(0), 10))
+	require.Equal(t, big.NewInt(150), bumpFee(big.NewInt(100), 50))
+}
+
+func TestBumpBlobTxFees(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	tx := newTestSignedBlobTx(t, opts, 5)
+
+	for _, c := range []struct {
+		bumpPercent int
+		gasTipCap   int64
+		gasFeeCap   int64
+		blobFeeCap  int64
+	}{
+		{100, 200, 400, 20},
+		{150, 250, 500, 25},
+		// Raised to the minimum bump required by the blob pool.
+		{0, 200, 400, 20},
+		{10, 200, 400, 20},
+	} {
+		replacement, err := BumpBlobTxFees(tx, c.bumpPercent)
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(c.gasTipCap), replacement.GasTipCap())
+		require.Equal(t, big.NewInt(c.gasFeeCap), replacement.GasFeeCap())
+		require.Equal(t, big.NewInt(c.blobFeeCap), replacement.BlobGasFeeCap())
+
+		// Each cap is bumped by at least the required percentage.
+		for _, fees := range [][2]*big.Int{
+			{tx.GasTipCap(), replacement.GasTipCap()},
+			{tx.GasFeeCap(), replacement.GasFeeCap()},
+			{tx.BlobGasFeeCap(), replacement.BlobGasFeeCap()},
+		} {
+			minimum := new(big.Int).Mul(fees[0], big.NewInt(int64(100+max(c.bumpPercent, 100))))
+			require.GreaterOrEqual(t, new(big.Int).Mul(fees[1], big.NewInt(100)).Cmp(minimum), 0)
+		}
+
+		require.Equal(t, tx.Nonce(), replacement.Nonce())
+		require.Equal(t, tx.BlobHashes(), replacement.BlobHashes())
+		require.Equal(t, tx.BlobTxSidecar(), replacement.BlobTxSidecar())
+		v, r, s := replacement.RawSignatureValues()
+		require.Zero(t, v.Sign()+r.Sign()+s.Sign())
+	}
+
+	_, err = BumpBlobTxFees(tx, -1)
+	require.NotNil(t, err)
+	_, err = BumpBlobTxFees(types.NewTx(&types.DynamicFeeTx{}), 10)
+	require.ErrorIs(t, err, errNotBlobTx)
+}
+
+func TestBlobTxManagerReplaceUnderpriced(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var (
+		mutex  sync.Mutex
+		height uint64
+		sent   []*types.Transaction
+		mined  common.Hash
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			height++
+			return newTestHeader(height), nil
+		},
+		getTransactionCount: func(common.Address) (uint64, error) { return 1, nil },
+		sendRawTransaction: func(tx *types.Transaction) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			sent = append(sent, tx)
+			switch len(sent) {
+			case 1:
+				return nil
+			case 2:
+				// The first replacement is rejected.
+				return txpool.ErrReplaceUnderpriced
+			default:
+				mined = tx.Hash()
+				return nil
+			}
+		},
+		getReceipt: func(hash common.Hash) (*types.Receipt, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if hash != mined {
+				return nil, nil
+			}
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				BlockNumber: new(big.Int).SetUint64(height),
+				Logs:        []*types.Log{},
+			}, nil
+		},
+	})
+
+	m := NewBlobTxManager(client, opts.From, opts.Signer, &BlobTxManagerOpts{
+		ResubmitBlocks:  1,
+		PollingInterval: time.Millisecond,
+	})
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+	receipt, err := m.Submit(context.Background(), tx).Wait(context.Background())
+	require.Nil(t, err)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	require.Equal(t, 3, len(sent))
+	require.Equal(t, mined, receipt.TxHash)
+	for i := 1; i < len(sent); i++ {
+		require.Equal(t, tx.Nonce(), sent[i].Nonce())
+		require.Equal(t, bumpFee(sent[i-1].GasTipCap(), minBlobFeeBumpPercent), sent[i].GasTipCap())
+		require.Equal(t, bumpFee(sent[i-1].GasFeeCap(), minBlobFeeBumpPercent), sent[i].GasFeeCap())
+		require.Equal(t, bumpFee(sent[i-1].BlobGasFeeCap(), minBlobFeeBumpPercent), sent[i].BlobGasFeeCap())
+		// The blob pool requires each replacement to at least double all of the three caps.
+		for _, fees := range [][2]*big.Int{
+			{sent[i-1].GasTipCap(), sent[i].GasTipCap()},
+			{sent[i-1].GasFeeCap(), sent[i].GasFeeCap()},
+			{sent[i-1].BlobGasFeeCap(), sent[i].BlobGasFeeCap()},
+		} {
+			require.GreaterOrEqual(t, fees[1].Cmp(new(big.Int).Mul(fees[0], common.Big2)), 0)
+		}
+		require.Equal(t, tx.BlobHashes(), sent[i].BlobHashes())
+	}
+
+	_, ok := m.Pending(tx.Nonce())
+	require.False(t, ok)
+}
+
+func TestBlobTxManagerCancelled(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:  func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		sendRawTransaction: func(*types.Transaction) error { return nil },
+		getReceipt:         func(common.Hash) (*types.Receipt, error) { return nil, nil },
+	})
+
+	m := NewBlobTxManager(client, opts.From, opts.Signer, &BlobTxManagerOpts{PollingInterval: time.Millisecond})
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = m.Submit(ctx, tx).Wait(context.Background())
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
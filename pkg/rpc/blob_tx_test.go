@@ -1,7 +1,11 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -12,6 +16,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/taikoxyz/taiko-client/internal/utils"
@@ -72,3 +79,476 @@ func TestMakeSideCar(t *testing.T) {
 	assert.NoError(t, dErr)
 	assert.Equal(t, hexutil.Bytes(origin), origin1)
 }
+
+func TestMakeSidecarWithMultipleBlobs(t *testing.T) {
+	// Empty input.
+	sideCar, err := MakeSidecarWithMultipleBlobs([]byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(sideCar.Blobs))
+	assert.Equal(t, 1, len(sideCar.BlobHashes()))
+
+	// Data that exactly fills two blobs.
+	data := bytes.Repeat([]byte{0xff}, 2*eth.MaxBlobDataSize)
+	sideCar, err = MakeSidecarWithMultipleBlobs(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sideCar.Blobs))
+	assert.Equal(t, 2, len(sideCar.Commitments))
+	assert.Equal(t, 2, len(sideCar.Proofs))
+	assert.Equal(t, 2, len(sideCar.BlobHashes()))
+
+	var decoded []byte
+	for _, b := range sideCar.Blobs {
+		blob := eth.Blob(b)
+		blobData, err := blob.ToData()
+		assert.NoError(t, err)
+		decoded = append(decoded, blobData...)
+	}
+	assert.Equal(t, data, decoded)
+
+	// Data that needs one more blob than the limit.
+	_, err = MakeSidecarWithMultipleBlobs(make([]byte, MaxBlobsPerTx*eth.MaxBlobDataSize+1))
+	assert.ErrorContains(t, err, "exceeds max")
+}
+
+func TestMakeSidecarParallelism(t *testing.T) {
+	defer SetSidecarParallelism(0)
+
+	data := make([]byte, MaxBlobsPerTx*eth.MaxBlobDataSize)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	SetSidecarParallelism(1)
+	sequential, err := MakeSidecarWithMultipleBlobs(data)
+	assert.NoError(t, err)
+
+	// The parallel computation should keep the same order as the blobs.
+	for _, parallelism := range []int{0, 2, MaxBlobsPerTx} {
+		SetSidecarParallelism(parallelism)
+		sideCar, err := MakeSidecarWithMultipleBlobs(data)
+		assert.NoError(t, err)
+		assert.Equal(t, sequential, sideCar)
+		assert.NoError(t, VerifySidecar(sideCar))
+	}
+
+	// An error from any blob should be propagated.
+	var invalid kzg4844.Blob
+	for i := range invalid {
+		invalid[i] = 0xff
+	}
+	blobs := append([]kzg4844.Blob{}, sequential.Blobs...)
+	blobs[3] = invalid
+	_, err = makeSidecarFromBlobs(blobs)
+	assert.ErrorContains(t, err, "blob 3")
+}
+
+func BenchmarkMakeSidecarWithMultipleBlobs(b *testing.B) {
+	defer SetSidecarParallelism(0)
+
+	data := bytes.Repeat([]byte{0x01}, MaxBlobsPerTx*eth.MaxBlobDataSize)
+	for _, c := range []struct {
+		name        string
+		parallelism int
+	}{{"sequential", 1}, {"parallel", 0}} {
+		b.Run(c.name, func(b *testing.B) {
+			SetSidecarParallelism(c.parallelism)
+			for i := 0; i < b.N; i++ {
+				if _, err := MakeSidecarWithMultipleBlobs(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCalcBlobFeeCap(t *testing.T) {
+	assert.Equal(t, big.NewInt(46), calcBlobFeeCap(big.NewInt(23), nil))
+	assert.Equal(t, big.NewInt(69), calcBlobFeeCap(big.NewInt(23), big.NewInt(3)))
+}
+
+func TestBlobBaseFee(t *testing.T) {
+	var (
+		// The EIP-4844 blob fee of 10*1024*1024 excess blob gas is 23.
+		excessBlobGas = uint64(10 * 1024 * 1024)
+		header        = newTestHeader(1)
+		service       = &testEthService{
+			getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) { return header, nil },
+		}
+		client = newTestEthClient(t, service)
+	)
+
+	// No excess blob gas in header, and `eth_blobBaseFee` is not supported.
+	_, err := client.BlobBaseFee(context.Background())
+	assert.ErrorContains(t, err, errNotImplemented.Error())
+
+	// No excess blob gas in header, fetch the fee by `eth_blobBaseFee`.
+	service.blobBaseFee = func() (*big.Int, error) { return big.NewInt(100), nil }
+	blobBaseFee, err := client.BlobBaseFee(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), blobBaseFee)
+
+	// Calculate the fee from the excess blob gas in header.
+	header.ExcessBlobGas = &excessBlobGas
+	blobBaseFee, err = client.BlobBaseFee(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(23), blobBaseFee)
+}
+
+func TestDecodeBlob(t *testing.T) {
+	for _, size := range []int{0, 1, 31, 32, 1024, eth.MaxBlobDataSize} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		assert.NoError(t, err)
+
+		sideCar, err := MakeSidecar(data)
+		assert.NoError(t, err)
+
+		decoded, err := DecodeBlob(sideCar.Blobs[0])
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+
+	// A blob which was not produced by our encoder.
+	var blob kzg4844.Blob
+	blob[1] = 0xff
+	_, err := DecodeBlob(blob)
+	assert.ErrorIs(t, err, ErrBlobInvalid)
+}
+
+func TestCheckSidecarShape(t *testing.T) {
+	assert.ErrorContains(t, checkSidecarShape(nil), "empty blob sidecar")
+	assert.ErrorContains(t, checkSidecarShape(&types.BlobTxSidecar{}), "empty blob sidecar")
+
+	sideCar, err := MakeSidecar([]byte{0x01})
+	assert.NoError(t, err)
+	assert.NoError(t, checkSidecarShape(sideCar))
+
+	sideCar.Proofs = sideCar.Proofs[:0]
+	assert.ErrorContains(t, checkSidecarShape(sideCar), "invalid blob sidecar")
+}
+
+func TestCalcGasFeeCap(t *testing.T) {
+	var (
+		baseFee   = big.NewInt(100)
+		gasTipCap = big.NewInt(10)
+	)
+
+	assert.Equal(t, big.NewInt(210), calcGasFeeCap(baseFee, gasTipCap, nil))
+	assert.Equal(t, big.NewInt(510), calcGasFeeCap(baseFee, gasTipCap, big.NewInt(5)))
+	assert.Equal(t, gasTipCap, calcGasFeeCap(nil, gasTipCap, big.NewInt(5)))
+}
+
+func TestEstimateGasFeeCaps(t *testing.T) {
+	client := &EthClient{GasFeeCapMultiplier: big.NewInt(3)}
+	header := &types.Header{BaseFee: big.NewInt(100)}
+
+	gasTipCap, gasFeeCap, err := client.estimateGasFeeCaps(&bind.TransactOpts{GasTipCap: big.NewInt(10)}, header)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), gasTipCap)
+	assert.Equal(t, big.NewInt(310), gasFeeCap)
+
+	// Explicitly set gasFeeCap should be respected.
+	gasTipCap, gasFeeCap, err = client.estimateGasFeeCaps(
+		&bind.TransactOpts{GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(1000)},
+		header,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), gasTipCap)
+	assert.Equal(t, big.NewInt(1000), gasFeeCap)
+}
+
+func TestMinGasTipCap(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+	})
+	client.MinGasTipCap = big.NewInt(10)
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0, GasLimit: 100_000}
+
+	// The suggested gasTipCap is below the floor, gasFeeCap = gasTipCap + 2 * baseFee.
+	blobTx, err := client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), blobTx.GasTipCap.ToBig())
+	assert.Equal(t, big.NewInt(12), blobTx.GasFeeCap.ToBig())
+
+	// The floor is applied to the explicitly given gasTipCap too, and the gasFeeCap is raised accordingly.
+	header := &types.Header{BaseFee: big.NewInt(100)}
+	gasTipCap, gasFeeCap, err := client.estimateGasFeeCaps(
+		&bind.TransactOpts{GasTipCap: big.NewInt(5), GasFeeCap: big.NewInt(8)},
+		header,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), gasTipCap)
+	assert.Equal(t, big.NewInt(10), gasFeeCap)
+
+	// A gasTipCap above the floor is kept.
+	gasTipCap, gasFeeCap, err = client.estimateGasFeeCaps(&bind.TransactOpts{GasTipCap: big.NewInt(20)}, header)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(20), gasTipCap)
+	assert.Equal(t, big.NewInt(220), gasFeeCap)
+}
+
+func TestMakeSidecarDataSize(t *testing.T) {
+	for _, size := range []int{eth.MaxBlobDataSize - 1, eth.MaxBlobDataSize} {
+		sidecar, err := MakeSidecar(bytes.Repeat([]byte{0xff}, size))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(sidecar.Blobs))
+	}
+
+	_, err := MakeSidecar(bytes.Repeat([]byte{0xff}, eth.MaxBlobDataSize+1))
+	assert.EqualError(
+		t,
+		err,
+		fmt.Sprintf("blob data length %d exceeds max %d", eth.MaxBlobDataSize+1, eth.MaxBlobDataSize),
+	)
+}
+
+func TestVerifySidecar(t *testing.T) {
+	sidecar, err := MakeSidecarWithMultipleBlobs(bytes.Repeat([]byte{0x01}, eth.MaxBlobDataSize+1))
+	assert.Nil(t, err)
+	assert.Nil(t, VerifySidecar(sidecar))
+
+	// Corrupt the proof of the second blob.
+	sidecar.Proofs[1][10] ^= 0xff
+	assert.ErrorContains(t, VerifySidecar(sidecar), "invalid KZG proof of blob 1")
+
+	// Swap the commitments.
+	sidecar, err = MakeSidecarWithMultipleBlobs(bytes.Repeat([]byte{0x01}, eth.MaxBlobDataSize+1))
+	assert.Nil(t, err)
+	sidecar.Commitments[0], sidecar.Commitments[1] = sidecar.Commitments[1], sidecar.Commitments[0]
+	assert.ErrorContains(t, VerifySidecar(sidecar), "invalid KZG proof of blob 0")
+
+	assert.NotNil(t, VerifySidecar(&types.BlobTxSidecar{}))
+}
+
+func TestCommitmentToVersionedHash(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte{0x01, 0x02, 0x03})
+	assert.Nil(t, err)
+
+	blobHashes := sidecar.BlobHashes()
+	assert.Equal(t, 1, len(blobHashes))
+	assert.Equal(t, blobHashes[0], CommitmentToVersionedHash(sidecar.Commitments[0]))
+	assert.Equal(t, uint8(params.BlobTxHashVersion), CommitmentToVersionedHash(sidecar.Commitments[0]).Bytes()[0])
+}
+
+func TestTransactBlobTxVerifySidecar(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	assert.Nil(t, err)
+
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+	sidecar.Proofs[0][10] ^= 0xff
+
+	client := newTestEthClient(t, &testEthService{})
+	client.VerifySidecars = true
+
+	_, err = client.TransactBlobTx(opts, common.Address{}, nil, sidecar)
+	assert.ErrorContains(t, err, "invalid KZG proof of blob 0")
+}
+
+func TestTransactBlobTxDryRun(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+		sendRawTransaction: func(*types.Transaction) error {
+			t.Fatal("dry run transaction should not be sent")
+			return nil
+		},
+	})
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: big.NewInt(7), GasLimit: 100_000}
+
+	// Without NoSend, a signer is still required.
+	_, err = client.TransactBlobTx(opts, common.HexToAddress("0x02"), []byte{0x03}, sidecar)
+	assert.ErrorIs(t, err, errNoSigner)
+
+	opts.NoSend = true
+	tx, err := client.TransactBlobTx(opts, common.HexToAddress("0x02"), []byte{0x03}, sidecar)
+	assert.Nil(t, err)
+
+	v, r, s := tx.RawSignatureValues()
+	assert.Zero(t, v.Sign())
+	assert.Zero(t, r.Sign())
+	assert.Zero(t, s.Sign())
+
+	assert.Equal(t, uint8(types.BlobTxType), tx.Type())
+	assert.Equal(t, common.Big1, tx.ChainId())
+	assert.Equal(t, uint64(7), tx.Nonce())
+	assert.Equal(t, uint64(100_000), tx.Gas())
+	assert.Equal(t, common.HexToAddress("0x02"), *tx.To())
+	assert.Equal(t, []byte{0x03}, tx.Data())
+	assert.Equal(t, common.Big1, tx.GasTipCap())
+	assert.Equal(t, big.NewInt(3), tx.GasFeeCap())
+	assert.Equal(t, big.NewInt(2), tx.BlobGasFeeCap())
+	assert.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	assert.Equal(t, sidecar, tx.BlobTxSidecar())
+}
+
+func TestEstimateBlobTxFeesMaxBlobFeeCap(t *testing.T) {
+	var (
+		blobBaseFee = big.NewInt(100)
+		client      = newTestEthClient(t, &testEthService{
+			getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+			blobBaseFee:          func() (*big.Int, error) { return blobBaseFee, nil },
+			maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		})
+		opts = &bind.TransactOpts{Context: context.Background()}
+	)
+
+	// Without a ceiling, the blob fee cap is doubled from the blob base fee.
+	fees, err := client.estimateBlobTxFees(opts, BlobFeeStrategyNormal)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(200), fees.BlobFeeCap)
+
+	// The computed blob fee cap is capped by the ceiling.
+	client.MaxBlobFeeCap = big.NewInt(150)
+	fees, err = client.estimateBlobTxFees(opts, BlobFeeStrategyNormal)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(150), fees.BlobFeeCap)
+
+	// The required blob fee exceeds the ceiling.
+	blobBaseFee = big.NewInt(151)
+	_, err = client.estimateBlobTxFees(opts, BlobFeeStrategyNormal)
+	assert.ErrorIs(t, err, ErrBlobFeeCapCeilingExceeded)
+
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+	_, err = client.TransactBlobTx(
+		&bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0, GasLimit: 100_000, NoSend: true},
+		common.Address{},
+		nil,
+		sidecar,
+	)
+	assert.ErrorIs(t, err, ErrBlobFeeCapCeilingExceeded)
+}
+
+func TestEstimateBlobTxFeesPinnedBlock(t *testing.T) {
+	var requested []rpc.BlockNumber
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			requested = append(requested, number)
+			header := newTestHeader(10)
+			header.BaseFee = big.NewInt(1000)
+			if number == 5 {
+				header = newTestHeader(5)
+				header.BaseFee = big.NewInt(100)
+			}
+			excessBlobGas := uint64(0)
+			header.ExcessBlobGas = &excessBlobGas
+			return header, nil
+		},
+	})
+	opts := &bind.TransactOpts{Context: context.Background(), GasTipCap: common.Big1}
+
+	// gasFeeCap = gasTipCap + 2 * baseFee
+	fees, err := client.estimateBlobTxFees(opts, BlobFeeStrategyNormal)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(2001), fees.GasFeeCap)
+
+	client.FeeEstimationBlock = big.NewInt(5)
+	for i := 0; i < 2; i++ {
+		fees, err = client.estimateBlobTxFees(opts, BlobFeeStrategyNormal)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(100), fees.BaseFee)
+		assert.Equal(t, big.NewInt(201), fees.GasFeeCap)
+	}
+	assert.Equal(t, []rpc.BlockNumber{rpc.LatestBlockNumber, 5, 5}, requested)
+}
+
+func TestCreateBlobTxGasLimitMultiplier(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) {
+			header := newTestHeader(1)
+			header.GasLimit = 150_000
+			return header, nil
+		},
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		estimateGas:          func(map[string]interface{}) (uint64, error) { return 100_000, nil },
+		fillTransaction:      fillTestTransaction,
+	})
+	createBlobTx := func(gasLimit uint64) uint64 {
+		tx, err := client.CreateBlobTx(
+			&bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0, GasLimit: gasLimit},
+			common.HexToAddress("0x02"),
+			nil,
+			sidecar,
+		)
+		assert.Nil(t, err)
+		return tx.Gas
+	}
+
+	// The estimated gas limit is inflated by 1.2 by default.
+	assert.Equal(t, uint64(120_000), createBlobTx(0))
+	client.GasLimitMultiplier = 1.25
+	assert.Equal(t, uint64(125_000), createBlobTx(0))
+
+	// The inflated gas limit is clamped to the block gas limit.
+	client.GasLimitMultiplier = 2
+	assert.Equal(t, uint64(150_000), createBlobTx(0))
+
+	// The explicit gas limit is not inflated.
+	assert.Equal(t, uint64(90_000), createBlobTx(90_000))
+}
+
+func TestInflateGasLimit(t *testing.T) {
+	assert.Equal(t, uint64(121), inflateGasLimit(100, 1.201, 0))
+	assert.Equal(t, uint64(100), inflateGasLimit(100, 0.5, 0))
+	assert.Equal(t, uint64(150), inflateGasLimit(100, 2, 150))
+	// Never below the estimation, even if it exceeds the block gas limit.
+	assert.Equal(t, uint64(200), inflateGasLimit(200, 2, 150))
+}
+
+func TestTransactBlobTxSignerMismatch(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+	})
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	assert.Nil(t, err)
+	opts.Nonce, opts.GasLimit, opts.NoSend = common.Big0, 100_000, true
+
+	tx, err := client.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(types.BlobTxType), tx.Type())
+
+	// The signer signs the blob transaction hash of another chain.
+	opts.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signer := types.NewCancunSigner(big.NewInt(2))
+		sig, err := crypto.Sign(signer.Hash(tx).Bytes(), key)
+		if err != nil {
+			return nil, err
+		}
+		return tx.WithSignature(types.NewCancunSigner(common.Big1), sig)
+	}
+	_, err = client.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	assert.ErrorIs(t, err, ErrBlobTxSignerMismatch)
+	assert.ErrorContains(t, err, "expected "+opts.From.Hex())
+
+	// The signer produces an invalid signature.
+	opts.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx.WithSignature(types.NewCancunSigner(common.Big1), make([]byte, crypto.SignatureLength))
+	}
+	_, err = client.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	assert.ErrorIs(t, err, ErrBlobTxSignerMismatch)
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BroadcastTransaction sends the given signed transaction to this client's endpoint and all the given
+// endpoints concurrently, to speed up its propagation, the BroadcastEndpoints will be used if no endpoint
+// is given. It succeeds if any of the endpoints accepts the transaction, an "already known" response counts
+// as accepted, since the transaction is in that node's mempool anyway. If all the endpoints reject it, the
+// joined errors will be returned.
+func (c *EthClient) BroadcastTransaction(ctx context.Context, tx *types.Transaction, endpoints []*EthClient) error {
+	if endpoints == nil {
+		endpoints = c.BroadcastEndpoints
+	}
+
+	// The same endpoint is only sent to once.
+	clients := []*EthClient{c}
+	for _, endpoint := range endpoints {
+		duplicated := false
+		for _, client := range clients {
+			if client == endpoint {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			clients = append(clients, endpoint)
+		}
+	}
+
+	var (
+		errs = make([]error, len(clients))
+		wg   sync.WaitGroup
+	)
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *EthClient) {
+			defer wg.Done()
+			if err := client.SendTransaction(ctx, tx); err != nil && !isTxAlreadyKnownErr(err) {
+				log.Debug("Failed to broadcast transaction", "hash", tx.Hash(), "endpoint", i, "error", err)
+				errs[i] = fmt.Errorf("endpoint %d: %w", i, err)
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"failed to broadcast transaction %s to %d endpoints: %w",
+		tx.Hash(),
+		len(clients),
+		errors.Join(errs...),
+	)
+}
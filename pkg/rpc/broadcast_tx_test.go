@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBroadcastEndpoint creates a mocked L1 endpoint which responds the given error to each sent
+// transaction, the sent transactions are counted.
+func newTestBroadcastEndpoint(t *testing.T, sent *atomic.Int64, err error) *EthClient {
+	return newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+		sendRawTransaction: func(*types.Transaction) error {
+			sent.Add(1)
+			return err
+		},
+	})
+}
+
+func TestBroadcastTransaction(t *testing.T) {
+	var (
+		sent     [3]atomic.Int64
+		tx       = newTestSignedTx(t, 0)
+		primary  = newTestBroadcastEndpoint(t, &sent[0], errors.New("connection refused"))
+		rejected = newTestBroadcastEndpoint(t, &sent[1], errors.New("insufficient funds"))
+		accepted = newTestBroadcastEndpoint(t, &sent[2], nil)
+	)
+
+	// One of the endpoints accepts the transaction.
+	require.Nil(t, primary.BroadcastTransaction(context.Background(), tx, []*EthClient{rejected, accepted}))
+	for i := range sent {
+		require.Equal(t, int64(1), sent[i].Load())
+	}
+
+	// All the endpoints reject the transaction, the duplicated endpoints are only sent to once.
+	err := primary.BroadcastTransaction(context.Background(), tx, []*EthClient{rejected, rejected, primary})
+	require.ErrorContains(t, err, "connection refused")
+	require.ErrorContains(t, err, "insufficient funds")
+	require.Equal(t, int64(2), sent[0].Load())
+	require.Equal(t, int64(2), sent[1].Load())
+
+	// The "already known" responses count as accepted.
+	var knownSent atomic.Int64
+	known := newTestBroadcastEndpoint(t, &knownSent, txpool.ErrAlreadyKnown)
+	require.Nil(t, known.BroadcastTransaction(context.Background(), tx, []*EthClient{rejected}))
+	require.Equal(t, int64(1), knownSent.Load())
+}
+
+func TestTransactBlobTxBroadcast(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	opts.Nonce = common.Big0
+	opts.GasLimit = 100_000
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+
+	var sent [2]atomic.Int64
+	client := newTestBroadcastEndpoint(t, &sent[0], errors.New("connection refused"))
+	client.BroadcastEndpoints = []*EthClient{newTestBroadcastEndpoint(t, &sent[1], nil)}
+
+	_, err = client.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), sent[0].Load())
+	require.Equal(t, int64(1), sent[1].Load())
+}
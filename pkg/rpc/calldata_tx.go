@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ProposalTxType represents the way a proposal payload is carried in the L1 transaction.
+type ProposalTxType uint8
+
+const (
+	// ProposalTxCalldata means the payload is carried in the transaction calldata.
+	ProposalTxCalldata ProposalTxType = iota
+	// ProposalTxBlob means the payload is carried in the transaction blobs.
+	ProposalTxBlob
+)
+
+// String implements the fmt.Stringer interface.
+func (t ProposalTxType) String() string {
+	switch t {
+	case ProposalTxCalldata:
+		return "calldata"
+	case ProposalTxBlob:
+		return "blob"
+	default:
+		return "unknown"
+	}
+}
+
+// ProposalTxCosts represents the estimated costs of proposing the same payload in calldata and in blobs,
+// and the cheaper proposal type.
+type ProposalTxCosts struct {
+	Type         ProposalTxType
+	CalldataCost *big.Int
+	BlobCost     *BlobTxCost
+}
+
+// TransactCalldataTx creates, signs and then sends an EIP-1559 transaction, which carries the payload in
+// the given contract call input instead of blobs.
+func (c *EthClient) TransactCalldataTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+) (*types.Transaction, error) {
+	if opts.Signer == nil {
+		return nil, errNoSigner
+	}
+
+	tx, err := c.CreateCalldataTx(opts, contract, input)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := opts.Signer(opts.From, types.NewTx(tx))
+	if err != nil {
+		c.releaseNonce(opts.From, tx.Nonce)
+		return nil, err
+	}
+	if opts.NoSend {
+		return signedTx, nil
+	}
+	if err := c.SendTransactionWithRetry(opts.Context, signedTx, nil); err != nil {
+		c.releaseNonce(opts.From, signedTx.Nonce())
+		return nil, err
+	}
+	c.markNonceSent(opts.From, signedTx.Nonce())
+	return signedTx, nil
+}
+
+// CreateCalldataTx creates an EIP-1559 transaction by given parameters.
+func (c *EthClient) CreateCalldataTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+) (_ *types.DynamicFeeTx, err error) {
+	if err := c.checkChainID(opts.Context); err != nil {
+		return nil, err
+	}
+
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = opts.Nonce.Uint64()
+	} else {
+		if nonce, err = c.NextNonce(opts.Context, opts.From); err != nil {
+			return nil, err
+		}
+		// The reserved nonce is released if the transaction can't be created.
+		defer func() {
+			if err != nil {
+				c.releaseNonce(opts.From, nonce)
+			}
+		}()
+	}
+
+	_, gasTipCap, gasFeeCap, err := c.estimateCalldataTxFees(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := c.estimateCalldataTxGas(opts, contract, input, gasTipCap, gasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	value := opts.Value
+	if value == nil {
+		value = common.Big0
+	}
+
+	return &types.DynamicFeeTx{
+		ChainID:   c.ChainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gasLimit,
+		To:        &contract,
+		Value:     value,
+		Data:      input,
+	}, nil
+}
+
+// EstimateCalldataTxCost estimates the total cost of an EIP-1559 transaction with the given contract
+// call input, based on the current base fee and the estimated gas limit.
+func (c *EthClient) EstimateCalldataTxCost(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+) (*big.Int, error) {
+	estimateOpts := *opts
+	estimateOpts.Context = ctx
+
+	baseFee, gasTipCap, gasFeeCap, err := c.estimateCalldataTxFees(&estimateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := c.estimateCalldataTxGas(&estimateOpts, contract, input, gasTipCap, gasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Mul(effectiveGasPrice(baseFee, gasTipCap, gasFeeCap), new(big.Int).SetUint64(gasLimit)), nil
+}
+
+// CheaperProposalTx estimates the costs of proposing the same payload in calldata (with the given calldata
+// input) and in blobs (with the given blob input and blob data), and returns the cheaper proposal type.
+func (c *EthClient) CheaperProposalTx(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	contract common.Address,
+	calldataInput []byte,
+	blobInput []byte,
+	blobData []byte,
+) (*ProposalTxCosts, error) {
+	calldataCost, err := c.EstimateCalldataTxCost(ctx, opts, contract, calldataInput)
+	if err != nil {
+		return nil, err
+	}
+
+	blobCost, err := c.EstimateBlobTxCost(ctx, opts, contract, blobInput, blobData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProposalTxCosts{
+		Type:         cheaperProposalTxType(calldataCost, blobCost.Total),
+		CalldataCost: calldataCost,
+		BlobCost:     blobCost,
+	}, nil
+}
+
+// estimateCalldataTxFees fetches the latest header, and estimates the gasTipCap and gasFeeCap of an
+// EIP-1559 transaction, the values which have already been set in the transact options will be respected,
+// the legacy gas price will be ignored.
+func (c *EthClient) estimateCalldataTxFees(opts *bind.TransactOpts) (*big.Int, *big.Int, *big.Int, error) {
+	header, err := c.HeaderByNumber(opts.Context, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	estimateOpts := *opts
+	estimateOpts.GasPrice = nil
+	gasTipCap, gasFeeCap, err := c.estimateGasFeeCaps(&estimateOpts, header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return header.BaseFee, gasTipCap, gasFeeCap, nil
+}
+
+// estimateCalldataTxGas returns the gas limit in the transact options if it is set, otherwise estimates
+// the gas limit of an EIP-1559 transaction with the given contract call input.
+func (c *EthClient) estimateCalldataTxGas(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	gasTipCap *big.Int,
+	gasFeeCap *big.Int,
+) (uint64, error) {
+	if opts.GasLimit != 0 {
+		return opts.GasLimit, nil
+	}
+
+	return c.EstimateGas(opts.Context, ethereum.CallMsg{
+		From:      opts.From,
+		To:        &contract,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Value:     opts.Value,
+		Data:      input,
+	})
+}
+
+// effectiveGasPrice returns the effective gas price of an EIP-1559 transaction, which is
+// `min(gasFeeCap, baseFee + gasTipCap)`.
+func effectiveGasPrice(baseFee, gasTipCap, gasFeeCap *big.Int) *big.Int {
+	gasPrice := new(big.Int).Set(gasFeeCap)
+	if baseFee != nil {
+		if price := new(big.Int).Add(baseFee, gasTipCap); price.Cmp(gasPrice) < 0 {
+			gasPrice = price
+		}
+	}
+
+	return gasPrice
+}
+
+// cheaperProposalTxType returns the proposal type with the lower cost, calldata is preferred if the
+// costs are equal, since it doesn't require blob space.
+func cheaperProposalTxType(calldataCost, blobCost *big.Int) ProposalTxType {
+	if blobCost.Cmp(calldataCost) < 0 {
+		return ProposalTxBlob
+	}
+
+	return ProposalTxCalldata
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheaperProposalTxType(t *testing.T) {
+	require.Equal(t, ProposalTxBlob, cheaperProposalTxType(big.NewInt(2), big.NewInt(1)))
+	require.Equal(t, ProposalTxCalldata, cheaperProposalTxType(big.NewInt(1), big.NewInt(2)))
+	require.Equal(t, ProposalTxCalldata, cheaperProposalTxType(big.NewInt(1), big.NewInt(1)))
+	require.Equal(t, "calldata", ProposalTxCalldata.String())
+	require.Equal(t, "blob", ProposalTxBlob.String())
+}
+
+func TestCheaperProposalTx(t *testing.T) {
+	var (
+		payload     = bytes.Repeat([]byte{0xff}, 4096)
+		calldataGas = uint64(21_000 + 16*len(payload))
+		blobGas     = uint64(25_000)
+	)
+
+	for _, tc := range []struct {
+		name         string
+		baseFee      int64
+		blobBaseFee  int64
+		expectedType ProposalTxType
+	}{
+		{"cheap blob space", 10, 1, ProposalTxBlob},
+		{"expensive blob space", 10, 1_000, ProposalTxCalldata},
+		{"expensive execution gas", 10_000, 1_000, ProposalTxBlob},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestEthClient(t, &testEthService{
+				getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+					header := newTestHeader(1)
+					header.BaseFee = big.NewInt(tc.baseFee)
+					return header, nil
+				},
+				blobBaseFee: func() (*big.Int, error) { return big.NewInt(tc.blobBaseFee), nil },
+				estimateGas: func(args map[string]interface{}) (uint64, error) {
+					if _, ok := args["blobVersionedHashes"]; ok {
+						return blobGas, nil
+					}
+					return calldataGas, nil
+				},
+			})
+
+			costs, err := client.CheaperProposalTx(
+				context.Background(),
+				&bind.TransactOpts{From: common.HexToAddress("0x01"), GasTipCap: common.Big1},
+				common.HexToAddress("0x02"),
+				payload,
+				[]byte{0x01},
+				payload,
+			)
+			require.Nil(t, err)
+			require.Equal(t, tc.expectedType, costs.Type)
+
+			// gasPrice = min(gasTipCap + 2 * baseFee, baseFee + gasTipCap)
+			require.Equal(
+				t,
+				new(big.Int).Mul(big.NewInt(tc.baseFee+1), new(big.Int).SetUint64(calldataGas)),
+				costs.CalldataCost,
+			)
+			require.Equal(t, inflateGasLimit(blobGas, 0, 0), costs.BlobCost.GasLimit)
+		})
+	}
+}
+
+func TestTransactCalldataTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	opts.NoSend = true
+	opts.GasTipCap = common.Big2
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			header := newTestHeader(1)
+			header.BaseFee = big.NewInt(7)
+			return header, nil
+		},
+		getTransactionCount: func(common.Address) (uint64, error) { return 5, nil },
+		estimateGas:         func(map[string]interface{}) (uint64, error) { return 30_000, nil },
+	})
+
+	tx, err := client.TransactCalldataTx(opts, common.HexToAddress("0x02"), []byte{0x01, 0x02})
+	require.Nil(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	require.Equal(t, uint64(5), tx.Nonce())
+	require.Equal(t, uint64(30_000), tx.Gas())
+	require.Equal(t, big.NewInt(2), tx.GasTipCap())
+	require.Equal(t, big.NewInt(2+2*7), tx.GasFeeCap())
+	require.Equal(t, []byte{0x01, 0x02}, tx.Data())
+
+	sender, err := types.Sender(types.LatestSignerForChainID(common.Big1), tx)
+	require.Nil(t, err)
+	require.Equal(t, opts.From, sender)
+
+	// No signer.
+	_, err = client.TransactCalldataTx(&bind.TransactOpts{}, common.HexToAddress("0x02"), nil)
+	require.ErrorContains(t, err, "no signer")
+}
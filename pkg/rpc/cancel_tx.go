@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	// ErrTxAlreadyMined is returned when cancelling a transaction whose nonce has already been used on chain.
+	ErrTxAlreadyMined = errors.New("transaction already mined")
+	// cancelTxFeeBumpPercent is the fee bump percentage required by the blob pool for replacing a blob transaction.
+	cancelTxFeeBumpPercent uint64 = 100
+)
+
+// CancelTx cancels the pending transaction of the given account with the given nonce, by sending a
+// zero-value self-transfer at the same nonce with fees high enough to replace it. Since the mempool
+// doesn't allow replacing a blob transaction with a non-blob transaction, the cancellation transaction
+// carries an empty blob. Use WaitMined to wait for the returned cancellation transaction to confirm.
+func (c *EthClient) CancelTx(
+	ctx context.Context,
+	from common.Address,
+	nonce uint64,
+	opts *bind.TransactOpts,
+) (*types.Transaction, error) {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+	if opts == nil || opts.Signer == nil {
+		return nil, errors.New("signer is required for cancelling a transaction")
+	}
+
+	if err := c.checkChainID(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make sure the transaction is still pending.
+	minedNonce, err := c.NonceAt(ctx, from, nil)
+	if err != nil {
+		return nil, err
+	}
+	if nonce < minedNonce {
+		return nil, fmt.Errorf("%w: account %s, nonce %d", ErrTxAlreadyMined, from, nonce)
+	}
+
+	fees, err := c.estimateBlobTxFees(
+		&bind.TransactOpts{Context: ctx, GasTipCap: opts.GasTipCap, GasFeeCap: opts.GasFeeCap},
+		BlobFeeStrategyNormal,
+	)
+	if err != nil {
+		return nil, err
+	}
+	gasTipCap, gasFeeCap, blobFeeCap := fees.GasTipCap, fees.GasFeeCap, fees.BlobFeeCap
+
+	// Make sure the fees are high enough to replace the pending transaction.
+	replaced, err := c.pendingTxByNonce(ctx, from, nonce)
+	if err != nil {
+		// Without the fees of the pending transaction, the cancellation transaction at the market fees
+		// would just be rejected as underpriced, so we only go on if the caller has given the fees.
+		if opts.GasTipCap == nil && opts.GasFeeCap == nil {
+			return nil, fmt.Errorf("failed to fetch the pending transaction to cancel: %w", err)
+		}
+		log.Warn("Failed to fetch the pending transaction to cancel", "account", from, "nonce", nonce, "error", err)
+	}
+	if replaced != nil {
+		gasTipCap = maxBig(gasTipCap, bumpFee(replaced.GasTipCap(), cancelTxFeeBumpPercent))
+		gasFeeCap = maxBig(gasFeeCap, bumpFee(replaced.GasFeeCap(), cancelTxFeeBumpPercent))
+		if replaced.BlobGasFeeCap() != nil {
+			blobFeeCap = maxBig(blobFeeCap, bumpFee(replaced.BlobGasFeeCap(), cancelTxFeeBumpPercent))
+		}
+	}
+	gasFeeCap = maxBig(gasFeeCap, gasTipCap)
+
+	sidecar, err := MakeSidecar([]byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := opts.Signer(from, types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(c.ChainID),
+		Nonce:      nonce,
+		GasTipCap:  uint256.MustFromBig(gasTipCap),
+		GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+		Gas:        params.TxGas,
+		To:         from,
+		Value:      new(uint256.Int),
+		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.SendTransaction(ctx, signedTx); err != nil {
+		return nil, err
+	}
+
+	log.Info(
+		"Cancellation transaction sent",
+		"account", from,
+		"nonce", nonce,
+		"hash", signedTx.Hash(),
+		"gasTipCap", gasTipCap,
+		"gasFeeCap", gasFeeCap,
+		"blobFeeCap", blobFeeCap,
+	)
+
+	return signedTx, nil
+}
+
+// pendingTxByNonce fetches the pending transaction of the given account with the given nonce
+// from the node's mempool, returns nil if not found.
+func (c *EthClient) pendingTxByNonce(
+	ctx context.Context,
+	from common.Address,
+	nonce uint64,
+) (*types.Transaction, error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+
+	var content map[string]map[string]*types.Transaction
+	if err := c.CallContext(ctxWithTimeout, &content, "txpool_contentFrom", from); err != nil {
+		return nil, err
+	}
+
+	return content["pending"][strconv.FormatUint(nonce, 10)], nil
+}
+
+// maxBig returns the larger one of the given two big integers.
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCancelTxService(
+	minedNonce uint64,
+	pending map[string]map[string]*types.Transaction,
+	sent *[]*types.Transaction,
+) *testEthService {
+	return &testEthService{
+		getTransactionCount: func(common.Address) (uint64, error) { return minedNonce, nil },
+		getHeaderByNumber:   func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:         func() (*big.Int, error) { return common.Big1, nil },
+		txPoolContentFrom: func(common.Address) (map[string]map[string]*types.Transaction, error) {
+			return pending, nil
+		},
+		sendRawTransaction: func(tx *types.Transaction) error {
+			*sent = append(*sent, tx)
+			return nil
+		},
+	}
+}
+
+func TestCancelTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	opts.GasTipCap = common.Big1
+	opts.GasFeeCap = common.Big2
+
+	pendingTx := newTestSignedBlobTx(t, opts, 5)
+
+	var sent []*types.Transaction
+	client := newTestEthClient(t, newTestCancelTxService(
+		5,
+		map[string]map[string]*types.Transaction{"pending": {"5": pendingTx}},
+		&sent,
+	))
+
+	tx, err := client.CancelTx(context.Background(), opts.From, 5, opts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(sent))
+	require.Equal(t, tx.Hash(), sent[0].Hash())
+
+	require.Equal(t, uint64(5), tx.Nonce())
+	require.Equal(t, opts.From, *tx.To())
+	require.Zero(t, tx.Value().Sign())
+	require.Equal(t, types.BlobTxType, int(tx.Type()))
+
+	// The fees should be high enough to replace the pending blob transaction.
+	require.Equal(t, big.NewInt(200), tx.GasTipCap())
+	require.Equal(t, big.NewInt(400), tx.GasFeeCap())
+	require.Equal(t, big.NewInt(20), tx.BlobGasFeeCap())
+
+	sender, err := types.LatestSignerForChainID(common.Big1).Sender(tx)
+	require.Nil(t, err)
+	require.Equal(t, opts.From, sender)
+}
+
+func TestCancelTxAlreadyMined(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var sent []*types.Transaction
+	client := newTestEthClient(t, newTestCancelTxService(6, nil, &sent))
+
+	_, err = client.CancelTx(context.Background(), opts.From, 5, opts)
+	require.ErrorIs(t, err, ErrTxAlreadyMined)
+	require.Empty(t, sent)
+}
+
+func TestCancelTxPendingTxUnavailable(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var sent []*types.Transaction
+	service := newTestCancelTxService(5, nil, &sent)
+	service.maxPriorityFeePerGas = func() (*big.Int, error) { return common.Big1, nil }
+	service.txPoolContentFrom = func(common.Address) (map[string]map[string]*types.Transaction, error) {
+		return nil, errors.New("method not found")
+	}
+	client := newTestEthClient(t, service)
+
+	// Without any fees given, the cancellation transaction would be underpriced.
+	_, err = client.CancelTx(context.Background(), opts.From, 5, opts)
+	require.ErrorContains(t, err, "method not found")
+	require.Empty(t, sent)
+
+	// With the given fees, the cancellation transaction should still be sent.
+	opts.GasTipCap = big.NewInt(300)
+	opts.GasFeeCap = big.NewInt(600)
+	tx, err := client.CancelTx(context.Background(), opts.From, 5, opts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(sent))
+	require.Equal(t, big.NewInt(300), tx.GasTipCap())
+	require.Equal(t, big.NewInt(600), tx.GasFeeCap())
+}
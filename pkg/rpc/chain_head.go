@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// chainHeadBufferSize is the number of recent headers kept for detecting reorgs.
+	chainHeadBufferSize = 64
+)
+
+// ChainHeadEventType is the type of a ChainHeadEvent.
+type ChainHeadEventType int
+
+const (
+	// NewHead means the new head extends the previous one.
+	NewHead ChainHeadEventType = iota
+	// Reorg means some of the previous heads have been reverted.
+	Reorg
+)
+
+// String implements the fmt.Stringer interface.
+func (t ChainHeadEventType) String() string {
+	switch t {
+	case NewHead:
+		return "NewHead"
+	case Reorg:
+		return "Reorg"
+	default:
+		return "Unknown"
+	}
+}
+
+// ChainHeadEvent represents a change of the chain head.
+type ChainHeadEvent struct {
+	Type ChainHeadEventType
+	Head *types.Header
+	// CommonAncestor is the latest header kept by both the old and the new chains, it will be nil
+	// if the reorg is deeper than the recent headers buffer.
+	CommonAncestor *types.Header
+	// Reverted contains the reverted headers, from the old head backwards.
+	Reverted []*types.Header
+	// Added contains the newly added headers, in ascending order, the last one is the new head.
+	Added []*types.Header
+}
+
+// SubscribeChainHead subscribes the new chain heads, and emits the events tagged as either NewHead
+// or Reorg, the returned channel will be closed once the given context is done.
+func (c *EthClient) SubscribeChainHead(ctx context.Context) (<-chan *ChainHeadEvent, error) {
+	var (
+		headCh = make(chan *types.Header, chainHeadBufferSize)
+		ch     = make(chan *ChainHeadEvent, chainHeadBufferSize)
+		sub    = SubscribeChainHead(c, headCh)
+		t      = newChainHeadTracker(func(ctx context.Context, hash common.Hash) (*types.Header, error) {
+			return c.HeaderByHash(ctx, hash)
+		})
+	)
+
+	go func() {
+		defer close(ch)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case head := <-headCh:
+				events, err := t.update(ctx, head)
+				if err != nil {
+					log.Warn("Failed to track chain head", "number", head.Number, "hash", head.Hash(), "error", err)
+					continue
+				}
+				for _, e := range events {
+					select {
+					case ch <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// chainHeadTracker keeps a small ring buffer of the recent headers to detect the parent hash mismatches.
+type chainHeadTracker struct {
+	headers      []*types.Header
+	headerByHash func(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// newChainHeadTracker creates a new chainHeadTracker instance.
+func newChainHeadTracker(
+	headerByHash func(ctx context.Context, hash common.Hash) (*types.Header, error),
+) *chainHeadTracker {
+	return &chainHeadTracker{headerByHash: headerByHash}
+}
+
+// update updates the tracker with the given new head, and returns the chain head events.
+func (t *chainHeadTracker) update(ctx context.Context, head *types.Header) ([]*ChainHeadEvent, error) {
+	if len(t.headers) == 0 {
+		t.push(head)
+		return []*ChainHeadEvent{{Type: NewHead, Head: head, Added: []*types.Header{head}}}, nil
+	}
+	if t.indexOf(head.Hash()) >= 0 {
+		// Already tracked.
+		return nil, nil
+	}
+
+	// Walk back from the new head until an ancestor kept in the buffer is found.
+	var (
+		added    = []*types.Header{head}
+		ancestor = -1
+	)
+	for len(added) <= chainHeadBufferSize {
+		if ancestor = t.indexOf(added[0].ParentHash); ancestor >= 0 {
+			break
+		}
+		if added[0].Number.Sign() == 0 {
+			break
+		}
+
+		parent, err := t.headerByHash(ctx, added[0].ParentHash)
+		if err != nil {
+			return nil, err
+		}
+		added = append([]*types.Header{parent}, added...)
+	}
+
+	// The new head extends the previous one, maybe with some missed headers.
+	if ancestor == len(t.headers)-1 {
+		events := make([]*ChainHeadEvent, len(added))
+		for i, header := range added {
+			t.push(header)
+			events[i] = &ChainHeadEvent{Type: NewHead, Head: header, Added: []*types.Header{header}}
+		}
+		return events, nil
+	}
+
+	e := &ChainHeadEvent{Type: Reorg, Head: head, Added: added}
+	var reverted []*types.Header
+	if ancestor >= 0 {
+		e.CommonAncestor = t.headers[ancestor]
+		reverted = t.headers[ancestor+1:]
+		t.headers = t.headers[:ancestor+1]
+	} else {
+		log.Warn("Reorg is deeper than the recent headers buffer", "head", head.Number, "buffer", len(t.headers))
+		reverted = t.headers
+		t.headers = nil
+	}
+	for i := len(reverted) - 1; i >= 0; i-- {
+		e.Reverted = append(e.Reverted, reverted[i])
+	}
+	for _, header := range added {
+		t.push(header)
+	}
+
+	var ancestorNumber uint64
+	if e.CommonAncestor != nil {
+		ancestorNumber = e.CommonAncestor.Number.Uint64()
+	}
+	log.Info(
+		"Chain reorg detected",
+		"newHead", head.Number,
+		"commonAncestor", ancestorNumber,
+		"reverted", len(e.Reverted),
+		"added", len(e.Added),
+	)
+
+	return []*ChainHeadEvent{e}, nil
+}
+
+// push appends the given header to the buffer, and drops the oldest one if the buffer is full.
+func (t *chainHeadTracker) push(header *types.Header) {
+	t.headers = append(t.headers, header)
+	if len(t.headers) > chainHeadBufferSize {
+		t.headers = append([]*types.Header{}, t.headers[len(t.headers)-chainHeadBufferSize:]...)
+	}
+}
+
+// indexOf returns the index of the header with the given hash in the buffer, or -1 if not found.
+func (t *chainHeadTracker) indexOf(hash common.Hash) int {
+	for i := len(t.headers) - 1; i >= 0; i-- {
+		if t.headers[i].Hash() == hash {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain is a simulated chain which can be reorged, for testing.
+type testChain struct {
+	headers map[common.Hash]*types.Header
+	mutex   sync.Mutex
+}
+
+// extend makes n new headers on top of the given parent, the given fork ID will be used to
+// distinguish the headers of different forks.
+func (c *testChain) extend(parent *types.Header, n int, forkID byte) []*types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	headers := make([]*types.Header, n)
+	for i := range headers {
+		header := newTestHeader(0)
+		if parent != nil {
+			header.Number = new(big.Int).Add(parent.Number, common.Big1)
+			header.ParentHash = parent.Hash()
+		}
+		header.Extra = []byte{forkID}
+
+		c.headers[header.Hash()] = header
+		headers[i], parent = header, header
+	}
+
+	return headers
+}
+
+func (c *testChain) headerByHash(_ context.Context, hash common.Hash) (*types.Header, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.headers[hash], nil
+}
+
+func TestChainHeadTrackerReorg(t *testing.T) {
+	var (
+		chain   = &testChain{headers: make(map[common.Hash]*types.Header)}
+		tracker = newChainHeadTracker(chain.headerByHash)
+		main    = chain.extend(nil, 10, 0)
+	)
+
+	for _, header := range main {
+		events, err := tracker.update(context.Background(), header)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(events))
+		require.Equal(t, NewHead, events[0].Type)
+		require.Equal(t, header.Hash(), events[0].Head.Hash())
+	}
+
+	// Reorg the last three blocks, with a longer fork.
+	fork := chain.extend(main[6], 4, 1)
+	events, err := tracker.update(context.Background(), fork[len(fork)-1])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(events))
+
+	e := events[0]
+	require.Equal(t, Reorg, e.Type)
+	require.Equal(t, main[6].Hash(), e.CommonAncestor.Hash())
+	require.Equal(t, []*types.Header{main[9], main[8], main[7]}, e.Reverted)
+	require.Equal(t, fork, e.Added)
+
+	// The fork is extended, with a missed header.
+	next := chain.extend(fork[len(fork)-1], 2, 1)
+	events, err = tracker.update(context.Background(), next[1])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(events))
+	for i, e := range events {
+		require.Equal(t, NewHead, e.Type)
+		require.Equal(t, next[i].Hash(), e.Head.Hash())
+	}
+
+	// Duplicated header.
+	events, err = tracker.update(context.Background(), next[1])
+	require.Nil(t, err)
+	require.Empty(t, events)
+}
+
+func TestSubscribeChainHeadReorg(t *testing.T) {
+	var (
+		chain   = &testChain{headers: make(map[common.Hash]*types.Header)}
+		service = &testEthService{
+			newHeads: make(chan *types.Header),
+			getHeaderByHash: func(hash common.Hash) (*types.Header, error) {
+				return chain.headerByHash(context.Background(), hash)
+			},
+		}
+		client = newTestEthClient(t, service)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := client.SubscribeChainHead(ctx)
+	require.Nil(t, err)
+
+	main := chain.extend(nil, 5, 0)
+	fork := chain.extend(main[2], 3, 1)
+	go func() {
+		for _, header := range append(main, fork[2]) {
+			select {
+			case service.newHeads <- header:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for _, header := range main {
+		e := waitChainHeadEvent(t, ch)
+		require.Equal(t, NewHead, e.Type)
+		require.Equal(t, header.Hash(), e.Head.Hash())
+	}
+
+	e := waitChainHeadEvent(t, ch)
+	require.Equal(t, Reorg, e.Type)
+	require.Equal(t, main[2].Hash(), e.CommonAncestor.Hash())
+	require.Equal(t, 2, len(e.Reverted))
+	require.Equal(t, 3, len(e.Added))
+	require.Equal(t, fork[2].Hash(), e.Head.Hash())
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func waitChainHeadEvent(t *testing.T, ch <-chan *ChainHeadEvent) *ChainHeadEvent {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("chain head event not received")
+	}
+	return nil
+}
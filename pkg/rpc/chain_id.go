@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	// ErrChainIDMismatch is returned when the chain ID reported by the endpoint differs from the cached
+	// ChainID, e.g. the endpoint has been switched to another network.
+	ErrChainIDMismatch = errors.New("chain ID mismatch")
+	// defaultChainIDCheckInterval is the default interval of re-checking the cached chain ID.
+	defaultChainIDCheckInterval = 5 * time.Minute
+)
+
+// checkChainID re-fetches the chain ID from the endpoint if it hasn't been checked for ChainIDCheckInterval,
+// and returns an error wrapping ErrChainIDMismatch if it differs from the cached ChainID.
+func (c *EthClient) checkChainID(ctx context.Context) error {
+	interval := c.ChainIDCheckInterval
+	if interval == 0 {
+		interval = defaultChainIDCheckInterval
+	}
+	if time.Since(time.Unix(0, c.chainIDCheckedAt.Load())) < interval {
+		return nil
+	}
+
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+
+	chainID, err := c.ethClient.ChainID(ctxWithTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the chain ID: %w", err)
+	}
+	if err := c.compareChainID(chainID); err != nil {
+		return err
+	}
+	c.chainIDCheckedAt.Store(time.Now().UnixNano())
+
+	return nil
+}
+
+// compareChainID returns an error wrapping ErrChainIDMismatch if the given chain ID differs from the cached
+// ChainID.
+func (c *EthClient) compareChainID(chainID *big.Int) error {
+	if chainID == nil || c.ChainID.Cmp(chainID) != 0 {
+		return fmt.Errorf("%w: cached %v, endpoint %v", ErrChainIDMismatch, c.ChainID, chainID)
+	}
+
+	return nil
+}
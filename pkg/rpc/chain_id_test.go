@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckChainID(t *testing.T) {
+	var (
+		chainID atomic.Int64
+		fetched atomic.Int64
+	)
+	chainID.Store(1)
+	client := newTestEthClient(t, &testEthService{
+		chainID: func() (*big.Int, error) {
+			fetched.Add(1)
+			return big.NewInt(chainID.Load()), nil
+		},
+	})
+	client.ChainIDCheckInterval = time.Hour
+
+	// The chain ID is only re-fetched once in each interval.
+	require.Nil(t, client.checkChainID(context.Background()))
+	require.Nil(t, client.checkChainID(context.Background()))
+	require.Equal(t, int64(1), fetched.Load())
+
+	// The endpoint has been switched to another network.
+	chainID.Store(2)
+	require.Nil(t, client.checkChainID(context.Background()))
+	client.ChainIDCheckInterval = time.Nanosecond
+	require.ErrorIs(t, client.checkChainID(context.Background()), ErrChainIDMismatch)
+	require.ErrorIs(t, client.checkChainID(context.Background()), ErrChainIDMismatch)
+	require.Equal(t, int64(3), fetched.Load())
+}
+
+func TestCreateBlobTxChainIDMismatch(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+
+	var chainID atomic.Int64
+	chainID.Store(1)
+	client := newTestEthClient(t, &testEthService{
+		chainID:              func() (*big.Int, error) { return big.NewInt(chainID.Load()), nil },
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+	})
+	client.ChainIDCheckInterval = time.Nanosecond
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0, GasLimit: 100_000}
+
+	_, err = client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.Nil(t, err)
+
+	chainID.Store(2)
+	_, err = client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+
+	// The chain ID of the transaction filled by the endpoint is checked as well.
+	chainID.Store(1)
+	client.ChainID = big.NewInt(2)
+	client.ChainIDCheckInterval = time.Hour
+	_, err = client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+}
@@ -0,0 +1,285 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// ErrCircuitOpen is returned by CircuitBreakerEthClient without calling the endpoint, when the circuit
+	// breaker is open after too many consecutive failures, or a recovery probe is already in flight.
+	ErrCircuitOpen                 = errors.New("circuit breaker is open")
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int32
+
+const (
+	// CircuitClosed means the calls are passed through to the endpoint.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means the calls are short-circuited with ErrCircuitOpen until the cooldown elapses.
+	CircuitOpen
+	// CircuitHalfOpen means a single probe call is passed through to test whether the endpoint recovered.
+	CircuitHalfOpen
+)
+
+// String implements the fmt.Stringer interface.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerEthClient wraps an EthClient endpoint with a circuit breaker. After threshold consecutive
+// calls fail with a connection error or a timeout, the breaker opens and the calls fail fast with
+// ErrCircuitOpen for the cooldown, so that the callers can move on to the healthy alternatives. Then the
+// breaker half-opens, and the next call is passed through as a probe, which closes the breaker again if
+// it succeeds, or re-opens it otherwise. The JSON-RPC errors returned by a reachable node don't count as
+// failures. If the wrapped client has a metrics registry, the breaker state is recorded as the
+// `rpc/circuit/state` gauge.
+type CircuitBreakerEthClient struct {
+	client    *EthClient
+	threshold int
+	cooldown  time.Duration
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+	mutex     sync.Mutex
+}
+
+// NewCircuitBreakerEthClient creates a new CircuitBreakerEthClient instance, a zero threshold or cooldown
+// means the default value, which are 5 consecutive failures and 30 seconds.
+func NewCircuitBreakerEthClient(
+	client *EthClient,
+	threshold int,
+	cooldown time.Duration,
+) *CircuitBreakerEthClient {
+	if threshold <= 0 {
+		threshold = defaultCircuitBreakerThreshold
+	}
+	if cooldown == 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	return &CircuitBreakerEthClient{client: client, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Client returns the wrapped EthClient.
+func (b *CircuitBreakerEthClient) Client() *EthClient {
+	return b.client
+}
+
+// State returns the current state of the circuit breaker, an open breaker whose cooldown has elapsed is
+// reported as half-open.
+func (b *CircuitBreakerEthClient) State() CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow checks whether a call can be passed through to the endpoint.
+func (b *CircuitBreakerEthClient) allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record records the result of a call which has been passed through to the endpoint.
+func (b *CircuitBreakerEthClient) record(ctx context.Context, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+	// The caller gave up, it says nothing about the endpoint.
+	if ctx != nil && ctx.Err() != nil {
+		return
+	}
+
+	if !isFailoverError(err) {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			log.Info("RPC endpoint recovered, closing the circuit breaker")
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		if b.state != CircuitOpen {
+			log.Warn("RPC endpoint unavailable, opening the circuit breaker", "failures", b.failures, "error", err)
+		}
+		b.setState(CircuitOpen)
+		b.openedAt = b.now()
+	}
+}
+
+// setState sets the circuit breaker state, and records it to the metrics registry, the mutex must be held.
+func (b *CircuitBreakerEthClient) setState(state CircuitState) {
+	b.state = state
+	if b.client.MetricsRegistry != nil {
+		metrics.GetOrRegisterGauge("rpc/circuit/state", b.client.MetricsRegistry).Update(int64(state))
+	}
+}
+
+// breakerCall calls the given function against the wrapped endpoint, if the circuit breaker allows.
+func breakerCall[T any](
+	ctx context.Context,
+	b *CircuitBreakerEthClient,
+	call func(c *EthClient) (T, error),
+) (T, error) {
+	if err := b.allow(); err != nil {
+		var result T
+		return result, err
+	}
+
+	result, err := call(b.client)
+	b.record(ctx, err)
+
+	return result, err
+}
+
+// BlockNumber returns the most recent block number.
+func (b *CircuitBreakerEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (uint64, error) {
+		return c.BlockNumber(ctx)
+	})
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (b *CircuitBreakerEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*types.Header, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (b *CircuitBreakerEthClient) BalanceAt(
+	ctx context.Context,
+	account common.Address,
+	number *big.Int,
+) (*big.Int, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*big.Int, error) {
+		return c.BalanceAt(ctx, account, number)
+	})
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (b *CircuitBreakerEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (uint64, error) {
+		return c.PendingNonceAt(ctx, account)
+	})
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
+// allow a timely execution of a transaction.
+func (b *CircuitBreakerEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*big.Int, error) {
+		return c.SuggestGasTipCap(ctx)
+	})
+}
+
+// BlobBaseFee retrieves the current blob base fee.
+func (b *CircuitBreakerEthClient) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*big.Int, error) {
+		return c.BlobBaseFee(ctx)
+	})
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction.
+func (b *CircuitBreakerEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (uint64, error) {
+		return c.EstimateGas(ctx, msg)
+	})
+}
+
+// FillTransaction fills the given transaction arguments.
+func (b *CircuitBreakerEthClient) FillTransaction(
+	ctx context.Context,
+	args *TransactionArgs,
+) (*types.Transaction, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*types.Transaction, error) {
+		return c.FillTransaction(ctx, args)
+	})
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+func (b *CircuitBreakerEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return breakerCall(ctx, b, func(c *EthClient) (*types.Receipt, error) {
+		return c.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution.
+func (b *CircuitBreakerEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := breakerCall(ctx, b, func(c *EthClient) (struct{}, error) {
+		return struct{}{}, c.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// CreateBlobTx creates a blob transaction by given parameters, see EthClient.CreateBlobTx.
+func (b *CircuitBreakerEthClient) CreateBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+) (*types.BlobTx, error) {
+	return breakerCall(opts.Context, b, func(c *EthClient) (*types.BlobTx, error) {
+		return c.CreateBlobTx(opts, contract, input, sidecar)
+	})
+}
+
+// TransactBlobTx creates, signs and then sends blob transactions, see EthClient.TransactBlobTx.
+func (b *CircuitBreakerEthClient) TransactBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+) (*types.Transaction, error) {
+	return breakerCall(opts.Context, b, func(c *EthClient) (*types.Transaction, error) {
+		return c.TransactBlobTx(opts, contract, input, sidecar)
+	})
+}
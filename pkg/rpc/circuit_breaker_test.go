@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakerEthClient(t *testing.T) {
+	var (
+		down  atomic.Bool
+		calls atomic.Int32
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			calls.Add(1)
+			if down.Load() {
+				time.Sleep(100 * time.Millisecond)
+			}
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+	client.CallTimeout = 20 * time.Millisecond
+	client.MetricsRegistry = metrics.NewRegistry()
+	metricsEnabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = metricsEnabled }()
+	stateGauge := func() int64 {
+		return metrics.GetOrRegisterGauge("rpc/circuit/state", client.MetricsRegistry).Snapshot().Value()
+	}
+
+	now := time.Now()
+	b := NewCircuitBreakerEthClient(client, 3, time.Minute)
+	b.now = func() time.Time { return now }
+	require.Equal(t, CircuitClosed, b.State())
+
+	// Trip the breaker by the consecutive timeouts.
+	down.Store(true)
+	for i := 0; i < 3; i++ {
+		_, err := b.HeaderByNumber(context.Background(), common.Big1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	require.Equal(t, CircuitOpen, b.State())
+	require.Equal(t, int64(CircuitOpen), stateGauge())
+
+	// The calls are short-circuited during the cooldown.
+	_, err := b.HeaderByNumber(context.Background(), common.Big1)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, int32(3), calls.Load())
+
+	// A failed probe re-opens the breaker.
+	now = now.Add(time.Minute)
+	require.Equal(t, CircuitHalfOpen, b.State())
+	_, err = b.HeaderByNumber(context.Background(), common.Big1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, CircuitOpen, b.State())
+	require.Equal(t, int32(4), calls.Load())
+
+	// A succeeded probe closes the breaker.
+	down.Store(false)
+	now = now.Add(time.Minute)
+	header, err := b.HeaderByNumber(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), header.Number.Uint64())
+	require.Equal(t, CircuitClosed, b.State())
+	require.Equal(t, int64(CircuitClosed), stateGauge())
+}
+
+func TestCircuitBreakerEthClientIgnoresNodeErrors(t *testing.T) {
+	b := NewCircuitBreakerEthClient(newTestEthClient(t, &testEthService{}), 1, 0)
+
+	// The JSON-RPC errors come from a reachable node, the breaker should stay closed.
+	for i := 0; i < 3; i++ {
+		_, err := b.HeaderByNumber(context.Background(), common.Big1)
+		require.ErrorContains(t, err, errNotImplemented.Error())
+	}
+	require.Equal(t, CircuitClosed, b.State())
+	require.Equal(t, defaultCircuitBreakerCooldown, b.cooldown)
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	b := NewCircuitBreakerEthClient(newTestEthClient(t, &testEthService{}), 1, time.Minute)
+	b.state, b.openedAt = CircuitOpen, time.Now().Add(-time.Minute)
+
+	require.Nil(t, b.allow())
+	require.ErrorIs(t, b.allow(), ErrCircuitOpen)
+	b.record(context.Background(), nil)
+	require.Equal(t, CircuitClosed, b.State())
+	require.Nil(t, b.allow())
+}
@@ -2,9 +2,11 @@ package rpc
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
@@ -27,6 +29,9 @@ type Client struct {
 	TaikoL2        *bindings.TaikoL2Client
 	TaikoToken     *bindings.TaikoToken
 	GuardianProver *bindings.GuardianProver
+	// taikoL1Address and protocolConfig are used by GetProtocolConfig.
+	taikoL1Address common.Address
+	protocolConfig protocolConfigCache
 }
 
 // ClientConfig contains all configs which will be used to initializing an
@@ -44,6 +49,12 @@ type ClientConfig struct {
 	L2EngineEndpoint      string
 	JwtSecret             string
 	Timeout               time.Duration
+	CallTimeout           time.Duration
+	MetricsRegistry       metrics.Registry
+	// L1Headers and L2Headers are the static HTTP headers attached to all JSON-RPC requests sent to
+	// the L1 and L2 (including the L2 checkpoint) nodes, e.g. the API keys of hosted providers.
+	L1Headers http.Header
+	L2Headers http.Header
 }
 
 // NewClient initializes all RPC clients used by Taiko client software.
@@ -51,12 +62,16 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
 	defer cancel()
 
-	l1Client, err := NewEthClient(ctxWithTimeout, cfg.L1Endpoint, cfg.Timeout)
+	l1Client, err := NewEthClientWithOpts(ctxWithTimeout, cfg.L1Endpoint, cfg.Timeout, &EthClientOpts{
+		Headers: cfg.L1Headers,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	l2Client, err := NewEthClient(ctxWithTimeout, cfg.L2Endpoint, cfg.Timeout)
+	l2Client, err := NewEthClientWithOpts(ctxWithTimeout, cfg.L2Endpoint, cfg.Timeout, &EthClientOpts{
+		Headers: cfg.L2Headers,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +120,24 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 
 	var l2CheckPoint *EthClient
 	if cfg.L2CheckPoint != "" {
-		l2CheckPoint, err = NewEthClient(ctxWithTimeout, cfg.L2CheckPoint, cfg.Timeout)
+		l2CheckPoint, err = NewEthClientWithOpts(ctxWithTimeout, cfg.L2CheckPoint, cfg.Timeout, &EthClientOpts{
+			Headers: cfg.L2Headers,
+		})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	for prefix, ethClient := range map[string]*EthClient{"l1/": l1Client, "l2/": l2Client, "l2CheckPoint/": l2CheckPoint} {
+		if ethClient == nil {
+			continue
+		}
+		ethClient.CallTimeout = cfg.CallTimeout
+		if cfg.MetricsRegistry != nil {
+			ethClient.MetricsRegistry = metrics.NewPrefixedChildRegistry(cfg.MetricsRegistry, prefix)
+		}
+	}
+
 	client := &Client{
 		L1:             l1Client,
 		L1Beacon:       l1BeaconClient,
@@ -121,6 +148,7 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		TaikoL2:        taikoL2,
 		TaikoToken:     taikoToken,
 		GuardianProver: guardianProver,
+		taikoL1Address: cfg.TaikoL1Address,
 	}
 
 	if err := client.ensureGenesisMatched(ctxWithTimeout); err != nil {
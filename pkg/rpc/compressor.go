@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+// The codec bytes prefixed to the compressed blob payloads, to tell which Compressor to decompress them with.
+const (
+	CodecZlib byte = 0x01
+	CodecZstd byte = 0x02
+)
+
+var (
+	// ErrDecompressedTooLarge is returned when the decompressed data exceeds maxDecompressedSize.
+	ErrDecompressedTooLarge    = errors.New("decompressed data too large")
+	errUnknownCompressionCodec = errors.New("unknown compression codec")
+	// maxDecompressedSize is the max size of the decompressed payloads, i.e. the max size of a tx list, so
+	// that a small compressed payload can't exhaust the memory by decompressing to a huge one.
+	maxDecompressedSize = BlockMaxTxListBytes
+)
+
+// Compressor compresses the proposal payloads before they are encoded into blobs, and decompresses them back.
+type Compressor interface {
+	// Codec returns the codec byte which identifies this compressor.
+	Codec() byte
+	// Compress compresses the given data.
+	Compress(data []byte) ([]byte, error)
+	// Decompress decompresses the given data compressed by this compressor.
+	Decompress(data []byte) ([]byte, error)
+}
+
+// ZlibCompressor is a Compressor using zlib, the same format as the compressed tx lists.
+type ZlibCompressor struct{}
+
+// Codec implements the Compressor interface.
+func (c *ZlibCompressor) Codec() byte { return CodecZlib }
+
+// Compress implements the Compressor interface.
+func (c *ZlibCompressor) Compress(data []byte) ([]byte, error) {
+	return utils.Compress(data)
+}
+
+// Decompress implements the Compressor interface, like utils.Decompress, a truncated stream is tolerated.
+func (c *ZlibCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// Read one more byte than the limit, to tell whether the decompressed data exceeds it.
+	decompressed, err := io.ReadAll(io.LimitReader(r, int64(maxDecompressedSize)+1))
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if uint64(len(decompressed)) > maxDecompressedSize {
+		return nil, fmt.Errorf("%w: exceeds max %d", ErrDecompressedTooLarge, maxDecompressedSize)
+	}
+
+	return decompressed, nil
+}
+
+// ZstdCompressor is a Compressor using zstd.
+type ZstdCompressor struct{}
+
+// Codec implements the Compressor interface.
+func (c *ZstdCompressor) Codec() byte { return CodecZstd }
+
+// Compress implements the Compressor interface.
+func (c *ZstdCompressor) Compress(data []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Close()
+
+	return encoder.EncodeAll(data, nil), nil
+}
+
+// Decompress implements the Compressor interface.
+func (c *ZstdCompressor) Decompress(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(
+		nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(maxDecompressedSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+
+	decompressed, err := decoder.DecodeAll(data, nil)
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		return nil, fmt.Errorf("%w: %w", ErrDecompressedTooLarge, err)
+	}
+
+	return decompressed, err
+}
+
+// compressorOf returns the Compressor identified by the given codec byte.
+func compressorOf(codec byte) (Compressor, error) {
+	switch codec {
+	case CodecZlib:
+		return new(ZlibCompressor), nil
+	case CodecZstd:
+		return new(ZstdCompressor), nil
+	default:
+		return nil, fmt.Errorf("%w: %#x", errUnknownCompressionCodec, codec)
+	}
+}
+
+// MakeCompressedSidecar makes a sidecar like MakeSidecar, but the given data is compressed by the given
+// compressor first, and prefixed with the compressor's codec byte, use DecodeCompressedBlob to decode it.
+func MakeCompressedSidecar(data []byte, compressor Compressor) (*types.BlobTxSidecar, error) {
+	compressed, err := compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress the blob data: %w", err)
+	}
+
+	return MakeSidecar(append([]byte{compressor.Codec()}, compressed...))
+}
+
+// DecodeCompressedBlob decodes the given blob made by MakeCompressedSidecar back to the original data,
+// which is decompressed by the compressor identified by the codec prefix.
+func DecodeCompressedBlob(blob kzg4844.Blob) ([]byte, error) {
+	data, err := DecodeBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: missing compression codec", ErrBlobInvalid)
+	}
+
+	return decompressWithCodec(data)
+}
+
+// DecompressTxList decompresses the given compressed tx list of a proposal. The payloads made by
+// MakeCompressedSidecar are decompressed by the compressor identified by their codec prefix, and the
+// others are decompressed as zlib, the legacy format, since a zlib stream never starts with a codec byte.
+// An error wrapping ErrDecompressedTooLarge will be returned if the tx list exceeds BlockMaxTxListBytes.
+func DecompressTxList(data []byte) ([]byte, error) {
+	if len(data) != 0 && (data[0] == CodecZlib || data[0] == CodecZstd) {
+		return decompressWithCodec(data)
+	}
+	return new(ZlibCompressor).Decompress(data)
+}
+
+// decompressWithCodec decompresses the given codec prefixed data by the compressor identified by the codec.
+func decompressWithCodec(data []byte) ([]byte, error) {
+	compressor, err := compressorOf(data[0])
+	if err != nil {
+		return nil, err
+	}
+	decompressed, err := compressor.Decompress(data[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the blob data: %w", err)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+func TestCompressedSidecarRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("taiko tx list "), 8*1024)
+
+	for _, compressor := range []Compressor{new(ZlibCompressor), new(ZstdCompressor)} {
+		sidecar, err := MakeCompressedSidecar(data, compressor)
+		require.Nil(t, err)
+
+		// The codec prefix is honored.
+		payload, err := DecodeBlob(sidecar.Blobs[0])
+		require.Nil(t, err)
+		require.Equal(t, compressor.Codec(), payload[0])
+		require.Less(t, len(payload), len(data))
+
+		decoded, err := DecodeCompressedBlob(sidecar.Blobs[0])
+		require.Nil(t, err)
+		require.Equal(t, data, decoded)
+
+		// Empty data.
+		sidecar, err = MakeCompressedSidecar([]byte{}, compressor)
+		require.Nil(t, err)
+		decoded, err = DecodeCompressedBlob(sidecar.Blobs[0])
+		require.Nil(t, err)
+		require.Empty(t, decoded)
+	}
+}
+
+func TestDecodeCompressedBlobInvalidCodec(t *testing.T) {
+	zstdCompressed, err := new(ZstdCompressor).Compress([]byte("hello"))
+	require.Nil(t, err)
+
+	// The zstd payload can't be decompressed as zlib.
+	sidecar, err := MakeSidecar(append([]byte{CodecZlib}, zstdCompressed...))
+	require.Nil(t, err)
+	_, err = DecodeCompressedBlob(sidecar.Blobs[0])
+	require.NotNil(t, err)
+
+	sidecar, err = MakeSidecar(append([]byte{0xff}, zstdCompressed...))
+	require.Nil(t, err)
+	_, err = DecodeCompressedBlob(sidecar.Blobs[0])
+	require.ErrorIs(t, err, errUnknownCompressionCodec)
+
+	sidecar, err = MakeSidecar(nil)
+	require.Nil(t, err)
+	_, err = DecodeCompressedBlob(sidecar.Blobs[0])
+	require.ErrorIs(t, err, ErrBlobInvalid)
+}
+
+func TestDecompressTooLarge(t *testing.T) {
+	bomb := make([]byte, maxDecompressedSize+1)
+
+	for _, compressor := range []Compressor{new(ZlibCompressor), new(ZstdCompressor)} {
+		compressed, err := compressor.Compress(bomb)
+		require.Nil(t, err)
+		_, err = compressor.Decompress(compressed)
+		require.ErrorIs(t, err, ErrDecompressedTooLarge)
+
+		// The data of the max size is still accepted.
+		compressed, err = compressor.Compress(bomb[1:])
+		require.Nil(t, err)
+		decompressed, err := compressor.Decompress(compressed)
+		require.Nil(t, err)
+		require.Equal(t, bomb[1:], decompressed)
+	}
+}
+
+func TestDecompressTxList(t *testing.T) {
+	txList := bytes.Repeat([]byte("taiko tx list "), 1024)
+
+	// The legacy zlib compressed tx list, without a codec prefix.
+	compressed, err := utils.Compress(txList)
+	require.Nil(t, err)
+	decompressed, err := DecompressTxList(compressed)
+	require.Nil(t, err)
+	require.Equal(t, txList, decompressed)
+
+	for _, compressor := range []Compressor{new(ZlibCompressor), new(ZstdCompressor)} {
+		compressed, err := compressor.Compress(txList)
+		require.Nil(t, err)
+		decompressed, err := DecompressTxList(append([]byte{compressor.Codec()}, compressed...))
+		require.Nil(t, err)
+		require.Equal(t, txList, decompressed)
+	}
+
+	compressed, err = utils.Compress(make([]byte, maxDecompressedSize+1))
+	require.Nil(t, err)
+	_, err = DecompressTxList(compressed)
+	require.ErrorIs(t, err, ErrDecompressedTooLarge)
+}
@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EffectiveGasPrice returns the gas price actually paid by the mined transaction of the given receipt,
+// i.e. the base fee of the including block plus the effective gas tip of the transaction.
+func (c *EthClient) EffectiveGasPrice(ctx context.Context, receipt *types.Receipt) (*big.Int, error) {
+	tx, _, err := c.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// The legacy gas price is paid as is before London.
+	if header.BaseFee == nil {
+		return new(big.Int).Set(tx.GasPrice()), nil
+	}
+	tip, err := tx.EffectiveGasTip(header.BaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective gas tip of tx %s: %w", receipt.TxHash, err)
+	}
+
+	return tip.Add(tip, header.BaseFee), nil
+}
+
+// EffectiveBlobGasPrice returns the blob gas price actually paid by the mined blob transaction of the given
+// receipt, which is the blob base fee derived from the excess blob gas of the including block.
+func (c *EthClient) EffectiveBlobGasPrice(ctx context.Context, receipt *types.Receipt) (*big.Int, error) {
+	if receipt.Type != types.BlobTxType {
+		return nil, fmt.Errorf("%w: %s", errNotBlobTx, receipt.TxHash)
+	}
+
+	header, err := c.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if header.ExcessBlobGas == nil {
+		return nil, errors.New("no excess blob gas in the block header")
+	}
+
+	return eip4844.CalcBlobFee(*header.ExcessBlobGas), nil
+}
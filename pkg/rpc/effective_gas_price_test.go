@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveGasPrice(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	// The mined blob transaction with a gas tip cap of 100, and a gas fee cap of 200.
+	tx := newTestSignedBlobTx(t, opts, 1)
+	excessBlobGas := uint64(10 * params.BlobTxTargetBlobGasPerBlock)
+	header := newTestHeader(10)
+	header.ExcessBlobGas = &excessBlobGas
+	receipt := &types.Receipt{Type: types.BlobTxType, TxHash: tx.Hash(), BlockHash: common.HexToHash("0x10")}
+
+	client := newTestEthClient(t, &testEthService{
+		getTransactionByHash: func(hash common.Hash) (*types.Transaction, error) {
+			require.Equal(t, tx.Hash(), hash)
+			return tx, nil
+		},
+		getHeaderByHash: func(hash common.Hash) (*types.Header, error) {
+			require.Equal(t, receipt.BlockHash, hash)
+			return header, nil
+		},
+	})
+
+	// The whole tip cap is paid on top of a low base fee.
+	header.BaseFee = big.NewInt(50)
+	price, err := client.EffectiveGasPrice(context.Background(), receipt)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(150), price)
+
+	// The tip is bounded by the gas fee cap.
+	header.BaseFee = big.NewInt(150)
+	price, err = client.EffectiveGasPrice(context.Background(), receipt)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(200), price)
+
+	blobPrice, err := client.EffectiveBlobGasPrice(context.Background(), receipt)
+	require.Nil(t, err)
+	require.Equal(t, eip4844.CalcBlobFee(excessBlobGas), blobPrice)
+	require.Equal(t, 1, blobPrice.Cmp(common.Big1))
+
+	// Not a blob transaction.
+	_, err = client.EffectiveBlobGasPrice(context.Background(), &types.Receipt{Type: types.DynamicFeeTxType})
+	require.ErrorIs(t, err, errNotBlobTx)
+
+	// No blob gas fields in the block.
+	header.ExcessBlobGas = nil
+	_, err = client.EffectiveBlobGasPrice(context.Background(), receipt)
+	require.ErrorContains(t, err, "no excess blob gas")
+}
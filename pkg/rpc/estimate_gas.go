@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	defaultEstimateGasMaxRetries    uint64 = 3
+	defaultEstimateGasRetryInterval        = 500 * time.Millisecond
+	// overloadedErrCode is the JSON-RPC error code of the "limit exceeded" errors, which are returned by
+	// the overloaded or rate limited nodes.
+	overloadedErrCode = -32005
+	// overloadedEstimateGasErrs are the error messages which mean the node is temporarily unable to
+	// estimate the gas, so the same estimation may succeed in a later attempt.
+	overloadedEstimateGasErrs = []string{
+		"timeout",
+		"timed out",
+		"too many requests",
+		"rate limit",
+		"overloaded",
+		"busy",
+	}
+)
+
+// EstimateGasRetryOpts contains all options for retrying estimating the gas of a call.
+type EstimateGasRetryOpts struct {
+	// MaxRetries is the maximum number of retries, default to 3.
+	MaxRetries uint64
+	// RetryInterval is the initial interval of the exponential backoff, default to 500ms.
+	RetryInterval time.Duration
+}
+
+// EstimateGasWithRetry estimates the gas of the given call message, and retries with an exponential
+// backoff policy when the node is overloaded or the request timed out. The reverts are deterministic
+// for the same state, so they are returned immediately without retrying, as well as any other errors.
+func (c *EthClient) EstimateGasWithRetry(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+	opts *EstimateGasRetryOpts,
+) (uint64, error) {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	var (
+		maxRetries    = defaultEstimateGasMaxRetries
+		retryInterval = defaultEstimateGasRetryInterval
+	)
+	if opts != nil {
+		if opts.MaxRetries != 0 {
+			maxRetries = opts.MaxRetries
+		}
+		if opts.RetryInterval != 0 {
+			retryInterval = opts.RetryInterval
+		}
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = retryInterval
+
+	var gas uint64
+	err := backoff.Retry(
+		func() (err error) {
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+
+			if gas, err = c.EstimateGas(ctx, msg); err == nil {
+				return nil
+			}
+			if !isRetriableEstimateGasErr(err) {
+				return backoff.Permanent(err)
+			}
+
+			log.Warn("Failed to estimate gas, retrying", "to", msg.To, "error", err)
+			return err
+		},
+		backoff.WithContext(backoff.WithMaxRetries(expBackoff, maxRetries), ctx),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return gas, nil
+}
+
+// isRetriableEstimateGasErr returns true if the given gas estimation error is caused by an overloaded
+// node or a timeout, rather than a revert of the call.
+func isRetriableEstimateGasErr(err error) bool {
+	if isRevertErr(err) {
+		return false
+	}
+	if isFailoverError(err) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == overloadedErrCode {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, overloadedErr := range overloadedEstimateGasErrs {
+		if strings.Contains(msg, overloadedErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRevertErr returns true if the given error means the estimated call reverted.
+func isRevertErr(err error) bool {
+	return errors.Is(err, vm.ErrExecutionReverted) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
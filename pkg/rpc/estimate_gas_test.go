@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// testRPCError is a JSON-RPC error with the given code returned by the mocked services.
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+// newTestEstimateGasClient creates a client whose eth_estimateGas fails with the given errors in order,
+// and then succeeds, the number of the estimations is counted.
+func newTestEstimateGasClient(t *testing.T, calls *atomic.Int64, errs ...error) *EthClient {
+	return newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+		estimateGas: func(map[string]interface{}) (uint64, error) {
+			if i := calls.Add(1) - 1; i < int64(len(errs)) {
+				return 0, errs[i]
+			}
+			return 50_000, nil
+		},
+	})
+}
+
+func TestEstimateGasWithRetryOverloaded(t *testing.T) {
+	var calls atomic.Int64
+	client := newTestEstimateGasClient(
+		t,
+		&calls,
+		&testRPCError{code: overloadedErrCode, message: "request limit exceeded"},
+		errors.New("server busy, try again later"),
+	)
+
+	gas, err := client.EstimateGasWithRetry(
+		context.Background(),
+		ethereum.CallMsg{To: &common.Address{}},
+		&EstimateGasRetryOpts{RetryInterval: time.Millisecond},
+	)
+	require.Nil(t, err)
+	require.Equal(t, uint64(50_000), gas)
+	require.Equal(t, int64(3), calls.Load())
+}
+
+func TestEstimateGasWithRetryRevert(t *testing.T) {
+	var calls atomic.Int64
+	client := newTestEstimateGasClient(t, &calls, errors.New("execution reverted: L1_INVALID_PARAM"))
+
+	_, err := client.EstimateGasWithRetry(
+		context.Background(),
+		ethereum.CallMsg{To: &common.Address{}},
+		&EstimateGasRetryOpts{RetryInterval: time.Millisecond},
+	)
+	require.ErrorContains(t, err, "execution reverted")
+	// The deterministic revert is not retried.
+	require.Equal(t, int64(1), calls.Load())
+}
+
+func TestEstimateGasWithRetryExhausted(t *testing.T) {
+	var (
+		calls atomic.Int64
+		errs  []error
+	)
+	for i := 0; i < 10; i++ {
+		errs = append(errs, &testRPCError{code: overloadedErrCode, message: "request limit exceeded"})
+	}
+	client := newTestEstimateGasClient(t, &calls, errs...)
+
+	_, err := client.EstimateGasWithRetry(
+		context.Background(),
+		ethereum.CallMsg{To: &common.Address{}},
+		&EstimateGasRetryOpts{MaxRetries: 2, RetryInterval: time.Millisecond},
+	)
+	require.ErrorContains(t, err, "request limit exceeded")
+	require.Equal(t, int64(3), calls.Load())
+}
+
+func TestIsRetriableEstimateGasErr(t *testing.T) {
+	for _, c := range []struct {
+		err       error
+		retriable bool
+	}{
+		{context.DeadlineExceeded, true},
+		{&testRPCError{code: overloadedErrCode, message: "limit exceeded"}, true},
+		{errors.New("429 Too Many Requests"), true},
+		{errors.New("request timed out"), true},
+		{fmt.Errorf("wrapped: %w", errors.New("execution reverted")), false},
+		// A revert is deterministic, even if its reason looks like an overload.
+		{errors.New("execution reverted: rate limit"), false},
+		{errors.New("insufficient funds for gas * price + value"), false},
+	} {
+		require.Equal(t, c.retriable, isRetriableEstimateGasErr(c.err), c.err.Error())
+	}
+}
+
+func TestCreateBlobTxEstimateGasRetry(t *testing.T) {
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x01"), Nonce: common.Big0}
+
+	var calls atomic.Int64
+	client := newTestEstimateGasClient(t, &calls, &testRPCError{code: overloadedErrCode, message: "limit exceeded"})
+	tx, err := client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), calls.Load())
+	require.Equal(t, inflateGasLimit(50_000, 0, 0), tx.Gas)
+
+	calls.Store(0)
+	client = newTestEstimateGasClient(t, &calls, errors.New("execution reverted"))
+	_, err = client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.ErrorContains(t, err, "execution reverted")
+	require.Equal(t, int64(1), calls.Load())
+
+	// The explicitly given gas limit is not estimated.
+	calls.Store(0)
+	opts.GasLimit = 100_000
+	tx, err = client.CreateBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.Nil(t, err)
+	require.Zero(t, calls.Load())
+	require.Equal(t, uint64(100_000), tx.Gas)
+}
@@ -2,18 +2,30 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
 )
 
+// ErrL1OriginNotFound is returned when the L2 node has no L1 origin record of the given L2 block,
+// e.g. the L2 node has just synced the block from the P2P network.
+var ErrL1OriginNotFound = errors.New("L1 origin not found")
+
 type gethClient struct {
 	*gethclient.Client
 }
@@ -25,21 +37,122 @@ type ethClient struct {
 // EthClient is a wrapper for go-ethereum eth client with a timeout attached.
 type EthClient struct {
 	ChainID *big.Int
+	// BlobFeeCapMultiplier is the multiplier applied to the current blob fee when
+	// creating blob transactions, default to 2.
+	BlobFeeCapMultiplier *big.Int
+	// MaxBlobFeeCap is the optional ceiling of the blob fee cap of the blob transactions, if it is set,
+	// the blob fee cap will be capped by it, and ErrBlobFeeCapCeilingExceeded will be returned when the
+	// current blob base fee exceeds it.
+	MaxBlobFeeCap *big.Int
+	// GasFeeCapMultiplier is the multiplier applied to the base fee when calculating
+	// `gasFeeCap = gasTipCap + multiplier * baseFee` for blob transactions, default to 2.
+	GasFeeCapMultiplier *big.Int
+	// GasLimitMultiplier is the multiplier applied to the estimated gas limit of the blob transactions, to leave
+	// a safety margin for the underestimations, the result is clamped to the block gas limit, default to 1.2.
+	// The explicitly given gas limits are not inflated.
+	GasLimitMultiplier float64
+	// NonceTracker is an optional in-process nonce tracker, if it is set, the blob transactions
+	// will use the locally tracked nonces instead of the node's pending nonces.
+	NonceTracker *NonceTracker
+	// HeadersBatchSize is the max number of headers fetched in a single JSON-RPC batch request
+	// by HeadersByRange, default to 100.
+	HeadersBatchSize uint64
+	// CallTimeout is the timeout of each individual outbound RPC call, if set, it will always be applied
+	// on top of the caller's context, even if the caller's context already has a (longer) deadline.
+	CallTimeout time.Duration
+	// MetricsRegistry is an optional registry, if it is set, the call count, latency and error count
+	// of each RPC method will be recorded in it.
+	MetricsRegistry metrics.Registry
+	// VerifySidecars is a paranoid mode flag, if it is set, the blob sidecars will be verified by
+	// VerifySidecar before sending the blob transactions.
+	VerifySidecars bool
+	// TipCapStrategy decides the gasTipCap of the transactions created by this client, when it is not
+	// explicitly set in the transact options, default to SuggestedTipCap.
+	TipCapStrategy TipCapStrategy
+	// MinGasTipCap is the optional floor of the gasTipCap of the blob transactions, it is applied to both
+	// the suggested and the explicitly given gasTipCap, so that the transactions won't be stuck behind
+	// the spam with tiny tips on a quiet chain.
+	MinGasTipCap *big.Int
+	// FilterLogsChunkSize is the max number of blocks queried by a single eth_getLogs request in
+	// FilterEventsFromBlock, default to 1000.
+	FilterLogsChunkSize uint64
+	// HeightStallTimeout is the max duration WaitForL2Height keeps waiting without the node's block
+	// height making any progress, before giving up with ErrHeightStalled, default to 1 minute.
+	HeightStallTimeout time.Duration
+	// BroadcastEndpoints are the optional extra L1 endpoints TransactBlobTx broadcasts the signed blob
+	// transactions to, along with this client's endpoint, see BroadcastTransaction.
+	BroadcastEndpoints []*EthClient
+	// ProposalFeeBudget is the optional max total fee of a single proposal transaction in wei, if it is set,
+	// ValidateProposal rejects the proposals whose estimated total fee exceeds it.
+	ProposalFeeBudget *big.Int
+	// FeeEstimationBlock is the optional number of the block whose header the fees of the blob transactions
+	// are estimated from, instead of the latest header, so that a batch of transactions can be priced off
+	// the same block.
+	FeeEstimationBlock *big.Int
+	// AccessList is the optional EIP-2930 access list attached to the blob transactions, which makes the
+	// contract calls touching the predictable accounts and storage slots cheaper.
+	AccessList *types.AccessList
+	// AutoAccessList makes the blob transactions attach the access lists created by eth_createAccessList,
+	// when AccessList is not set.
+	AutoAccessList bool
+	// ChainIDCheckInterval is the interval of re-fetching the chain ID from the endpoint when creating
+	// transactions, to make sure the cached ChainID is still valid after an endpoint switch, otherwise
+	// ErrChainIDMismatch will be returned, default to 5 minutes.
+	ChainIDCheckInterval time.Duration
+	// ReceiptPollInterval is the max interval of polling the transaction receipts in WaitReceipt, WaitMined
+	// and WaitConfirmations, default to 3 seconds. If the endpoint supports subscriptions, the receipts are
+	// re-checked on each new chain head instead, and at the latest after this interval.
+	ReceiptPollInterval time.Duration
+	// ReceiptWaitTimeout is the max duration of waiting for a transaction receipt, WaitReceipt defaults it
+	// to 1 minute, while WaitMined and WaitConfirmations only apply it if it is set.
+	ReceiptWaitTimeout time.Duration
 
 	*rpc.Client
 	*gethClient
 	*ethClient
 
 	timeout time.Duration
+	// chainIDCheckedAt is the unix time in nanoseconds of the last chain ID check.
+	chainIDCheckedAt atomic.Int64
+}
+
+// EthClientOpts contains the optional transport configurations of an EthClient.
+type EthClientOpts struct {
+	// Headers are the static HTTP headers attached to all JSON-RPC requests, for the websocket
+	// transport, they are sent in the handshake request.
+	Headers http.Header
+	// HeaderFunc is called to set the dynamic headers (e.g. a short-lived auth token) before each
+	// HTTP request is sent, for the websocket transport, it is only called for the handshake request.
+	HeaderFunc func(h http.Header) error
 }
 
 func NewEthClient(ctx context.Context, url string, timeout time.Duration) (*EthClient, error) {
+	return NewEthClientWithOpts(ctx, url, timeout, nil)
+}
+
+// NewEthClientWithOpts creates a new EthClient instance with the given transport options.
+func NewEthClientWithOpts(
+	ctx context.Context,
+	url string,
+	timeout time.Duration,
+	opts *EthClientOpts,
+) (*EthClient, error) {
 	var timeoutVal = defaultTimeout
 	if timeout != 0 {
 		timeoutVal = timeout
 	}
 
-	client, err := rpc.DialContext(ctx, url)
+	var dialOpts []rpc.ClientOption
+	if opts != nil {
+		if len(opts.Headers) != 0 {
+			dialOpts = append(dialOpts, rpc.WithHeaders(opts.Headers))
+		}
+		if opts.HeaderFunc != nil {
+			dialOpts = append(dialOpts, rpc.WithHTTPAuth(opts.HeaderFunc))
+		}
+	}
+
+	client, err := rpc.DialOptions(ctx, url, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,22 +164,38 @@ func NewEthClient(ctx context.Context, url string, timeout time.Duration) (*EthC
 		return nil, err
 	}
 
-	return &EthClient{
+	c := &EthClient{
 		ChainID:    chainID,
 		Client:     client,
 		gethClient: &gethClient{gethclient.New(client)},
 		ethClient:  ethClient,
 		timeout:    timeoutVal,
-	}, nil
+	}
+	c.chainIDCheckedAt.Store(time.Now().UnixNano())
+
+	return c, nil
+}
+
+// ctxWithCallTimeout returns the context for an outbound RPC call, derived from the given parent context.
+func (c *EthClient) ctxWithCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.CallTimeout == 0 {
+		return ctxWithTimeoutOrDefault(ctx, c.timeout)
+	}
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	return context.WithTimeout(ctx, c.CallTimeout)
 }
 
 // BlockByHash returns the given full block.
 //
 // Note that loading full blocks requires two requests. Use HeaderByHash
 // if you don't need all transactions or uncle headers.
-func (c *EthClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) BlockByHash(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("BlockByHash", time.Now(), &err)
 
 	return c.ethClient.BlockByHash(ctxWithTimeout, hash)
 }
@@ -76,42 +205,47 @@ func (c *EthClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.B
 //
 // Note that loading full blocks requires two requests. Use HeaderByNumber
 // if you don't need all transactions or uncle headers.
-func (c *EthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("BlockByNumber", time.Now(), &err)
 
 	return c.ethClient.BlockByNumber(ctxWithTimeout, number)
 }
 
 // BlockNumber returns the most recent block number
-func (c *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) BlockNumber(ctx context.Context) (number uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("BlockNumber", time.Now(), &err)
 
 	return c.ethClient.BlockNumber(ctxWithTimeout)
 }
 
 // PeerCount returns the number of p2p peers as reported by the net_peerCount method.
-func (c *EthClient) PeerCount(ctx context.Context) (uint64, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PeerCount(ctx context.Context) (count uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PeerCount", time.Now(), &err)
 
 	return c.ethClient.PeerCount(ctxWithTimeout)
 }
 
 // HeaderByHash returns the block header with the given hash.
-func (c *EthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) HeaderByHash(ctx context.Context, hash common.Hash) (header *types.Header, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("HeaderByHash", time.Now(), &err)
 
 	return c.ethClient.HeaderByHash(ctxWithTimeout, hash)
 }
 
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
-func (c *EthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("HeaderByNumber", time.Now(), &err)
 
 	return c.ethClient.HeaderByNumber(ctxWithTimeout, number)
 }
@@ -121,8 +255,9 @@ func (c *EthClient) TransactionByHash(
 	ctx context.Context,
 	hash common.Hash,
 ) (tx *types.Transaction, isPending bool, err error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("TransactionByHash", time.Now(), &err)
 
 	return c.ethClient.TransactionByHash(ctxWithTimeout, hash)
 }
@@ -138,17 +273,19 @@ func (c *EthClient) TransactionSender(
 	tx *types.Transaction,
 	block common.Hash,
 	index uint,
-) (common.Address, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (sender common.Address, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("TransactionSender", time.Now(), &err)
 
 	return c.ethClient.TransactionSender(ctxWithTimeout, tx, block, index)
 }
 
 // TransactionCount returns the total number of transactions in the given block.
-func (c *EthClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) TransactionCount(ctx context.Context, blockHash common.Hash) (count uint, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("TransactionCount", time.Now(), &err)
 
 	return c.ethClient.TransactionCount(ctxWithTimeout, blockHash)
 }
@@ -158,26 +295,39 @@ func (c *EthClient) TransactionInBlock(
 	ctx context.Context,
 	blockHash common.Hash,
 	index uint,
-) (*types.Transaction, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (tx *types.Transaction, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("TransactionInBlock", time.Now(), &err)
 
 	return c.ethClient.TransactionInBlock(ctxWithTimeout, blockHash, index)
 }
 
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// Note that the receipt is not available for pending transactions.
+func (c *EthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("TransactionReceipt", time.Now(), &err)
+
+	return c.ethClient.TransactionReceipt(ctxWithTimeout, txHash)
+}
+
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
-func (c *EthClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) SyncProgress(ctx context.Context) (progress *ethereum.SyncProgress, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("SyncProgress", time.Now(), &err)
 
 	return c.ethClient.SyncProgress(ctxWithTimeout)
 }
 
 // NetworkID returns the network ID for this client.
-func (c *EthClient) NetworkID(ctx context.Context) (*big.Int, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) NetworkID(ctx context.Context) (networkID *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("NetworkID", time.Now(), &err)
 
 	return c.ethClient.NetworkID(ctxWithTimeout)
 }
@@ -188,9 +338,10 @@ func (c *EthClient) BalanceAt(
 	ctx context.Context,
 	account common.Address,
 	blockNumber *big.Int,
-) (*big.Int, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (balance *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("BalanceAt", time.Now(), &err)
 
 	return c.ethClient.BalanceAt(ctxWithTimeout, account, blockNumber)
 }
@@ -202,9 +353,10 @@ func (c *EthClient) StorageAt(
 	account common.Address,
 	key common.Hash,
 	blockNumber *big.Int,
-) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (value []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("StorageAt", time.Now(), &err)
 
 	return c.ethClient.StorageAt(ctxWithTimeout, account, key, blockNumber)
 }
@@ -215,9 +367,10 @@ func (c *EthClient) CodeAt(
 	ctx context.Context,
 	account common.Address,
 	blockNumber *big.Int,
-) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (code []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("CodeAt", time.Now(), &err)
 
 	return c.ethClient.CodeAt(ctxWithTimeout, account, blockNumber)
 }
@@ -228,17 +381,19 @@ func (c *EthClient) NonceAt(
 	ctx context.Context,
 	account common.Address,
 	blockNumber *big.Int,
-) (uint64, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (nonce uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("NonceAt", time.Now(), &err)
 
 	return c.ethClient.NonceAt(ctxWithTimeout, account, blockNumber)
 }
 
 // PendingBalanceAt returns the wei balance of the given account in the pending state.
-func (c *EthClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PendingBalanceAt(ctx context.Context, account common.Address) (balance *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingBalanceAt", time.Now(), &err)
 
 	return c.ethClient.PendingBalanceAt(ctxWithTimeout, account)
 }
@@ -248,34 +403,63 @@ func (c *EthClient) PendingStorageAt(
 	ctx context.Context,
 	account common.Address,
 	key common.Hash,
-) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (value []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingStorageAt", time.Now(), &err)
 
 	return c.ethClient.PendingStorageAt(ctxWithTimeout, account, key)
 }
 
 // PendingCodeAt returns the contract code of the given account in the pending state.
-func (c *EthClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingCodeAt", time.Now(), &err)
 
 	return c.ethClient.PendingCodeAt(ctxWithTimeout, account)
 }
 
 // PendingNonceAt returns the account nonce of the given account in the pending state.
 // This is the nonce that should be used for the next transaction.
-func (c *EthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingNonceAt", time.Now(), &err)
 
 	return c.ethClient.PendingNonceAt(ctxWithTimeout, account)
 }
 
+// NextNonce returns the nonce which should be used for the next transaction of the given account,
+// the locally tracked nonce will be used and reserved if the NonceTracker is enabled.
+func (c *EthClient) NextNonce(ctx context.Context, account common.Address) (uint64, error) {
+	if c.NonceTracker == nil {
+		return c.PendingNonceAt(ctx, account)
+	}
+
+	return c.NonceTracker.Next(ctx, account, c.PendingNonceAt)
+}
+
+// markNonceSent marks the transaction with the given nonce as sent, if the NonceTracker is enabled.
+func (c *EthClient) markNonceSent(account common.Address, nonce uint64) {
+	if c.NonceTracker != nil {
+		c.NonceTracker.MarkSent(account, nonce)
+	}
+}
+
+// releaseNonce releases the nonce reserved by NextNonce after its transaction failed to be sent, if the
+// NonceTracker is enabled.
+func (c *EthClient) releaseNonce(account common.Address, nonce uint64) {
+	if c.NonceTracker != nil {
+		c.NonceTracker.Release(account, nonce)
+	}
+}
+
 // PendingTransactionCount returns the total number of transactions in the pending state.
-func (c *EthClient) PendingTransactionCount(ctx context.Context) (uint, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PendingTransactionCount(ctx context.Context) (count uint, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingTransactionCount", time.Now(), &err)
 
 	return c.ethClient.PendingTransactionCount(ctxWithTimeout)
 }
@@ -290,9 +474,10 @@ func (c *EthClient) CallContract(
 	ctx context.Context,
 	msg ethereum.CallMsg,
 	blockNumber *big.Int,
-) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (result []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("CallContract", time.Now(), &err)
 
 	return c.ethClient.CallContract(ctxWithTimeout, msg, blockNumber)
 }
@@ -303,36 +488,40 @@ func (c *EthClient) CallContractAtHash(
 	ctx context.Context,
 	msg ethereum.CallMsg,
 	blockHash common.Hash,
-) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (result []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("CallContractAtHash", time.Now(), &err)
 
 	return c.ethClient.CallContractAtHash(ctxWithTimeout, msg, blockHash)
 }
 
 // PendingCallContract executes a message call transaction using the EVM.
 // The state seen by the contract call is the pending state.
-func (c *EthClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) (result []byte, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("PendingCallContract", time.Now(), &err)
 
 	return c.ethClient.PendingCallContract(ctxWithTimeout, msg)
 }
 
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
-func (c *EthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) SuggestGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("SuggestGasPrice", time.Now(), &err)
 
 	return c.ethClient.SuggestGasPrice(ctxWithTimeout)
 }
 
 // SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
 // allow a timely execution of a transaction.
-func (c *EthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) SuggestGasTipCap(ctx context.Context) (gasTipCap *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("SuggestGasTipCap", time.Now(), &err)
 
 	return c.ethClient.SuggestGasTipCap(ctxWithTimeout)
 }
@@ -343,20 +532,37 @@ func (c *EthClient) FeeHistory(
 	blockCount uint64,
 	lastBlock *big.Int,
 	rewardPercentiles []float64,
-) (*ethereum.FeeHistory, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+) (feeHistory *ethereum.FeeHistory, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("FeeHistory", time.Now(), &err)
 
 	return c.ethClient.FeeHistory(ctxWithTimeout, blockCount, lastBlock, rewardPercentiles)
 }
 
+// BlobBaseFee retrieves the current blob base fee, it will be calculated based on the excess blob gas
+// of the latest header if possible, otherwise the `eth_blobBaseFee` RPC method will be used.
+func (c *EthClient) BlobBaseFee(ctx context.Context) (blobBaseFee *big.Int, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("BlobBaseFee", time.Now(), &err)
+
+	header, err := c.ethClient.HeaderByNumber(ctxWithTimeout, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.blobBaseFeeOf(ctxWithTimeout, header)
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific transaction based on
 // the current pending state of the backend blockchain. There is no guarantee that this is
 // the true gas limit requirement as other transactions may be added or removed by miners,
 // but it should provide a basis for setting a reasonable default.
-func (c *EthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("EstimateGas", time.Now(), &err)
 
 	return c.ethClient.EstimateGas(ctxWithTimeout, msg)
 }
@@ -365,9 +571,10 @@ func (c *EthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint
 //
 // If the transaction was a contract creation use the TransactionReceipt method to get the
 // contract address after the transaction has been mined.
-func (c *EthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) SendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("SendTransaction", time.Now(), &err)
 
 	return c.ethClient.SendTransaction(ctxWithTimeout, tx)
 }
@@ -406,15 +613,35 @@ type SignTransactionResult struct {
 }
 
 // FillTransaction fill transaction.
-func (c *EthClient) FillTransaction(ctx context.Context, args *TransactionArgs) (*types.Transaction, error) {
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, c.timeout)
+func (c *EthClient) FillTransaction(ctx context.Context, args *TransactionArgs) (tx *types.Transaction, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
 	defer cancel()
+	defer c.recordCall("FillTransaction", time.Now(), &err)
 
 	var result SignTransactionResult
-	err := c.CallContext(ctxWithTimeout, &result, "eth_fillTransaction", *args)
-	if err != nil {
+	if err = c.CallContext(ctxWithTimeout, &result, "eth_fillTransaction", *args); err != nil {
 		return nil, err
 	}
 
 	return result.Tx, nil
 }
+
+// L1OriginByL2Number returns the L1 origin of the given L2 block, which records the L1 block the L2 block
+// was proposed in, an error wrapping ErrL1OriginNotFound will be returned if the L2 node has no such record.
+func (c *EthClient) L1OriginByL2Number(ctx context.Context, l2Number *big.Int) (l1Origin *rawdb.L1Origin, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("L1OriginByL2Number", time.Now(), &err)
+
+	if l1Origin, err = c.ethClient.L1OriginByID(ctxWithTimeout, l2Number); err != nil {
+		if err.Error() == ethereum.NotFound.Error() {
+			return nil, fmt.Errorf("%w (blockID: %d)", ErrL1OriginNotFound, l2Number)
+		}
+		return nil, err
+	}
+	if l1Origin == nil {
+		return nil, fmt.Errorf("%w (blockID: %d)", ErrL1OriginNotFound, l2Number)
+	}
+
+	return l1Origin, nil
+}
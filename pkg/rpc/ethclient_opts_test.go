@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// testChainIDService is a mocked `eth` namespace JSON-RPC service, which only serves the chain ID.
+type testChainIDService struct{}
+
+// ChainId implements the `eth_chainId` RPC method.
+func (s *testChainIDService) ChainId() hexutil.Big { // nolint: revive,stylecheck
+	return hexutil.Big(*common.Big1)
+}
+
+func TestNewEthClientWithOptsHeaders(t *testing.T) {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", new(testChainIDService)))
+	defer server.Stop()
+
+	var (
+		mutex   sync.Mutex
+		headers []http.Header
+	)
+	record := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			headers = append(headers, r.Header.Clone())
+			mutex.Unlock()
+			next.ServeHTTP(w, r)
+		})
+	}
+	httpServer := httptest.NewServer(record(server))
+	defer httpServer.Close()
+	wsServer := httptest.NewServer(record(server.WebsocketHandler([]string{"*"})))
+	defer wsServer.Close()
+
+	for _, url := range []string{httpServer.URL, "ws" + strings.TrimPrefix(wsServer.URL, "http")} {
+		mutex.Lock()
+		headers = nil
+		mutex.Unlock()
+
+		var tokens atomic.Int32
+		client, err := NewEthClientWithOpts(context.Background(), url, 0, &EthClientOpts{
+			Headers: http.Header{"X-Api-Key": []string{"secret"}},
+			HeaderFunc: func(h http.Header) error {
+				h.Set("Authorization", "Bearer token")
+				tokens.Add(1)
+				return nil
+			},
+		})
+		require.Nil(t, err)
+		require.Equal(t, common.Big1, client.ChainID)
+
+		_, err = client.ethClient.ChainID(context.Background())
+		require.Nil(t, err)
+		client.Close()
+
+		mutex.Lock()
+		require.NotEmpty(t, headers)
+		for _, h := range headers {
+			require.Equal(t, "secret", h.Get("X-Api-Key"))
+			require.Equal(t, "Bearer token", h.Get("Authorization"))
+		}
+		require.Equal(t, int32(len(headers)), tokens.Load())
+		mutex.Unlock()
+	}
+}
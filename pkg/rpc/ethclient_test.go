@@ -2,10 +2,16 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -168,3 +174,69 @@ func TestEstimateGas(t *testing.T) {
 	_, err := client.L1.EstimateGas(context.Background(), ethereum.CallMsg{})
 	require.Nil(t, err)
 }
+
+func TestCallTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			<-unblock
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+	t.Cleanup(func() { close(unblock) })
+	client.CallTimeout = 50 * time.Millisecond
+
+	// The call timeout should be applied, even though the caller's deadline is much longer.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.HeaderByNumber(ctx, common.Big1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// The cancellation of the caller's context should still be propagated.
+	client.CallTimeout = time.Hour
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err = client.HeaderByNumber(ctx, common.Big1)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestL1OriginByL2Number(t *testing.T) {
+	origin := &rawdb.L1Origin{
+		BlockID:       big.NewInt(10),
+		L2BlockHash:   common.HexToHash("0x01"),
+		L1BlockHeight: big.NewInt(100),
+		L1BlockHash:   common.HexToHash("0x02"),
+	}
+	client := newTestEthClient(t, &testEthService{
+		l1OriginByID: func(blockID *big.Int) (*rawdb.L1Origin, error) {
+			switch blockID.Uint64() {
+			case 10:
+				return origin, nil
+			case 11:
+				return nil, ethereum.NotFound
+			case 12:
+				return nil, nil
+			default:
+				return nil, errors.New("internal error")
+			}
+		},
+	})
+
+	l1Origin, err := client.L1OriginByL2Number(context.Background(), big.NewInt(10))
+	require.Nil(t, err)
+	require.Equal(t, origin, l1Origin)
+
+	// Both a not found error and an empty result are reported as not found.
+	_, err = client.L1OriginByL2Number(context.Background(), big.NewInt(11))
+	require.ErrorIs(t, err, ErrL1OriginNotFound)
+	_, err = client.L1OriginByL2Number(context.Background(), big.NewInt(12))
+	require.ErrorIs(t, err, ErrL1OriginNotFound)
+
+	_, err = client.L1OriginByL2Number(context.Background(), big.NewInt(13))
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, ErrL1OriginNotFound)
+}
@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var (
+	defaultFilterLogsChunkSize uint64 = 1000
+	// tooManyResultsErrors are the error messages returned by the providers when the result of an
+	// eth_getLogs request exceeds their size limits.
+	tooManyResultsErrors = []string{
+		"query returned more than",
+		"log response size exceeded",
+		"response size exceeded",
+		"too many results",
+	}
+)
+
+// TaikoL1Event represents a protocol event replayed by FilterEventsFromBlock, only one of the event
+// fields will be set.
+type TaikoL1Event struct {
+	BlockProposed    *bindings.TaikoL1ClientBlockProposed
+	TransitionProved *bindings.TaikoL1ClientTransitionProved
+	BlockVerified    *bindings.TaikoL1ClientBlockVerified
+}
+
+// Raw returns the raw log of the event.
+func (e *TaikoL1Event) Raw() types.Log {
+	switch {
+	case e.BlockProposed != nil:
+		return e.BlockProposed.Raw
+	case e.TransitionProved != nil:
+		return e.TransitionProved.Raw
+	default:
+		return e.BlockVerified.Raw
+	}
+}
+
+// FilterEventsFromBlock fetches the BlockProposed, TransitionProved and BlockVerified events emitted by the
+// given TaikoL1 contract in the block range [fromBlock, toBlock], and returns them merged in the chain order.
+// The logs are queried in chunks of FilterLogsChunkSize blocks, and if a provider rejects a query for
+// returning too many results, the range will be halved and retried.
+func (c *EthClient) FilterEventsFromBlock(
+	ctx context.Context,
+	contract common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*TaikoL1Event, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid events range: from %d > to %d", fromBlock, toBlock)
+	}
+
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := bindings.NewTaikoL1ClientFilterer(contract, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blockProposedID    = taikoL1ABI.Events["BlockProposed"].ID
+		transitionProvedID = taikoL1ABI.Events["TransitionProved"].ID
+		blockVerifiedID    = taikoL1ABI.Events["BlockVerified"].ID
+		query              = ethereum.FilterQuery{
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{blockProposedID, transitionProvedID, blockVerifiedID}},
+		}
+	)
+
+	chunkSize := c.FilterLogsChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultFilterLogsChunkSize
+	}
+
+	var events []*TaikoL1Event
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		end := min(start+chunkSize-1, toBlock)
+
+		logs, err := c.filterLogsInRange(ctx, query, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range logs {
+			if len(l.Topics) == 0 {
+				continue
+			}
+
+			var e TaikoL1Event
+			switch l.Topics[0] {
+			case blockProposedID:
+				e.BlockProposed, err = filterer.ParseBlockProposed(l)
+			case transitionProvedID:
+				e.TransitionProved, err = filterer.ParseTransitionProved(l)
+			case blockVerifiedID:
+				e.BlockVerified, err = filterer.ParseBlockVerified(l)
+			default:
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse log (tx: %s, index: %d): %w", l.TxHash, l.Index, err)
+			}
+			events = append(events, &e)
+		}
+
+		// Make sure the loop terminates when toBlock is the max uint64.
+		if end == toBlock {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// filterLogsInRange fetches the logs matching the given query in the block range [from, to], the range
+// will be halved recursively if the provider returns too many results.
+func (c *EthClient) filterLogsInRange(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	from uint64,
+	to uint64,
+) ([]types.Log, error) {
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = new(big.Int).SetUint64(to)
+
+	logs, err := c.filterLogs(ctx, query)
+	if err == nil {
+		return logs, nil
+	}
+	if from == to || !isTooManyResultsError(err) {
+		return nil, err
+	}
+
+	mid := from + (to-from)/2
+	log.Debug("Too many logs in range, halving the range", "from", from, "to", to, "mid", mid, "error", err)
+
+	left, err := c.filterLogsInRange(ctx, query, from, mid)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.filterLogsInRange(ctx, query, mid+1, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// filterLogs executes a filter query.
+func (c *EthClient) filterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+	defer c.recordCall("FilterLogs", time.Now(), &err)
+
+	return c.ethClient.FilterLogs(ctxWithTimeout, query)
+}
+
+// isTooManyResultsError checks whether the given error is returned by a provider because the result
+// of an eth_getLogs request exceeds its size limit.
+func isTooManyResultsError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range tooManyResultsErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// newTestTaikoL1Log creates a log of the given TaikoL1 event with zero values in the given block.
+func newTestTaikoL1Log(
+	t *testing.T,
+	contract common.Address,
+	eventName string,
+	blockNumber uint64,
+	index uint,
+) types.Log {
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
+	require.Nil(t, err)
+
+	event := taikoL1ABI.Events[eventName]
+	topics := []common.Hash{event.ID}
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		switch input.Type.T {
+		case abi.AddressTy:
+			topics = append(topics, common.Hash{})
+		default:
+			topics = append(topics, common.BigToHash(new(big.Int).SetUint64(blockNumber)))
+		}
+	}
+
+	var values []interface{}
+	for _, input := range event.Inputs.NonIndexed() {
+		typ := input.Type.GetType()
+		if typ.Kind() == reflect.Ptr {
+			values = append(values, reflect.New(typ.Elem()).Interface())
+		} else {
+			values = append(values, reflect.Zero(typ).Interface())
+		}
+	}
+	data, err := event.Inputs.NonIndexed().Pack(values...)
+	require.Nil(t, err)
+
+	return types.Log{
+		Address:     contract,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		Index:       index,
+	}
+}
+
+// newTestLogsService creates a mocked service which serves the given logs, and rejects the queries
+// which return more than the given max results.
+func newTestLogsService(logs []types.Log, maxResults int, queries *[][2]uint64) *testEthService {
+	var mutex sync.Mutex
+	return &testEthService{
+		getLogs: func(from, to uint64) ([]types.Log, error) {
+			mutex.Lock()
+			*queries = append(*queries, [2]uint64{from, to})
+			mutex.Unlock()
+
+			var result []types.Log
+			for _, l := range logs {
+				if l.BlockNumber >= from && l.BlockNumber <= to {
+					result = append(result, l)
+				}
+			}
+			if len(result) > maxResults {
+				return nil, fmt.Errorf("query returned more than %d results", maxResults)
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestFilterEventsFromBlock(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x1670000000000000000000000000000000010001")
+		names    = []string{"BlockProposed", "TransitionProved", "BlockVerified"}
+		logs     []types.Log
+		queries  [][2]uint64
+	)
+	for i := uint64(0); i < 100; i++ {
+		// Block 50 contains more events than the others.
+		count := 1
+		if i == 50 {
+			count = 4
+		}
+		for j := 0; j < count; j++ {
+			logs = append(logs, newTestTaikoL1Log(t, contract, names[(int(i)+j)%len(names)], i, uint(j)))
+		}
+	}
+
+	client := newTestEthClient(t, newTestLogsService(logs, 8, &queries))
+	client.FilterLogsChunkSize = 10
+
+	events, err := client.FilterEventsFromBlock(context.Background(), contract, 5, 99)
+	require.Nil(t, err)
+	require.Len(t, events, 95+3)
+
+	// The events should be merged in the chain order, and parsed as the right types.
+	for i, e := range events {
+		if i > 0 {
+			prev := events[i-1].Raw()
+			require.True(t, prev.BlockNumber < e.Raw().BlockNumber ||
+				(prev.BlockNumber == e.Raw().BlockNumber && prev.Index < e.Raw().Index))
+		}
+		switch names[(int(e.Raw().BlockNumber)+int(e.Raw().Index))%len(names)] {
+		case "BlockProposed":
+			require.NotNil(t, e.BlockProposed)
+			require.Equal(t, e.Raw().BlockNumber, e.BlockProposed.BlockId.Uint64())
+		case "TransitionProved":
+			require.NotNil(t, e.TransitionProved)
+			require.Equal(t, e.Raw().BlockNumber, e.TransitionProved.BlockId.Uint64())
+		case "BlockVerified":
+			require.NotNil(t, e.BlockVerified)
+			require.Equal(t, e.Raw().BlockNumber, e.BlockVerified.BlockId.Uint64())
+		}
+	}
+
+	// The queries should be chunked, and the chunk which has too many results should be halved.
+	require.Equal(t, [2]uint64{5, 14}, queries[0])
+	for _, q := range queries {
+		require.LessOrEqual(t, q[1]-q[0]+1, uint64(10))
+	}
+	require.Contains(t, queries, [2]uint64{45, 54})
+	require.Contains(t, queries, [2]uint64{45, 49})
+	require.Contains(t, queries, [2]uint64{50, 54})
+}
+
+func TestFilterEventsFromBlockTooManyResults(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x1670000000000000000000000000000000010001")
+		logs     []types.Log
+		queries  [][2]uint64
+	)
+	for j := uint(0); j < 3; j++ {
+		logs = append(logs, newTestTaikoL1Log(t, contract, "BlockVerified", 1, j))
+	}
+
+	// A single block which still has too many results can't be split anymore.
+	client := newTestEthClient(t, newTestLogsService(logs, 2, &queries))
+	_, err := client.FilterEventsFromBlock(context.Background(), contract, 0, 3)
+	require.ErrorContains(t, err, "query returned more than 2 results")
+
+	_, err = client.FilterEventsFromBlock(context.Background(), contract, 3, 0)
+	require.ErrorContains(t, err, "invalid events range")
+}
+
+func TestFilterEventsFromBlockError(t *testing.T) {
+	errUnavailable := errors.New("service unavailable")
+	var calls int
+	client := newTestEthClient(t, &testEthService{
+		getLogs: func(from, to uint64) ([]types.Log, error) {
+			calls++
+			return nil, errUnavailable
+		},
+	})
+
+	_, err := client.FilterEventsFromBlock(context.Background(), common.Address{}, 0, 100)
+	require.ErrorContains(t, err, errUnavailable.Error())
+	require.Equal(t, 1, calls)
+}
+
+func TestIsTooManyResultsError(t *testing.T) {
+	require.True(t, isTooManyResultsError(errors.New("query returned more than 10000 results")))
+	require.True(t, isTooManyResultsError(errors.New("Log response size exceeded.")))
+	require.False(t, isTooManyResultsError(errors.New("execution reverted")))
+}
@@ -0,0 +1,313 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	defaultFailoverHealthCheckInterval = 12 * time.Second
+	errNoFailoverEndpoints             = errors.New("no failover endpoints")
+)
+
+// FailoverEthClient wraps multiple EthClient endpoints of the same chain, and routes each call to
+// the first healthy endpoint in the given order. An endpoint will be marked as unhealthy when a call
+// fails with a connection error or a timeout, and the call will be retried against the next healthy
+// endpoint, the unhealthy endpoints will be re-checked periodically and promoted back once recovered.
+type FailoverEthClient struct {
+	clients             []*EthClient
+	healthy             []bool
+	healthCheckInterval time.Duration
+	mutex               sync.RWMutex
+}
+
+// NewFailoverEthClient creates a new FailoverEthClient instance, the first given client is the primary
+// endpoint, a zero health check interval means the default interval.
+func NewFailoverEthClient(clients []*EthClient, healthCheckInterval time.Duration) (*FailoverEthClient, error) {
+	if len(clients) == 0 {
+		return nil, errNoFailoverEndpoints
+	}
+	if healthCheckInterval == 0 {
+		healthCheckInterval = defaultFailoverHealthCheckInterval
+	}
+
+	healthy := make([]bool, len(clients))
+	for i := range healthy {
+		healthy[i] = true
+	}
+
+	return &FailoverEthClient{
+		clients:             clients,
+		healthy:             healthy,
+		healthCheckInterval: healthCheckInterval,
+	}, nil
+}
+
+// Start starts re-checking the unhealthy endpoints periodically, until the given context is done.
+func (f *FailoverEthClient) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(f.healthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.checkEndpoints(ctx)
+			}
+		}
+	}()
+}
+
+// ChainID returns the chain ID of the wrapped endpoints.
+func (f *FailoverEthClient) ChainID() *big.Int {
+	return f.clients[0].ChainID
+}
+
+// Active returns the first healthy endpoint, or the primary endpoint if all endpoints are unhealthy.
+func (f *FailoverEthClient) Active() *EthClient {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	for i, healthy := range f.healthy {
+		if healthy {
+			return f.clients[i]
+		}
+	}
+
+	return f.clients[0]
+}
+
+// checkEndpoints checks all the unhealthy endpoints, and promotes the recovered ones back.
+func (f *FailoverEthClient) checkEndpoints(ctx context.Context) {
+	for i, client := range f.clients {
+		f.mutex.RLock()
+		healthy := f.healthy[i]
+		f.mutex.RUnlock()
+		if healthy {
+			continue
+		}
+
+		if _, err := client.HeaderByNumber(ctx, nil); err != nil {
+			log.Debug("Failover endpoint is still unhealthy", "index", i, "error", err)
+			continue
+		}
+
+		log.Info("Failover endpoint recovered", "index", i)
+		f.setHealthy(i, true)
+	}
+}
+
+// setHealthy sets the health status of the endpoint with the given index.
+func (f *FailoverEthClient) setHealthy(index int, healthy bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.healthy[index] = healthy
+}
+
+// candidates returns the indexes of the endpoints a call should be routed to in order, the healthy
+// endpoints come first, then the unhealthy ones as the last resort.
+func (f *FailoverEthClient) candidates() []int {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	var healthy, unhealthy []int
+	for i := range f.clients {
+		if f.healthy[i] {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// failoverCall calls the given function against the endpoints in order, until it succeeds or fails
+// with an error which is not caused by the endpoint's availability.
+func failoverCall[T any](ctx context.Context, f *FailoverEthClient, call func(c *EthClient) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for _, i := range f.candidates() {
+		if result, err = call(f.clients[i]); err == nil {
+			f.setHealthy(i, true)
+			return result, nil
+		}
+		if !isFailoverError(err) || (ctx != nil && ctx.Err() != nil) {
+			return result, err
+		}
+
+		log.Warn("Failover endpoint unavailable, trying the next one", "index", i, "error", err)
+		f.setHealthy(i, false)
+	}
+
+	return result, err
+}
+
+// isFailoverError checks whether the given error is caused by the endpoint's availability, i.e.
+// a connection error or a timeout, rather than a JSON-RPC error returned by a reachable node.
+func isFailoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError ||
+			httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, rpc.ErrClientQuit) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.As(err, &netErr)
+}
+
+// BlockNumber returns the most recent block number.
+func (f *FailoverEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (uint64, error) {
+		return c.BlockNumber(ctx)
+	})
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (f *FailoverEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*types.Header, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (f *FailoverEthClient) BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*big.Int, error) {
+		return c.BalanceAt(ctx, account, number)
+	})
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (f *FailoverEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (uint64, error) {
+		return c.PendingNonceAt(ctx, account)
+	})
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
+// allow a timely execution of a transaction.
+func (f *FailoverEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*big.Int, error) {
+		return c.SuggestGasTipCap(ctx)
+	})
+}
+
+// BlobBaseFee retrieves the current blob base fee.
+func (f *FailoverEthClient) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*big.Int, error) {
+		return c.BlobBaseFee(ctx)
+	})
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction.
+func (f *FailoverEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (uint64, error) {
+		return c.EstimateGas(ctx, msg)
+	})
+}
+
+// FillTransaction fills the given transaction arguments.
+func (f *FailoverEthClient) FillTransaction(ctx context.Context, args *TransactionArgs) (*types.Transaction, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*types.Transaction, error) {
+		return c.FillTransaction(ctx, args)
+	})
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+func (f *FailoverEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return failoverCall(ctx, f, func(c *EthClient) (*types.Receipt, error) {
+		return c.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution.
+func (f *FailoverEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := failoverCall(ctx, f, func(c *EthClient) (struct{}, error) {
+		return struct{}{}, c.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// CreateBlobTx creates a blob transaction by given parameters, see EthClient.CreateBlobTx.
+func (f *FailoverEthClient) CreateBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+) (*types.BlobTx, error) {
+	return failoverCall(opts.Context, f, func(c *EthClient) (*types.BlobTx, error) {
+		return c.CreateBlobTx(opts, contract, input, sidecar)
+	})
+}
+
+// TransactBlobTx creates, signs and then sends blob transactions, see EthClient.TransactBlobTx. Only the
+// creation and signing fail over, the signed transaction is then sent as is to the next endpoint if the
+// send fails over, since a send which timed out might have been accepted, and re-creating the transaction
+// with the freshly suggested fees would result in a differently priced transaction at the same nonce.
+func (f *FailoverEthClient) TransactBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+) (*types.Transaction, error) {
+	if opts.Signer == nil && !opts.NoSend {
+		return nil, errNoSigner
+	}
+
+	var (
+		client   *EthClient
+		signOpts = *opts
+	)
+	signOpts.NoSend = true
+	signedTx, err := failoverCall(opts.Context, f, func(c *EthClient) (*types.Transaction, error) {
+		client = c
+		return c.TransactBlobTx(&signOpts, contract, input, sidecar)
+	})
+	if err != nil || opts.NoSend || opts.Signer == nil {
+		return signedTx, err
+	}
+
+	if _, err := failoverCall(opts.Context, f, func(c *EthClient) (struct{}, error) {
+		if err := c.SendTransaction(opts.Context, signedTx); err != nil && !isTxAlreadyKnownErr(err) {
+			return struct{}{}, err
+		}
+		return struct{}{}, nil
+	}); err != nil {
+		client.releaseNonce(opts.From, signedTx.Nonce())
+		return nil, parseBlobFeeCapTooLowErr(err, signedTx.BlobGasFeeCap())
+	}
+	client.markNonceSent(opts.From, signedTx.Nonce())
+
+	return signedTx, nil
+}
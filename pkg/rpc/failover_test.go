@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFailoverEthClientNoEndpoints(t *testing.T) {
+	_, err := NewFailoverEthClient(nil, 0)
+	require.ErrorIs(t, err, errNoFailoverEndpoints)
+}
+
+func TestFailoverEthClientPrimaryDown(t *testing.T) {
+	primary := newTestEthClient(t, &testEthService{})
+	primary.Client.Close()
+
+	var secondaryCalls atomic.Int32
+	secondary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			secondaryCalls.Add(1)
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+
+	f, err := NewFailoverEthClient([]*EthClient{primary, secondary}, 0)
+	require.Nil(t, err)
+	require.Equal(t, primary, f.Active())
+
+	header, err := f.HeaderByNumber(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), header.Number.Uint64())
+	require.Equal(t, int32(1), secondaryCalls.Load())
+
+	// The primary endpoint should be skipped until it recovers.
+	require.Equal(t, secondary, f.Active())
+	_, err = f.HeaderByNumber(context.Background(), common.Big2)
+	require.Nil(t, err)
+	require.Equal(t, int32(2), secondaryCalls.Load())
+}
+
+func TestFailoverEthClientPrimaryTimeout(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+
+	unblock := make(chan struct{})
+	primary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			if down.Load() {
+				<-unblock
+			}
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+	t.Cleanup(func() { close(unblock) })
+	primary.CallTimeout = 50 * time.Millisecond
+
+	secondary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+
+	f, err := NewFailoverEthClient([]*EthClient{primary, secondary}, 10*time.Millisecond)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f.Start(ctx)
+
+	header, err := f.HeaderByNumber(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), header.Number.Uint64())
+	require.Equal(t, secondary, f.Active())
+
+	// The primary endpoint should be promoted back once it recovers.
+	down.Store(false)
+	require.Eventually(t, func() bool { return f.Active() == primary }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFailoverEthClientNoFailoverOnRPCError(t *testing.T) {
+	errNotFound := errors.New("header not found")
+	primary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) { return nil, errNotFound },
+	})
+
+	var secondaryCalls atomic.Int32
+	secondary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			secondaryCalls.Add(1)
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+
+	f, err := NewFailoverEthClient([]*EthClient{primary, secondary}, 0)
+	require.Nil(t, err)
+
+	_, err = f.HeaderByNumber(context.Background(), common.Big1)
+	require.ErrorContains(t, err, errNotFound.Error())
+	require.Zero(t, secondaryCalls.Load())
+	require.Equal(t, primary, f.Active())
+}
+
+func TestFailoverEthClientAllDown(t *testing.T) {
+	primary := newTestEthClient(t, &testEthService{})
+	primary.Client.Close()
+	secondary := newTestEthClient(t, &testEthService{})
+	secondary.Client.Close()
+
+	f, err := NewFailoverEthClient([]*EthClient{primary, secondary}, 0)
+	require.Nil(t, err)
+
+	_, err = f.BlockNumber(context.Background())
+	require.ErrorIs(t, err, rpc.ErrClientQuit)
+	require.Equal(t, primary, f.Active())
+}
+
+func TestFailoverEthClientTransactBlobTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	opts.Nonce = common.Big0
+	opts.GasLimit = 100_000
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+
+	// The primary endpoint times out after the transaction has been sent.
+	var primarySent, secondarySent atomic.Pointer[types.Transaction]
+	unblock := make(chan struct{})
+	t.Cleanup(func() { close(unblock) })
+	primary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		fillTransaction:      fillTestTransaction,
+		sendRawTransaction: func(tx *types.Transaction) error {
+			primarySent.Store(tx)
+			<-unblock
+			return nil
+		},
+	})
+	primary.CallTimeout = 50 * time.Millisecond
+
+	var secondaryFees atomic.Int32
+	secondary := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) {
+			secondaryFees.Add(1)
+			return common.Big2, nil
+		},
+		sendRawTransaction: func(tx *types.Transaction) error {
+			secondarySent.Store(tx)
+			return nil
+		},
+	})
+
+	f, err := NewFailoverEthClient([]*EthClient{primary, secondary}, 0)
+	require.Nil(t, err)
+
+	// The same signed transaction should be sent to the next endpoint, instead of a re-created one.
+	tx, err := f.TransactBlobTx(opts, common.HexToAddress("0x02"), nil, sidecar)
+	require.Nil(t, err)
+	require.NotNil(t, primarySent.Load())
+	require.NotNil(t, secondarySent.Load())
+	require.Equal(t, tx.Hash(), primarySent.Load().Hash())
+	require.Equal(t, tx.Hash(), secondarySent.Load().Hash())
+	require.Zero(t, secondaryFees.Load())
+	require.Equal(t, secondary, f.Active())
+
+	// A transaction without a signer is never sent, unless it's a dry run.
+	_, err = f.TransactBlobTx(
+		&bind.TransactOpts{From: opts.From, Nonce: common.Big1, GasLimit: 100_000},
+		common.HexToAddress("0x02"),
+		nil,
+		sidecar,
+	)
+	require.ErrorIs(t, err, errNoSigner)
+}
+
+func TestIsFailoverError(t *testing.T) {
+	require.False(t, isFailoverError(nil))
+	require.False(t, isFailoverError(errors.New("execution reverted")))
+	require.False(t, isFailoverError(rpc.HTTPError{StatusCode: 400}))
+	require.True(t, isFailoverError(rpc.HTTPError{StatusCode: 503}))
+	require.True(t, isFailoverError(rpc.HTTPError{StatusCode: 429}))
+	require.True(t, isFailoverError(context.DeadlineExceeded))
+	require.True(t, isFailoverError(rpc.ErrClientQuit))
+}
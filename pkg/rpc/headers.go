@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	defaultHeadersBatchSize uint64 = 100
+	// maxHeadersRange is the max number of headers which can be fetched by a single HeadersByRange call.
+	maxHeadersRange uint64 = 10_000
+)
+
+// HeadersByRange fetches the headers of the blocks in range [from, to] by JSON-RPC batch requests,
+// and returns them in order. If some headers are missing (e.g. `to` is beyond the current chain head),
+// only the contiguous headers before the first missing one will be returned.
+func (c *EthClient) HeadersByRange(ctx context.Context, from, to uint64) ([]*types.Header, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid headers range: from %d > to %d", from, to)
+	}
+	if to-from+1 > maxHeadersRange {
+		return nil, fmt.Errorf("headers range [%d, %d] exceeds max %d", from, to, maxHeadersRange)
+	}
+
+	batchSize := c.HeadersBatchSize
+	if batchSize == 0 {
+		batchSize = defaultHeadersBatchSize
+	}
+
+	headers := make([]*types.Header, 0, to-from+1)
+	for start := from; start <= to; start += batchSize {
+		end := min(start+batchSize-1, to)
+
+		batch, err := c.headersBatch(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, header := range batch {
+			if header == nil {
+				log.Debug("Header not found, stop fetching", "number", start+uint64(i), "from", from, "to", to)
+				return headers, nil
+			}
+			headers = append(headers, header)
+		}
+	}
+
+	return headers, nil
+}
+
+// headersBatch fetches the headers of the blocks in range [from, to] by one JSON-RPC batch request,
+// the missing headers will be nil in the returned slice.
+func (c *EthClient) headersBatch(ctx context.Context, from, to uint64) ([]*types.Header, error) {
+	ctxWithTimeout, cancel := c.ctxWithCallTimeout(ctx)
+	defer cancel()
+
+	var (
+		headers = make([]*types.Header, to-from+1)
+		reqs    = make([]rpc.BatchElem, to-from+1)
+	)
+	for i := range reqs {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i)), false},
+			Result: &headers[i],
+		}
+	}
+
+	if err := c.BatchCallContext(ctxWithTimeout, reqs); err != nil {
+		return nil, err
+	}
+
+	for i, req := range reqs {
+		if req.Error != nil {
+			return nil, fmt.Errorf("failed to fetch header %d: %w", from+uint64(i), req.Error)
+		}
+	}
+
+	return headers, nil
+}
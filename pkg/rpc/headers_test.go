@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHeadersService creates a mocked service whose chain head is the given height.
+func newTestHeadersService(height uint64) *testEthService {
+	return &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			if number < 0 || uint64(number) > height {
+				return nil, nil
+			}
+			return newTestHeader(uint64(number)), nil
+		},
+	}
+}
+
+func TestHeadersByRange(t *testing.T) {
+	client := newTestEthClient(t, newTestHeadersService(1000))
+	client.HeadersBatchSize = 7
+
+	headers, err := client.HeadersByRange(context.Background(), 10, 60)
+	require.Nil(t, err)
+	require.Equal(t, 51, len(headers))
+	for i, header := range headers {
+		require.Equal(t, uint64(10+i), header.Number.Uint64())
+	}
+
+	headers, err = client.HeadersByRange(context.Background(), 5, 5)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(headers))
+}
+
+func TestHeadersByRangeMissingHeaders(t *testing.T) {
+	client := newTestEthClient(t, newTestHeadersService(20))
+
+	headers, err := client.HeadersByRange(context.Background(), 10, 200)
+	require.Nil(t, err)
+	require.Equal(t, 11, len(headers))
+	require.Equal(t, uint64(20), headers[len(headers)-1].Number.Uint64())
+}
+
+func TestHeadersByRangeInvalidRange(t *testing.T) {
+	client := newTestEthClient(t, newTestHeadersService(20))
+
+	_, err := client.HeadersByRange(context.Background(), 10, 9)
+	require.ErrorContains(t, err, "invalid headers range")
+
+	_, err = client.HeadersByRange(context.Background(), 0, maxHeadersRange)
+	require.ErrorContains(t, err, "exceeds max")
+}
+
+func BenchmarkHeadersByRange(b *testing.B) {
+	client := newTestEthClient(b, newTestHeadersService(1000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.HeadersByRange(context.Background(), 0, 999); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHeaderByNumberLoop(b *testing.B) {
+	client := newTestEthClient(b, newTestHeadersService(1000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := int64(0); n < 1000; n++ {
+			if _, err := client.HeaderByNumber(context.Background(), big.NewInt(n)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// testEthService is a mocked `eth` namespace JSON-RPC service, its hooks can be set
+// to simulate different L1 node behaviors in unit tests.
+type testEthService struct {
+	sendRawTransaction   func(tx *types.Transaction) error
+	getTransactionByHash func(hash common.Hash) (*types.Transaction, error)
+	getReceipt           func(hash common.Hash) (*types.Receipt, error)
+	getHeaderByNumber    func(number rpc.BlockNumber) (*types.Header, error)
+	blobBaseFee          func() (*big.Int, error)
+	getTransactionCount  func(account common.Address) (uint64, error)
+	txPoolContentFrom    func(account common.Address) (map[string]map[string]*types.Transaction, error)
+	getHeaderByHash      func(hash common.Hash) (*types.Header, error)
+	newHeads             chan *types.Header
+	estimateGas          func(args map[string]interface{}) (uint64, error)
+	maxPriorityFeePerGas func() (*big.Int, error)
+	getBlobSidecars      func(blockHash common.Hash) ([]*rpcBlobSidecar, error)
+	getLogs              func(from, to uint64) ([]types.Log, error)
+	blockNumber          func() (uint64, error)
+	fillTransaction      func(args TransactionArgs) (*types.Transaction, error)
+	l1OriginByID         func(blockID *big.Int) (*rawdb.L1Origin, error)
+	chainID              func() (*big.Int, error)
+	call                 func(args map[string]interface{}) ([]byte, error)
+	getStorageAt         func(account common.Address, key common.Hash) (common.Hash, error)
+}
+
+// testFilterQuery is the filter query argument of the `eth_getLogs` RPC method.
+type testFilterQuery struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+}
+
+// testTxPoolService is a mocked `txpool` namespace JSON-RPC service, backed by the hooks of a testEthService.
+type testTxPoolService struct {
+	eth *testEthService
+}
+
+// ContentFrom implements the `txpool_contentFrom` RPC method.
+func (s *testTxPoolService) ContentFrom(account common.Address) (map[string]map[string]*types.Transaction, error) {
+	if s.eth.txPoolContentFrom == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.eth.txPoolContentFrom(account)
+}
+
+// testTaikoService is a mocked `taiko` namespace JSON-RPC service, backed by the hooks of a testEthService.
+type testTaikoService struct {
+	eth *testEthService
+}
+
+// L1OriginByID implements the `taiko_l1OriginByID` RPC method.
+func (s *testTaikoService) L1OriginByID(blockID *hexutil.Big) (*rawdb.L1Origin, error) {
+	if s.eth.l1OriginByID == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.eth.l1OriginByID(blockID.ToInt())
+}
+
+// ChainId implements the `eth_chainId` RPC method, the chain ID is 1 by default.
+func (s *testEthService) ChainId() (*hexutil.Big, error) { // nolint: revive,stylecheck
+	if s.chainID == nil {
+		return (*hexutil.Big)(common.Big1), nil
+	}
+
+	chainID, err := s.chainID()
+	return (*hexutil.Big)(chainID), err
+}
+
+// SendRawTransaction implements the `eth_sendRawTransaction` RPC method.
+func (s *testEthService) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	if s.sendRawTransaction == nil {
+		return common.Hash{}, errNotImplemented
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), s.sendRawTransaction(tx)
+}
+
+// GetTransactionByHash implements the `eth_getTransactionByHash` RPC method.
+func (s *testEthService) GetTransactionByHash(hash common.Hash) (*types.Transaction, error) {
+	if s.getTransactionByHash == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getTransactionByHash(hash)
+}
+
+// GetTransactionReceipt implements the `eth_getTransactionReceipt` RPC method.
+func (s *testEthService) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	if s.getReceipt == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getReceipt(hash)
+}
+
+// GetBlockByNumber implements the `eth_getBlockByNumber` RPC method, only the header will be returned.
+func (s *testEthService) GetBlockByNumber(number rpc.BlockNumber, _ bool) (*types.Header, error) {
+	if s.getHeaderByNumber == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getHeaderByNumber(number)
+}
+
+// BlobBaseFee implements the `eth_blobBaseFee` RPC method.
+func (s *testEthService) BlobBaseFee() (*hexutil.Big, error) {
+	if s.blobBaseFee == nil {
+		return nil, errNotImplemented
+	}
+
+	blobBaseFee, err := s.blobBaseFee()
+	return (*hexutil.Big)(blobBaseFee), err
+}
+
+// GetBlockByHash implements the `eth_getBlockByHash` RPC method, only the header will be returned.
+func (s *testEthService) GetBlockByHash(hash common.Hash, _ bool) (*types.Header, error) {
+	if s.getHeaderByHash == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getHeaderByHash(hash)
+}
+
+// NewHeads implements the `newHeads` subscription, the headers sent to the newHeads channel will be notified.
+func (s *testEthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported || s.newHeads == nil {
+		return nil, errNotImplemented
+	}
+
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case header := <-s.newHeads:
+				if err := notifier.Notify(sub.ID, header); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// EstimateGas implements the `eth_estimateGas` RPC method.
+func (s *testEthService) EstimateGas(args map[string]interface{}, _ *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	if s.estimateGas == nil {
+		return 0, errNotImplemented
+	}
+
+	gas, err := s.estimateGas(args)
+	return hexutil.Uint64(gas), err
+}
+
+// MaxPriorityFeePerGas implements the `eth_maxPriorityFeePerGas` RPC method.
+func (s *testEthService) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	if s.maxPriorityFeePerGas == nil {
+		return nil, errNotImplemented
+	}
+
+	gasTipCap, err := s.maxPriorityFeePerGas()
+	return (*hexutil.Big)(gasTipCap), err
+}
+
+// GetBlobSidecars implements the `eth_getBlobSidecars` RPC method.
+func (s *testEthService) GetBlobSidecars(blockHash common.Hash) ([]*rpcBlobSidecar, error) {
+	if s.getBlobSidecars == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getBlobSidecars(blockHash)
+}
+
+// GetLogs implements the `eth_getLogs` RPC method.
+func (s *testEthService) GetLogs(query testFilterQuery) ([]types.Log, error) {
+	if s.getLogs == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.getLogs(uint64(query.FromBlock), uint64(query.ToBlock))
+}
+
+// GetTransactionCount implements the `eth_getTransactionCount` RPC method.
+func (s *testEthService) GetTransactionCount(account common.Address, _ rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	if s.getTransactionCount == nil {
+		return 0, errNotImplemented
+	}
+
+	count, err := s.getTransactionCount(account)
+	return hexutil.Uint64(count), err
+}
+
+// BlockNumber implements the `eth_blockNumber` RPC method.
+func (s *testEthService) BlockNumber() (hexutil.Uint64, error) {
+	if s.blockNumber == nil {
+		return 0, errNotImplemented
+	}
+
+	number, err := s.blockNumber()
+	return hexutil.Uint64(number), err
+}
+
+// FillTransaction implements the `eth_fillTransaction` RPC method.
+func (s *testEthService) FillTransaction(args TransactionArgs) (*SignTransactionResult, error) {
+	if s.fillTransaction == nil {
+		return nil, errNotImplemented
+	}
+
+	tx, err := s.fillTransaction(args)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignTransactionResult{Raw: raw, Tx: tx}, nil
+}
+
+// Call implements the `eth_call` RPC method.
+func (s *testEthService) Call(args map[string]interface{}, _ rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if s.call == nil {
+		return nil, errNotImplemented
+	}
+
+	return s.call(args)
+}
+
+// GetStorageAt implements the `eth_getStorageAt` RPC method.
+func (s *testEthService) GetStorageAt(
+	account common.Address,
+	key common.Hash,
+	_ rpc.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	if s.getStorageAt == nil {
+		return nil, errNotImplemented
+	}
+
+	value, err := s.getStorageAt(account, key)
+	return value.Bytes(), err
+}
+
+// newTestEthClient creates a new EthClient instance which connects to the given mocked service in process.
+func newTestEthClient(t testing.TB, service *testEthService) *EthClient {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", service))
+	require.Nil(t, server.RegisterName("txpool", &testTxPoolService{service}))
+	require.Nil(t, server.RegisterName("taiko", &testTaikoService{service}))
+
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	return &EthClient{
+		ChainID:    common.Big1,
+		Client:     client,
+		gethClient: &gethClient{gethclient.New(client)},
+		ethClient:  &ethClient{ethclient.NewClient(client)},
+		timeout:    defaultTimeout,
+	}
+}
+
+// fillTestTransaction fills a dynamic fee transaction with the given arguments, the nonce defaults to zero.
+func fillTestTransaction(args TransactionArgs) (*types.Transaction, error) {
+	var nonce uint64
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   common.Big1,
+		Nonce:     nonce,
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(*args.Gas),
+		To:        args.To,
+		Data:      *args.Data,
+	}), nil
+}
+
+// newTestHeader creates a new header with the given number for testing.
+func newTestHeader(number uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: common.Big0,
+		BaseFee:    common.Big1,
+		Extra:      []byte{},
+	}
+}
+
+// newTestSignedTx creates a new signed transaction for testing.
+func newTestSignedTx(t *testing.T, nonce uint64) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(common.Big1), &types.DynamicFeeTx{
+		ChainID:   common.Big1,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &common.Address{},
+		Value:     common.Big0,
+	})
+	require.Nil(t, err)
+
+	return tx
+}
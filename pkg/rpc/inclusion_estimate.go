@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrInclusionUnlikely is returned by EstimateInclusionBlocks when the given fee caps are below the current
+	// fees, and the recent fee trend won't bring the fees under the caps within maxInclusionEstimateBlocks.
+	ErrInclusionUnlikely = errors.New("transaction inclusion unlikely with the given fee caps")
+	// inclusionEstimateWindow is the number of the recent L1 blocks whose fees are used for the trend.
+	inclusionEstimateWindow uint64 = 20
+	// maxInclusionEstimateBlocks is the max number of blocks EstimateInclusionBlocks will estimate.
+	maxInclusionEstimateBlocks uint64 = 256
+)
+
+// EstimateInclusionBlocks estimates how many L1 blocks it will take until a transaction with the given fee caps
+// is likely to be included, 0 means it can be included in the next block. The base fee and the blob base fee are
+// projected by their average per-block change rate over the recent inclusionEstimateWindow blocks, and the
+// larger estimate of the two is returned. A nil blobFeeCap means a non-blob transaction.
+func (c *EthClient) EstimateInclusionBlocks(
+	ctx context.Context,
+	gasFeeCap *big.Int,
+	blobFeeCap *big.Int,
+) (uint64, error) {
+	if gasFeeCap == nil {
+		return 0, errors.New("gas fee cap is required")
+	}
+
+	head, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch the latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		return 0, errors.New("latest header has no base fee")
+	}
+
+	var from uint64
+	if head.Number.Uint64()+1 > inclusionEstimateWindow {
+		from = head.Number.Uint64() + 1 - inclusionEstimateWindow
+	}
+	headers, err := c.HeadersByRange(ctx, from, head.Number.Uint64())
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch the recent headers: %w", err)
+	}
+
+	blocks, err := estimateInclusionBlocks(head.BaseFee, gasFeeCap, feeChangeRate(headers, headerBaseFee))
+	if err != nil {
+		return 0, fmt.Errorf("base fee: %w", err)
+	}
+	if blobFeeCap == nil {
+		return blocks, nil
+	}
+
+	blobBaseFee, err := c.blobBaseFeeOf(ctx, head)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the blob base fee: %w", err)
+	}
+	blobBlocks, err := estimateInclusionBlocks(blobBaseFee, blobFeeCap, feeChangeRate(headers, headerBlobBaseFee))
+	if err != nil {
+		return 0, fmt.Errorf("blob base fee: %w", err)
+	}
+
+	return max(blocks, blobBlocks), nil
+}
+
+// headerBaseFee returns the base fee of the given header.
+func headerBaseFee(header *types.Header) *big.Int {
+	return header.BaseFee
+}
+
+// headerBlobBaseFee returns the blob base fee of the given header, or nil if it is a pre-Cancun header.
+func headerBlobBaseFee(header *types.Header) *big.Int {
+	if header.ExcessBlobGas == nil {
+		return nil
+	}
+	return eip4844.CalcBlobFee(*header.ExcessBlobGas)
+}
+
+// feeChangeRate returns the average per-block change rate of the fees of the given headers, the headers
+// without the fee are skipped, and 1 will be returned if there are not enough fees for a trend.
+func feeChangeRate(headers []*types.Header, feeOf func(*types.Header) *big.Int) float64 {
+	var (
+		first, last             *big.Int
+		firstNumber, lastNumber uint64
+	)
+	for _, header := range headers {
+		fee := feeOf(header)
+		if fee == nil || fee.Sign() <= 0 {
+			continue
+		}
+		if first == nil {
+			first, firstNumber = fee, header.Number.Uint64()
+		}
+		last, lastNumber = fee, header.Number.Uint64()
+	}
+	if first == nil || lastNumber == firstNumber {
+		return 1
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(last), new(big.Float).SetInt(first)).Float64()
+	return math.Pow(ratio, 1/float64(lastNumber-firstNumber))
+}
+
+// estimateInclusionBlocks returns the number of blocks until the given fee, changing by the given per-block
+// rate, drops to the given cap.
+func estimateInclusionBlocks(fee *big.Int, feeCap *big.Int, rate float64) (uint64, error) {
+	if feeCap.Cmp(fee) >= 0 {
+		return 0, nil
+	}
+	if feeCap.Sign() <= 0 || rate >= 1 {
+		return 0, fmt.Errorf("%w: fee %s, cap %s", ErrInclusionUnlikely, fee, feeCap)
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(feeCap), new(big.Float).SetInt(fee)).Float64()
+	// Tolerate the floating point error, e.g. log(0.125) / log(0.5) might be slightly above 3.
+	blocks := math.Ceil(math.Log(ratio)/math.Log(rate) - 1e-9)
+	if blocks > float64(maxInclusionEstimateBlocks) {
+		return 0, fmt.Errorf(
+			"%w: fee %s, cap %s, more than %d blocks",
+			ErrInclusionUnlikely,
+			fee,
+			feeCap,
+			maxInclusionEstimateBlocks,
+		)
+	}
+
+	return uint64(blocks), nil
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFeeHistoryService creates a mocked service with a chain of the given height, whose base fee halves
+// in every block till 2^20 wei in the head block, and whose blob base fee stays flat.
+func newTestFeeHistoryService(height uint64) *testEthService {
+	excessBlobGas := uint64(10_000_000)
+	header := func(number uint64) *types.Header {
+		header := newTestHeader(number)
+		header.BaseFee = new(big.Int).Lsh(common.Big1, uint(20+height-number))
+		header.ExcessBlobGas = &excessBlobGas
+		return header
+	}
+
+	return &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			if number == rpc.LatestBlockNumber {
+				return header(height), nil
+			}
+			if number < 0 || uint64(number) > height {
+				return nil, nil
+			}
+			return header(uint64(number)), nil
+		},
+	}
+}
+
+func TestEstimateInclusionBlocks(t *testing.T) {
+	service := newTestFeeHistoryService(100)
+	client := newTestEthClient(t, service)
+	head, err := service.getHeaderByNumber(rpc.LatestBlockNumber)
+	require.Nil(t, err)
+	blobBaseFee := headerBlobBaseFee(head)
+
+	// Both caps are already above the current fees.
+	blocks, err := client.EstimateInclusionBlocks(context.Background(), big.NewInt(1<<20), blobBaseFee)
+	require.Nil(t, err)
+	require.Zero(t, blocks)
+
+	// The base fee halves in every block, 2^20 -> 2^17 takes 3 blocks.
+	blocks, err = client.EstimateInclusionBlocks(context.Background(), big.NewInt(1<<17), nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), blocks)
+	blocks, err = client.EstimateInclusionBlocks(context.Background(), big.NewInt(1<<17+1), blobBaseFee)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), blocks)
+
+	// The blob base fee is flat, the cap below it will never be reached.
+	_, err = client.EstimateInclusionBlocks(
+		context.Background(),
+		big.NewInt(1<<20),
+		new(big.Int).Sub(blobBaseFee, common.Big1),
+	)
+	require.ErrorIs(t, err, ErrInclusionUnlikely)
+
+	// 2^20 -> 1 wei takes 20 blocks, while a zero gas fee cap will never be reached.
+	blocks, err = client.EstimateInclusionBlocks(context.Background(), common.Big1, nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(20), blocks)
+	_, err = client.EstimateInclusionBlocks(context.Background(), common.Big0, nil)
+	require.ErrorIs(t, err, ErrInclusionUnlikely)
+}
+
+func TestFeeChangeRate(t *testing.T) {
+	headers := []*types.Header{
+		{Number: big.NewInt(1), BaseFee: big.NewInt(100)},
+		{Number: big.NewInt(2)},
+		{Number: big.NewInt(3), BaseFee: big.NewInt(400)},
+	}
+	require.InDelta(t, 2, feeChangeRate(headers, headerBaseFee), 1e-9)
+	require.Equal(t, float64(1), feeChangeRate(headers[:1], headerBaseFee))
+	require.Equal(t, float64(1), feeChangeRate(headers, headerBlobBaseFee))
+}
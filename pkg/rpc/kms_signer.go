@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// RemoteSignFn signs the given transaction signing hash with a remote key (e.g. a KMS key), and
+// returns the r, s, v values of the signature, v can be either in {0, 1} or {27, 28}.
+type RemoteSignFn func(ctx context.Context, hash common.Hash) (r *big.Int, s *big.Int, v byte, err error)
+
+// NewKMSSigner adapts the given remote signing callback into a bind.SignerFn, which can be used to
+// sign all transaction types (including blob transactions) for the given address, the Cancun signer
+// of the given chain is used for hashing.
+func NewKMSSigner(chainID *big.Int, address common.Address, sign RemoteSignFn) bind.SignerFn {
+	signer := types.NewCancunSigner(chainID)
+
+	return func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != address {
+			return nil, bind.ErrNotAuthorized
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+
+		r, s, v, err := sign(ctx, signer.Hash(tx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction remotely: %w", err)
+		}
+
+		sig, err := remoteSignatureBytes(r, s, v)
+		if err != nil {
+			return nil, err
+		}
+
+		signedTx, err := tx.WithSignature(signer, sig)
+		if err != nil {
+			return nil, err
+		}
+
+		sender, err := types.Sender(signer, signedTx)
+		if err != nil {
+			return nil, err
+		}
+		if sender != address {
+			return nil, fmt.Errorf("remote signature recovered to %s, expected %s", sender.Hex(), address.Hex())
+		}
+
+		return signedTx, nil
+	}
+}
+
+// remoteSignatureBytes converts the given r, s, v values into a 65 bytes [R || S || V] signature, the
+// s value will be normalized to the lower half of the curve order, since remote signers (e.g. AWS KMS)
+// don't enforce the EIP-2 malleability rule.
+func remoteSignatureBytes(r, s *big.Int, v byte) ([]byte, error) {
+	if r == nil || s == nil {
+		return nil, fmt.Errorf("invalid remote signature: r=%v, s=%v", r, s)
+	}
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("invalid remote signature recovery id: %d", v)
+	}
+
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+		v ^= 1
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[crypto.RecoveryIDOffset] = v
+
+	return sig, nil
+}
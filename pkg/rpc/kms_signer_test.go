@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRemoteSignFn creates a mocked remote signer backed by a local key, if highS is set, the
+// returned signature will use the high s value, like some KMS services do.
+func newTestRemoteSignFn(t *testing.T, highS bool) (common.Address, RemoteSignFn) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	sign := func(_ context.Context, hash common.Hash) (*big.Int, *big.Int, byte, error) {
+		sig, err := crypto.Sign(hash.Bytes(), key)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		r, s, v := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), sig[64]
+		if highS {
+			s = new(big.Int).Sub(secp256k1N, s)
+			v ^= 1
+		}
+
+		return r, s, v + 27, nil
+	}
+
+	return crypto.PubkeyToAddress(key.PublicKey), sign
+}
+
+func TestKMSSignerBlobTx(t *testing.T) {
+	for _, highS := range []bool{false, true} {
+		address, sign := newTestRemoteSignFn(t, highS)
+
+		opts := &bind.TransactOpts{From: address, Signer: NewKMSSigner(common.Big1, address, sign)}
+
+		tx := newTestSignedBlobTx(t, opts, 1)
+		require.Equal(t, uint8(types.BlobTxType), tx.Type())
+
+		sender, err := types.Sender(types.NewCancunSigner(common.Big1), tx)
+		require.Nil(t, err)
+		require.Equal(t, address, sender)
+	}
+}
+
+func TestKMSSignerErrors(t *testing.T) {
+	address, sign := newTestRemoteSignFn(t, false)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: common.Big1, Nonce: 1, Gas: 21000})
+
+	// Unknown address.
+	_, err := NewKMSSigner(common.Big1, address, sign)(common.Address{}, tx)
+	require.ErrorIs(t, err, bind.ErrNotAuthorized)
+
+	// Remote signer failure.
+	errRemote := errors.New("kms unavailable")
+	_, err = NewKMSSigner(
+		common.Big1,
+		address,
+		func(context.Context, common.Hash) (*big.Int, *big.Int, byte, error) { return nil, nil, 0, errRemote },
+	)(address, tx)
+	require.ErrorIs(t, err, errRemote)
+
+	// Signature of another key.
+	_, otherSign := newTestRemoteSignFn(t, false)
+	_, err = NewKMSSigner(common.Big1, address, otherSign)(address, tx)
+	require.ErrorContains(t, err, "remote signature recovered to")
+
+	// Invalid recovery id.
+	_, err = NewKMSSigner(
+		common.Big1,
+		address,
+		func(context.Context, common.Hash) (*big.Int, *big.Int, byte, error) {
+			return common.Big1, common.Big1, 5, nil
+		},
+	)(address, tx)
+	require.ErrorContains(t, err, "invalid remote signature recovery id")
+}
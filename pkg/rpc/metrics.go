@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// recordCall records the call count, latency and error count of the given RPC method to the
+// metrics registry, if it is set.
+func (c *EthClient) recordCall(method string, start time.Time, err *error) {
+	if c.MetricsRegistry == nil {
+		return
+	}
+
+	metrics.GetOrRegisterCounter(fmt.Sprintf("rpc/%s/calls", method), c.MetricsRegistry).Inc(1)
+	metrics.GetOrRegisterTimer(fmt.Sprintf("rpc/%s/latency", method), c.MetricsRegistry).UpdateSince(start)
+	if err != nil && *err != nil {
+		metrics.GetOrRegisterCounter(fmt.Sprintf("rpc/%s/errors", method), c.MetricsRegistry).Inc(1)
+	}
+}
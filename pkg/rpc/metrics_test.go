@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCallMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			if number > 1 {
+				return nil, errors.New("header not found")
+			}
+			return newTestHeader(uint64(number)), nil
+		},
+	})
+	client.MetricsRegistry = metrics.NewRegistry()
+
+	_, err := client.HeaderByNumber(context.Background(), common.Big1)
+	require.Nil(t, err)
+	_, err = client.HeaderByNumber(context.Background(), common.Big1)
+	require.Nil(t, err)
+	_, err = client.HeaderByNumber(context.Background(), common.Big2)
+	require.NotNil(t, err)
+
+	calls, ok := client.MetricsRegistry.Get("rpc/HeaderByNumber/calls").(metrics.Counter)
+	require.True(t, ok)
+	require.Equal(t, int64(3), calls.Snapshot().Count())
+
+	errs, ok := client.MetricsRegistry.Get("rpc/HeaderByNumber/errors").(metrics.Counter)
+	require.True(t, ok)
+	require.Equal(t, int64(1), errs.Snapshot().Count())
+
+	latency, ok := client.MetricsRegistry.Get("rpc/HeaderByNumber/latency").(metrics.Timer)
+	require.True(t, ok)
+	require.Equal(t, int64(3), latency.Snapshot().Count())
+
+	// Other methods are not recorded yet.
+	require.Nil(t, client.MetricsRegistry.Get("rpc/SendTransaction/calls"))
+}
+
+func TestRecordCallMetricsDisabled(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{})
+
+	// Should not panic without a registry.
+	_, err := client.HeaderByNumber(context.Background(), common.Big1)
+	require.NotNil(t, err)
+}
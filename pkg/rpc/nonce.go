@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// trackedNonce is the locally cached next nonce of an account, and its reserved nonces whose
+// transactions are still being sent.
+type trackedNonce struct {
+	next     uint64
+	inFlight map[uint64]struct{}
+	// stale is set after a failed sending, to reconcile with the node's pending nonce at the next call.
+	stale    bool
+	syncedAt time.Time
+}
+
+// NonceTracker tracks the next nonce of accounts locally, since some L1 providers don't count the
+// pending blob transactions sent by ourselves in their pending nonce. Each nonce handed out by Next
+// is reserved until it is marked as sent or released, so that the concurrent transactions of an
+// account get unique nonces. The cached nonce will be reconciled with the node's pending nonce
+// periodically, or after a failed sending.
+type NonceTracker struct {
+	mu                sync.Mutex
+	nonces            map[common.Address]*trackedNonce
+	reconcileInterval time.Duration
+}
+
+// NewNonceTracker creates a new NonceTracker instance.
+func NewNonceTracker(reconcileInterval time.Duration) *NonceTracker {
+	return &NonceTracker{nonces: make(map[common.Address]*trackedNonce), reconcileInterval: reconcileInterval}
+}
+
+// Next reserves and returns the next nonce of the given account, the given pendingNonceAt function
+// will be used to reconcile the cached nonce if needed. The caller must call MarkSent or Release
+// with the returned nonce once its transaction has been sent or failed to be sent.
+func (t *NonceTracker) Next(
+	ctx context.Context,
+	account common.Address,
+	pendingNonceAt func(ctx context.Context, account common.Address) (uint64, error),
+) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cached, ok := t.nonces[account]
+	if !ok || cached.stale || time.Since(cached.syncedAt) >= t.reconcileInterval {
+		pendingNonce, err := pendingNonceAt(ctx, account)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			cached = &trackedNonce{inFlight: make(map[uint64]struct{})}
+			t.nonces[account] = cached
+		}
+		t.reconcile(account, cached, pendingNonce)
+	}
+
+	nonce := cached.next
+	cached.next++
+	cached.inFlight[nonce] = struct{}{}
+
+	return nonce, nil
+}
+
+// reconcile reconciles the given cached nonce with the node's pending nonce, the mutex must be held.
+func (t *NonceTracker) reconcile(account common.Address, cached *trackedNonce, pendingNonce uint64) {
+	cached.syncedAt = time.Now()
+
+	// The node may not count our own pending transactions, so we never go backwards while some
+	// reserved nonces are still being sent.
+	if cached.next > pendingNonce {
+		if len(cached.inFlight) != 0 {
+			log.Debug(
+				"Local nonce is ahead of the pending nonce",
+				"account", account,
+				"local", cached.next,
+				"pending", pendingNonce,
+				"inFlight", len(cached.inFlight),
+			)
+			return
+		}
+		log.Warn(
+			"Local nonce is ahead of the pending nonce, dropping back",
+			"account", account,
+			"local", cached.next,
+			"pending", pendingNonce,
+		)
+	}
+
+	cached.next = pendingNonce
+	cached.stale = false
+}
+
+// MarkSent marks the transaction with the given nonce of the given account as sent successfully,
+// the cached nonce will be increased in case the nonce wasn't handed out by Next.
+func (t *NonceTracker) MarkSent(account common.Address, nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cached, ok := t.nonces[account]
+	if !ok {
+		return
+	}
+	delete(cached.inFlight, nonce)
+	if nonce+1 > cached.next {
+		cached.next = nonce + 1
+	}
+}
+
+// Release releases the reserved nonce of the given account after its transaction failed to be sent, the
+// nonce will be handed out again if it is the latest reserved one, and the cached nonce will be reconciled
+// with the node's pending nonce at the next call. Releasing a nonce which is not reserved is a no-op.
+func (t *NonceTracker) Release(account common.Address, nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cached, ok := t.nonces[account]
+	if !ok {
+		return
+	}
+	if _, reserved := cached.inFlight[nonce]; !reserved {
+		return
+	}
+
+	delete(cached.inFlight, nonce)
+	if nonce+1 == cached.next {
+		cached.next = nonce
+	}
+	cached.stale = true
+}
+
+// Reset drops the cached nonce of the given account, so that it will be re-fetched from the node next time.
+func (t *NonceTracker) Reset(account common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.nonces, account)
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceTracker(t *testing.T) {
+	var (
+		account      = common.HexToAddress("0x01")
+		pendingNonce uint64
+		fetched      int
+		fetch        = func(context.Context, common.Address) (uint64, error) {
+			fetched++
+			return pendingNonce, nil
+		}
+		tracker = NewNonceTracker(time.Hour)
+	)
+
+	// Fetch the pending nonce at first.
+	pendingNonce = 5
+	nonce, err := tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(5), nonce)
+	require.Equal(t, 1, fetched)
+
+	// Use the locally cached nonce after a successful sending.
+	tracker.MarkSent(account, nonce)
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), nonce)
+	require.Equal(t, 1, fetched)
+
+	// Reconcile with the pending nonce after a reset.
+	tracker.Reset(account)
+	pendingNonce = 10
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), nonce)
+	require.Equal(t, 2, fetched)
+}
+
+func TestNonceTrackerReconcile(t *testing.T) {
+	var (
+		account      = common.HexToAddress("0x01")
+		pendingNonce uint64
+		fetch        = func(context.Context, common.Address) (uint64, error) { return pendingNonce, nil }
+		tracker      = NewNonceTracker(0)
+	)
+
+	nonce, err := tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), nonce)
+
+	// The node doesn't count our own transaction which is still being sent, keep using the local one.
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), nonce)
+	tracker.MarkSent(account, 0)
+	tracker.MarkSent(account, 1)
+
+	// Nothing is in flight, drop back to the node's pending nonce, e.g. after a dropped transaction.
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), nonce)
+	tracker.MarkSent(account, nonce)
+
+	// The node's pending nonce is ahead.
+	pendingNonce = 3
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), nonce)
+}
+
+func TestNonceTrackerConcurrentReservations(t *testing.T) {
+	var (
+		account = common.HexToAddress("0x01")
+		fetch   = func(context.Context, common.Address) (uint64, error) { return 5, nil }
+		tracker = NewNonceTracker(time.Hour)
+		nonces  = make(chan uint64, 10)
+		wg      sync.WaitGroup
+	)
+
+	// The concurrent callers get unique nonces, before any of them is sent.
+	for i := 0; i < cap(nonces); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := tracker.Next(context.Background(), account, fetch)
+			require.Nil(t, err)
+			nonces <- nonce
+		}()
+	}
+	wg.Wait()
+	close(nonces)
+
+	seen := make(map[uint64]bool)
+	for nonce := range nonces {
+		require.False(t, seen[nonce])
+		seen[nonce] = true
+	}
+	for nonce := uint64(5); nonce < 15; nonce++ {
+		require.True(t, seen[nonce])
+	}
+}
+
+func TestNonceTrackerRelease(t *testing.T) {
+	var (
+		account      = common.HexToAddress("0x01")
+		pendingNonce uint64
+		fetched      int
+		fetch        = func(context.Context, common.Address) (uint64, error) {
+			fetched++
+			return pendingNonce, nil
+		}
+		tracker = NewNonceTracker(time.Hour)
+	)
+
+	for i := uint64(0); i < 3; i++ {
+		nonce, err := tracker.Next(context.Background(), account, fetch)
+		require.Nil(t, err)
+		require.Equal(t, i, nonce)
+	}
+	require.Equal(t, 1, fetched)
+
+	// The latest reserved nonce is rolled back after a failed sending, and reconciled at the next call,
+	// the other nonces are kept since they are still in flight.
+	tracker.Release(account, 2)
+	tracker.Release(account, 2)
+	nonce, err := tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), nonce)
+	require.Equal(t, 2, fetched)
+
+	// Releasing a nonce in the middle leaves a gap, which is healed once nothing is in flight.
+	tracker.Release(account, 1)
+	tracker.MarkSent(account, 2)
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), nonce)
+	tracker.MarkSent(account, 3)
+	tracker.MarkSent(account, 0)
+
+	pendingNonce = 1
+	nonce, err = tracker.Next(context.Background(), account, fetch)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), nonce)
+}
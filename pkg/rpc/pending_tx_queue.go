@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrNonceGap is returned by PendingTxQueue.Sync when the lowest pending transaction is above the on-chain
+// nonce, i.e. the transaction of a lower nonce has been dropped, so none of the pending transactions can
+// be mined until the gap is filled.
+var ErrNonceGap = errors.New("nonce gap before the pending transactions")
+
+// PendingTxQueue tracks the pending transactions of an account by nonce, along with the account's on-chain
+// nonce, so that the lowest stuck nonce can be bumped first, since the transactions of the higher nonces
+// can't be mined before it anyway.
+type PendingTxQueue struct {
+	client     *EthClient
+	from       common.Address
+	txs        map[uint64]*types.Transaction
+	minedNonce uint64
+	mutex      sync.Mutex
+}
+
+// NewPendingTxQueue creates a new PendingTxQueue instance for the given account.
+func NewPendingTxQueue(client *EthClient, from common.Address) *PendingTxQueue {
+	return &PendingTxQueue{client: client, from: from, txs: make(map[uint64]*types.Transaction)}
+}
+
+// Put adds the given transaction to the queue, it replaces the pending transaction of the same nonce.
+func (q *PendingTxQueue) Put(tx *types.Transaction) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.txs[tx.Nonce()] = tx
+}
+
+// Remove removes the pending transaction of the given nonce from the queue.
+func (q *PendingTxQueue) Remove(nonce uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.txs, nonce)
+}
+
+// Get returns the pending transaction of the given nonce.
+func (q *PendingTxQueue) Get(nonce uint64) (*types.Transaction, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	tx, ok := q.txs[nonce]
+	return tx, ok
+}
+
+// Lowest returns the pending transaction of the lowest nonce, which is not below the last synced
+// on-chain nonce.
+func (q *PendingTxQueue) Lowest() (*types.Transaction, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.lowest()
+}
+
+// IsLowest returns whether the given nonce is the lowest pending nonce of the queue.
+func (q *PendingTxQueue) IsLowest(nonce uint64) bool {
+	tx, ok := q.Lowest()
+	return ok && tx.Nonce() == nonce
+}
+
+// Sync re-fetches the on-chain nonce of the account, the pending transactions below it are mined and
+// skipped afterwards. If the lowest pending transaction is above the on-chain nonce, the cached nonce of
+// the client's NonceTracker will be reset, so that the next transaction fills the gap, and an error
+// wrapping ErrNonceGap will be returned.
+func (q *PendingTxQueue) Sync(ctx context.Context) error {
+	minedNonce, err := q.client.NonceAt(ctx, q.from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the on-chain nonce: %w", err)
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.minedNonce = minedNonce
+	lowest, ok := q.lowest()
+	if !ok || lowest.Nonce() == minedNonce {
+		return nil
+	}
+
+	log.Warn(
+		"Nonce gap before the pending transactions, re-syncing the nonce",
+		"account", q.from,
+		"onChainNonce", minedNonce,
+		"lowestPendingNonce", lowest.Nonce(),
+	)
+	if q.client.NonceTracker != nil {
+		q.client.NonceTracker.Reset(q.from)
+	}
+
+	return fmt.Errorf("%w: on-chain nonce %d, lowest pending nonce %d", ErrNonceGap, minedNonce, lowest.Nonce())
+}
+
+// lowest returns the pending transaction of the lowest nonce, the mutex must be held.
+func (q *PendingTxQueue) lowest() (*types.Transaction, bool) {
+	var lowest *types.Transaction
+	for nonce, tx := range q.txs {
+		if nonce < q.minedNonce {
+			continue
+		}
+		if lowest == nil || nonce < lowest.Nonce() {
+			lowest = tx
+		}
+	}
+
+	return lowest, lowest != nil
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingTxQueueSync(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var minedNonce uint64 = 1
+	client := newTestEthClient(t, &testEthService{
+		getTransactionCount: func(common.Address) (uint64, error) { return minedNonce, nil },
+	})
+	client.NonceTracker = NewNonceTracker(time.Hour)
+	q := NewPendingTxQueue(client, opts.From)
+
+	_, ok := q.Lowest()
+	require.False(t, ok)
+	require.Nil(t, q.Sync(context.Background()))
+
+	for _, nonce := range []uint64{3, 1, 2} {
+		q.Put(newTestSignedBlobTx(t, opts, nonce))
+	}
+	require.Nil(t, q.Sync(context.Background()))
+	require.True(t, q.IsLowest(1))
+	require.False(t, q.IsLowest(2))
+
+	// The nonce 1 has been mined.
+	minedNonce = 2
+	require.Nil(t, q.Sync(context.Background()))
+	require.True(t, q.IsLowest(2))
+
+	// The nonce 2 has been dropped, so there is a gap, and the local nonce is re-synced.
+	nonce, err := client.NextNonce(context.Background(), opts.From)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), nonce)
+	client.NonceTracker.MarkSent(opts.From, 3)
+	q.Remove(2)
+	require.ErrorIs(t, q.Sync(context.Background()), ErrNonceGap)
+	require.True(t, q.IsLowest(3))
+	nonce, err = client.NextNonce(context.Background(), opts.From)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), nonce)
+}
+
+func TestBlobTxManagerBumpLowestNonceFirst(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var (
+		mutex      sync.Mutex
+		height     uint64
+		minedNonce uint64 = 1
+		mined             = make(map[common.Hash]bool)
+		bumped     []uint64
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			height++
+			return newTestHeader(height), nil
+		},
+		getTransactionCount: func(common.Address) (uint64, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return minedNonce, nil
+		},
+		sendRawTransaction: func(tx *types.Transaction) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			// The original transactions are all stuck, and the replacements are mined at once, the
+			// lower nonces must have been mined before then.
+			if tx.GasTipCap().Cmp(big.NewInt(100)) > 0 {
+				require.Equal(t, minedNonce, tx.Nonce())
+				bumped = append(bumped, tx.Nonce())
+				mined[tx.Hash()] = true
+				minedNonce++
+			}
+			return nil
+		},
+		getReceipt: func(hash common.Hash) (*types.Receipt, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !mined[hash] {
+				return nil, nil
+			}
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				BlockNumber: new(big.Int).SetUint64(height),
+				Logs:        []*types.Log{},
+			}, nil
+		},
+	})
+
+	m := NewBlobTxManager(client, opts.From, opts.Signer, &BlobTxManagerOpts{
+		ResubmitBlocks:  1,
+		PollingInterval: time.Millisecond,
+	})
+
+	// Submit the stuck transactions out of order.
+	var futures []*BlobTxFuture
+	for _, nonce := range []uint64{3, 1, 2} {
+		futures = append(futures, m.Submit(context.Background(), newTestSignedBlobTx(t, opts, nonce)))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, future := range futures {
+		_, err := future.Wait(ctx)
+		require.Nil(t, err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, []uint64{1, 2, 3}, bumped)
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// proposalLengthPrefixSize is the size of the big-endian length prefix of each packed proposal.
+const proposalLengthPrefixSize = 4
+
+var (
+	errProposalsTooLarge     = errors.New("packed proposals exceed the blob size")
+	errInvalidPackedProposal = errors.New("invalid packed proposal")
+)
+
+// PackProposals packs the given proposal payloads into a single blob payload, each payload is framed
+// with a 4-byte big-endian length prefix, so that they can be split back out by UnpackProposals. The
+// returned offsets are the starting positions of the payloads (excluding the prefixes) in the packed
+// blob, and the total size of the packed blob won't exceed BlobBytes.
+func PackProposals(datas [][]byte) (blob []byte, offsets []int, err error) {
+	size := 0
+	for _, data := range datas {
+		size += proposalLengthPrefixSize + len(data)
+	}
+	if size > BlobBytes {
+		return nil, nil, fmt.Errorf("%w: %d > %d", errProposalsTooLarge, size, BlobBytes)
+	}
+
+	blob = make([]byte, 0, size)
+	offsets = make([]int, 0, len(datas))
+	for _, data := range datas {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(data)))
+		offsets = append(offsets, len(blob))
+		blob = append(blob, data...)
+	}
+
+	return blob, offsets, nil
+}
+
+// UnpackProposals splits the blob payload packed by PackProposals back out into the proposal payloads.
+func UnpackProposals(blob []byte) ([][]byte, error) {
+	var datas [][]byte
+	for offset := 0; offset < len(blob); {
+		if len(blob)-offset < proposalLengthPrefixSize {
+			return nil, fmt.Errorf("%w: truncated length prefix at offset %d", errInvalidPackedProposal, offset)
+		}
+		length := int(binary.BigEndian.Uint32(blob[offset:]))
+		offset += proposalLengthPrefixSize
+
+		if length > len(blob)-offset {
+			return nil, fmt.Errorf(
+				"%w: length %d at offset %d exceeds the remaining %d bytes",
+				errInvalidPackedProposal,
+				length,
+				offset,
+				len(blob)-offset,
+			)
+		}
+		datas = append(datas, blob[offset:offset+length])
+		offset += length
+	}
+
+	return datas, nil
+}
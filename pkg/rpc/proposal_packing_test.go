@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackProposalsRoundTrip(t *testing.T) {
+	datas := [][]byte{
+		bytes.Repeat([]byte{0x01}, 1),
+		{},
+		bytes.Repeat([]byte{0x02}, 1024),
+		bytes.Repeat([]byte{0x03}, 31),
+		bytes.Repeat([]byte{0x04}, 64*1024),
+	}
+
+	blob, offsets, err := PackProposals(datas)
+	require.Nil(t, err)
+	require.LessOrEqual(t, len(blob), BlobBytes)
+	require.Len(t, offsets, len(datas))
+	for i, data := range datas {
+		require.Equal(t, data, blob[offsets[i]:offsets[i]+len(data)])
+	}
+
+	unpacked, err := UnpackProposals(blob)
+	require.Nil(t, err)
+	require.Len(t, unpacked, len(datas))
+	for i, data := range datas {
+		require.Equal(t, data, unpacked[i])
+	}
+
+	// The packed proposals should also survive the blob encoding.
+	sidecar, err := MakeSidecar(blob)
+	require.Nil(t, err)
+	decoded, err := DecodeBlob(sidecar.Blobs[0])
+	require.Nil(t, err)
+	unpacked, err = UnpackProposals(decoded)
+	require.Nil(t, err)
+	require.Len(t, unpacked, len(datas))
+}
+
+func TestPackProposalsOverflow(t *testing.T) {
+	_, _, err := PackProposals([][]byte{make([]byte, BlobBytes-proposalLengthPrefixSize)})
+	require.Nil(t, err)
+
+	_, _, err = PackProposals([][]byte{
+		make([]byte, BlobBytes/2),
+		make([]byte, BlobBytes/2),
+	})
+	require.ErrorIs(t, err, errProposalsTooLarge)
+}
+
+func TestUnpackProposalsInvalid(t *testing.T) {
+	blob, _, err := PackProposals([][]byte{[]byte("hello"), []byte("world")})
+	require.Nil(t, err)
+
+	_, err = UnpackProposals(blob[:len(blob)-1])
+	require.ErrorIs(t, err, errInvalidPackedProposal)
+	_, err = UnpackProposals(append(blob, 0x00))
+	require.ErrorIs(t, err, errInvalidPackedProposal)
+
+	unpacked, err := UnpackProposals(nil)
+	require.Nil(t, err)
+	require.Empty(t, unpacked)
+}
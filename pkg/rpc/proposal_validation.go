@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// ErrProposalTooManyBlobs is returned when the blobs of a proposal exceed the remaining blob
+	// capacity of the current L1 block.
+	ErrProposalTooManyBlobs = errors.New("proposal blobs exceed the remaining blob capacity")
+	// ErrProposalGasLimitExceeded is returned when the estimated gas of a proposal exceeds the L1 block
+	// gas limit.
+	ErrProposalGasLimitExceeded = errors.New("proposal gas exceeds the block gas limit")
+	// ErrProposalOverBudget is returned when the estimated total fee of a proposal exceeds ProposalFeeBudget.
+	ErrProposalOverBudget = errors.New("proposal fee exceeds the budget")
+)
+
+// ValidateProposal checks whether the proposal transaction calling the given contract, with the given input
+// and blob data, fits the current L1 block before sending it. The number of its blobs is checked against the
+// remaining blob capacity of the current block, its estimated gas against the block gas limit, and its
+// estimated total fee against ProposalFeeBudget if it is set, an error wrapping ErrProposalTooManyBlobs,
+// ErrProposalGasLimitExceeded or ErrProposalOverBudget will be returned respectively.
+func (c *EthClient) ValidateProposal(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	blobData []byte,
+) error {
+	header, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	blobs, blobGas, err := BlobGasForData(len(blobData))
+	if err != nil {
+		return err
+	}
+	var blobGasUsed uint64
+	if header.BlobGasUsed != nil {
+		blobGasUsed = *header.BlobGasUsed
+	}
+	if blobGasUsed+blobGas > params.MaxBlobGasPerBlock {
+		return fmt.Errorf(
+			"%w: %d blobs, %d blobs remaining in block %d",
+			ErrProposalTooManyBlobs,
+			blobs,
+			(params.MaxBlobGasPerBlock-min(blobGasUsed, params.MaxBlobGasPerBlock))/params.BlobTxBlobGasPerBlob,
+			header.Number,
+		)
+	}
+
+	cost, err := c.EstimateBlobTxCost(ctx, opts, contract, input, blobData)
+	if err != nil {
+		return err
+	}
+	if cost.GasLimit > header.GasLimit {
+		return fmt.Errorf("%w: %d > %d", ErrProposalGasLimitExceeded, cost.GasLimit, header.GasLimit)
+	}
+	if c.ProposalFeeBudget != nil && cost.Total.Cmp(c.ProposalFeeBudget) > 0 {
+		return fmt.Errorf("%w: %s > %s", ErrProposalOverBudget, cost.Total, c.ProposalFeeBudget)
+	}
+
+	return nil
+}
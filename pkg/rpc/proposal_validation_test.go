@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProposal(t *testing.T) {
+	var (
+		blobGasUsed uint64
+		gasLimit    uint64 = 30_000_000
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			header := newTestHeader(1)
+			header.BaseFee = big.NewInt(7)
+			header.GasLimit = gasLimit
+			header.BlobGasUsed = &blobGasUsed
+			return header, nil
+		},
+		blobBaseFee: func() (*big.Int, error) { return big.NewInt(3), nil },
+		estimateGas: func(map[string]interface{}) (uint64, error) { return 50_000, nil },
+	})
+	validate := func(blobData []byte) error {
+		return client.ValidateProposal(
+			context.Background(),
+			&bind.TransactOpts{GasTipCap: big.NewInt(2)},
+			common.HexToAddress("0x01"),
+			[]byte{0x01},
+			blobData,
+		)
+	}
+
+	require.Nil(t, validate([]byte{0x01}))
+
+	// Too many blobs for the remaining blob capacity.
+	blobGasUsed = params.MaxBlobGasPerBlock - params.BlobTxBlobGasPerBlob
+	require.Nil(t, validate([]byte{0x01}))
+	require.ErrorIs(t, validate(make([]byte, eth.MaxBlobDataSize+1)), ErrProposalTooManyBlobs)
+	blobGasUsed = params.MaxBlobGasPerBlock
+	require.ErrorIs(t, validate([]byte{0x01}), ErrProposalTooManyBlobs)
+	blobGasUsed = 0
+
+	// The estimated gas exceeds the block gas limit.
+	gasLimit = 49_999
+	require.ErrorIs(t, validate([]byte{0x01}), ErrProposalGasLimitExceeded)
+	gasLimit = 30_000_000
+
+	// total = 9 * inflated gas limit + 3 * BlobTxBlobGasPerBlob
+	total := big.NewInt(9*int64(inflateGasLimit(50_000, 0, gasLimit)) + 3*params.BlobTxBlobGasPerBlob)
+	client.ProposalFeeBudget = total
+	require.Nil(t, validate([]byte{0x01}))
+	client.ProposalFeeBudget = new(big.Int).Sub(total, common.Big1)
+	require.ErrorIs(t, validate([]byte{0x01}), ErrProposalOverBudget)
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+var errInvalidProposeBlockInput = errors.New("invalid proposeBlock input")
+
+// EncodeProposeBlockInput ABI encodes the TaikoL1.proposeBlock call with the given block params and the
+// calldata txList, which should be empty if the txList is sent in a blob. The returned bytes can be used
+// as the input of TransactBlobTx directly.
+func EncodeProposeBlockInput(params *encoding.BlockParams, txList []byte) ([]byte, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: empty block params", errInvalidProposeBlockInput)
+	}
+	if params.AssignedProver == ZeroAddress {
+		return nil, fmt.Errorf("%w: empty assigned prover", errInvalidProposeBlockInput)
+	}
+	for i, hookCall := range params.HookCalls {
+		if hookCall.Hook == ZeroAddress {
+			return nil, fmt.Errorf("%w: empty hook address of hook call %d", errInvalidProposeBlockInput, i)
+		}
+	}
+	if uint64(len(txList)) > BlockMaxTxListBytes {
+		return nil, fmt.Errorf(
+			"%w: txList length %d exceeds max %d",
+			errInvalidProposeBlockInput,
+			len(txList),
+			BlockMaxTxListBytes,
+		)
+	}
+	if txList == nil {
+		txList = []byte{}
+	}
+
+	encodedParams, err := encoding.EncodeBlockParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encoding.TaikoL1ABI.Pack("proposeBlock", encodedParams, txList)
+	if err != nil {
+		return nil, encoding.TryParsingCustomError(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func TestEncodeProposeBlockInput(t *testing.T) {
+	params := &encoding.BlockParams{
+		AssignedProver: common.HexToAddress("0x01"),
+		Coinbase:       common.HexToAddress("0x02"),
+		ExtraData:      StringToBytes32("extra"),
+		ParentMetaHash: common.HexToHash("0x03"),
+		HookCalls:      []encoding.HookCall{{Hook: common.HexToAddress("0x04"), Data: []byte{0x05, 0x06}}},
+	}
+	txList := []byte{0x07, 0x08, 0x09}
+
+	data, err := EncodeProposeBlockInput(params, txList)
+	require.Nil(t, err)
+
+	// The encoded bytes should decode back to the same input via the TaikoL1 ABI.
+	method, err := encoding.TaikoL1ABI.MethodById(data)
+	require.Nil(t, err)
+	require.Equal(t, "proposeBlock", method.Name)
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.Nil(t, err)
+	require.Len(t, args, 2)
+
+	decoded, err := encoding.DecodeBlockParams(args[0].([]byte))
+	require.Nil(t, err)
+	require.Equal(t, params, decoded)
+	require.Equal(t, txList, args[1])
+
+	unpackedTxList, err := encoding.UnpackTxListBytes(data)
+	require.Nil(t, err)
+	require.Equal(t, txList, unpackedTxList)
+
+	// A nil txList is encoded as an empty one, as the txList is sent in the blob.
+	data, err = EncodeProposeBlockInput(params, nil)
+	require.Nil(t, err)
+	unpackedTxList, err = encoding.UnpackTxListBytes(data)
+	require.Nil(t, err)
+	require.Empty(t, unpackedTxList)
+}
+
+func TestEncodeProposeBlockInputInvalid(t *testing.T) {
+	params := &encoding.BlockParams{AssignedProver: common.HexToAddress("0x01")}
+
+	_, err := EncodeProposeBlockInput(nil, nil)
+	require.ErrorIs(t, err, errInvalidProposeBlockInput)
+	_, err = EncodeProposeBlockInput(&encoding.BlockParams{}, nil)
+	require.ErrorIs(t, err, errInvalidProposeBlockInput)
+	_, err = EncodeProposeBlockInput(
+		&encoding.BlockParams{
+			AssignedProver: common.HexToAddress("0x01"),
+			HookCalls:      []encoding.HookCall{{Data: []byte{0x01}}},
+		},
+		nil,
+	)
+	require.ErrorIs(t, err, errInvalidProposeBlockInput)
+
+	_, err = EncodeProposeBlockInput(params, bytes.Repeat([]byte{0x01}, int(BlockMaxTxListBytes)))
+	require.Nil(t, err)
+	_, err = EncodeProposeBlockInput(params, bytes.Repeat([]byte{0x01}, int(BlockMaxTxListBytes)+1))
+	require.ErrorIs(t, err, errInvalidProposeBlockInput)
+}
@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ProposerAddressFromTx recovers the proposer address, i.e. the sender, of the given L1 proposal transaction.
+// If the given signer doesn't support the transaction type, e.g. a London signer for a blob transaction, the
+// latest signer of the signer's chain is used instead. A nil signer means the latest signer of the
+// transaction's chain.
+func ProposerAddressFromTx(tx *types.Transaction, signer types.Signer) (common.Address, error) {
+	if signer == nil {
+		signer = types.LatestSignerForChainID(tx.ChainId())
+	}
+
+	proposer, err := types.Sender(signer, tx)
+	if errors.Is(err, types.ErrTxTypeNotSupported) {
+		proposer, err = types.Sender(types.LatestSignerForChainID(signer.ChainID()), tx)
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover the proposer of tx %s: %w", tx.Hash(), err)
+	}
+
+	return proposer, nil
+}
@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposerAddressFromTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	sidecar, err := MakeSidecar([]byte("blob"))
+	assert.Nil(t, err)
+
+	tx, err := types.SignNewTx(key, types.NewCancunSigner(common.Big1), &types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        100_000,
+		To:         common.HexToAddress("0x01"),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	assert.Nil(t, err)
+
+	for _, signer := range []types.Signer{
+		nil,
+		types.NewCancunSigner(common.Big1),
+		types.NewLondonSigner(common.Big1),
+	} {
+		proposer, err := ProposerAddressFromTx(tx, signer)
+		assert.Nil(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), proposer)
+	}
+
+	// The signer of another chain.
+	_, err = ProposerAddressFromTx(tx, types.NewCancunSigner(big.NewInt(2)))
+	assert.ErrorIs(t, err, types.ErrInvalidChainId)
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// erc1967ImplementationSlot is the storage slot of the implementation address of an ERC-1967 proxy, i.e.
+// `keccak256("eip1967.proxy.implementation") - 1`.
+var erc1967ImplementationSlot = common.HexToHash(
+	"0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc",
+)
+
+// protocolConfigCache caches the protocol config along with the TaikoL1 implementation it's read from.
+type protocolConfigCache struct {
+	config         *bindings.TaikoDataConfig
+	implementation common.Hash
+	mutex          sync.Mutex
+}
+
+// GetProtocolConfig fetches the protocol config from the TaikoL1 contract, so that the components can
+// configure themselves with the live on-chain config. The config is cached until the TaikoL1 proxy is
+// upgraded to another implementation, i.e. a protocol fork, or InvalidateProtocolConfig is called. The
+// returned config is shared, and must not be modified.
+func (c *Client) GetProtocolConfig(ctx context.Context) (*bindings.TaikoDataConfig, error) {
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultTimeout)
+	defer cancel()
+
+	implementation, err := c.L1.StorageAt(ctxWithTimeout, c.taikoL1Address, erc1967ImplementationSlot, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.protocolConfig.mutex.Lock()
+	defer c.protocolConfig.mutex.Unlock()
+
+	if c.protocolConfig.config != nil && c.protocolConfig.implementation == common.BytesToHash(implementation) {
+		return c.protocolConfig.config, nil
+	}
+
+	config, err := c.TaikoL1.GetConfig(&bind.CallOpts{Context: ctxWithTimeout})
+	if err != nil {
+		return nil, err
+	}
+	c.protocolConfig.config = &config
+	c.protocolConfig.implementation = common.BytesToHash(implementation)
+
+	return c.protocolConfig.config, nil
+}
+
+// InvalidateProtocolConfig drops the cached protocol config, so that it will be fetched again by the next
+// GetProtocolConfig call.
+func (c *Client) InvalidateProtocolConfig() {
+	c.protocolConfig.mutex.Lock()
+	defer c.protocolConfig.mutex.Unlock()
+
+	c.protocolConfig.config = nil
+}
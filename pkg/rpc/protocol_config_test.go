@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+func TestGetProtocolConfig(t *testing.T) {
+	var (
+		taikoL1Address = common.HexToAddress("0x1000")
+		implementation atomic.Value
+		calls          atomic.Int64
+		config         = bindings.TaikoDataConfig{
+			ChainId:                    167001,
+			BlockMaxProposals:          432_000,
+			BlockRingBufferSize:        432_010,
+			BlockMaxGasLimit:           15_000_000,
+			LivenessBond:               new(big.Int).Mul(big.NewInt(250), big.NewInt(1e18)),
+			EthDepositRingBufferSize:   big.NewInt(1024),
+			EthDepositMinCountPerBlock: 8,
+			EthDepositMaxCountPerBlock: 32,
+			EthDepositMinAmount:        big.NewInt(1e18),
+			EthDepositMaxAmount:        new(big.Int).Mul(big.NewInt(10_000), big.NewInt(1e18)),
+			EthDepositGas:              big.NewInt(21000),
+			EthDepositMaxFee:           big.NewInt(1e18),
+			BlockSyncThreshold:         16,
+		}
+	)
+	implementation.Store(common.HexToHash("0x2000"))
+
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
+	require.Nil(t, err)
+	l1 := newTestEthClient(t, &testEthService{
+		call: func(args map[string]interface{}) ([]byte, error) {
+			require.Equal(t, taikoL1Address.Hex(), common.HexToAddress(args["to"].(string)).Hex())
+			calls.Add(1)
+			return taikoL1ABI.Methods["getConfig"].Outputs.Pack(config)
+		},
+		getStorageAt: func(account common.Address, key common.Hash) (common.Hash, error) {
+			require.Equal(t, taikoL1Address, account)
+			require.Equal(t, erc1967ImplementationSlot, key)
+			return implementation.Load().(common.Hash), nil
+		},
+	})
+	taikoL1, err := bindings.NewTaikoL1Client(taikoL1Address, l1)
+	require.Nil(t, err)
+	client := &Client{L1: l1, TaikoL1: taikoL1, taikoL1Address: taikoL1Address}
+
+	// The config is cached.
+	for i := 0; i < 2; i++ {
+		fetched, err := client.GetProtocolConfig(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, config, *fetched)
+	}
+	require.Equal(t, int64(1), calls.Load())
+
+	// The config is fetched again after a protocol fork.
+	config.BlockMaxGasLimit = 30_000_000
+	implementation.Store(common.HexToHash("0x3000"))
+	fetched, err := client.GetProtocolConfig(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint32(30_000_000), fetched.BlockMaxGasLimit)
+	require.Equal(t, int64(2), calls.Load())
+
+	// Or after the cache is invalidated.
+	client.InvalidateProtocolConfig()
+	_, err = client.GetProtocolConfig(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(3), calls.Load())
+}
@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	waitMinedInitialInterval  = 500 * time.Millisecond
+	errReceiptReorged         = errors.New("transaction receipt has been reorged")
+	errNotEnoughConfirmations = errors.New("not enough transaction confirmations")
+)
+
+// WaitMined keeps waiting for the receipt of the given transaction, see waitReceipt, until the
+// transaction is included in the canonical chain, or the context is done or ReceiptWaitTimeout
+// elapses. If the block including the transaction is reorged, it will resume waiting.
+func (c *EthClient) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := c.ctxWithReceiptWaitTimeout(ctx)
+	defer cancel()
+
+	var receipt *types.Receipt
+	if err := c.waitReceipt(ctx, func() (err error) {
+		receipt, err = c.canonicalReceipt(ctx, tx.Hash())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction reverted, hash: %s", tx.Hash())
+	}
+
+	return receipt, nil
+}
+
+// WaitConfirmations keeps waiting for the receipt of the given transaction, see waitReceipt, until the
+// block including the transaction is buried under n blocks in the canonical chain, or the context is done
+// or ReceiptWaitTimeout elapses. The receipt is re-verified at each step, so if the block including the
+// transaction is reorged, it will resume waiting for the new inclusion.
+func (c *EthClient) WaitConfirmations(ctx context.Context, txHash common.Hash, n uint64) (*types.Receipt, error) {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := c.ctxWithReceiptWaitTimeout(ctx)
+	defer cancel()
+
+	var receipt *types.Receipt
+	if err := c.waitReceipt(ctx, func() error {
+		r, err := c.canonicalReceipt(ctx, txHash)
+		if err != nil {
+			return err
+		}
+
+		head, err := c.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		confirmations := new(big.Int).Sub(head.Number, r.BlockNumber)
+		if confirmations.Cmp(new(big.Int).SetUint64(n)) < 0 {
+			log.Debug(
+				"Waiting for transaction confirmations",
+				"hash", txHash,
+				"blockNumber", r.BlockNumber,
+				"confirmations", confirmations,
+				"required", n,
+			)
+			return errNotEnoughConfirmations
+		}
+
+		receipt = r
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction reverted, hash: %s", txHash)
+	}
+
+	return receipt, nil
+}
+
+// canonicalReceipt fetches the receipt of the given transaction, and makes sure the block including the
+// transaction is still in the canonical chain.
+func (c *EthClient) canonicalReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	r, err := c.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		log.Debug("Transaction receipt not found, keep waiting", "hash", txHash, "error", err)
+		return nil, err
+	}
+
+	header, err := c.HeaderByNumber(ctx, r.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header.Hash() != r.BlockHash {
+		log.Warn(
+			"Block including the transaction has been reorged, keep waiting",
+			"hash", txHash,
+			"blockNumber", r.BlockNumber,
+			"blockHash", r.BlockHash,
+			"canonicalBlockHash", header.Hash(),
+		)
+		return nil, errReceiptReorged
+	}
+
+	return r, nil
+}
+
+// receiptPollInterval returns the interval of polling the transaction receipts.
+func (c *EthClient) receiptPollInterval() time.Duration {
+	if c.ReceiptPollInterval != 0 {
+		return c.ReceiptPollInterval
+	}
+	return waitReceiptPollingInterval
+}
+
+// ctxWithReceiptWaitTimeout applies ReceiptWaitTimeout to the given context, if it is set.
+func (c *EthClient) ctxWithReceiptWaitTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.ReceiptWaitTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.ReceiptWaitTimeout)
+}
+
+// waitReceipt keeps calling the given check until it succeeds or the context is done. If the endpoint
+// supports subscriptions, e.g. a websocket endpoint, the check is re-run on each new chain head, and at
+// the latest after ReceiptPollInterval in case a notification is missed, otherwise the check is polled with
+// an exponential backoff policy, which is capped by ReceiptPollInterval.
+func (c *EthClient) waitReceipt(ctx context.Context, check func() error) error {
+	interval := c.receiptPollInterval()
+
+	if c.Client != nil && c.SupportsSubscriptions() {
+		heads := make(chan *types.Header, 1)
+		sub, err := c.SubscribeNewHead(ctx, heads)
+		if err == nil {
+			defer sub.Unsubscribe()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				if err := check(); err == nil {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-heads:
+				case <-ticker.C:
+				case err := <-sub.Err():
+					log.Debug("Chain head subscription failed, fall back to polling", "error", err)
+					return c.pollReceipt(ctx, interval, check)
+				}
+			}
+		}
+		log.Debug("Failed to subscribe chain heads, fall back to polling", "error", err)
+	}
+
+	return c.pollReceipt(ctx, interval, check)
+}
+
+// pollReceipt keeps polling the given check with an exponential backoff policy, whose max interval is the
+// given interval, until it succeeds or the context is done.
+func (c *EthClient) pollReceipt(ctx context.Context, interval time.Duration, check func() error) error {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = min(waitMinedInitialInterval, interval)
+	expBackoff.MaxInterval = interval
+	expBackoff.MaxElapsedTime = 0
+
+	return backoff.Retry(check, backoff.WithContext(expBackoff, ctx))
+}
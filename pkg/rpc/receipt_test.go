@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	waitMinedInitialInterval = time.Millisecond
+}
+
+// newTestReceiptService creates a mocked service which includes the transaction in block 1
+// after the given delay, and then reorgs it out of block 1 `reorgedTimes` times.
+func newTestReceiptService(delay time.Duration, reorgedTimes int, status uint64) *testEthService {
+	var (
+		header = newTestHeader(1)
+		sentAt = time.Now()
+	)
+	return &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			return header, nil
+		},
+		getReceipt: func(hash common.Hash) (*types.Receipt, error) {
+			if time.Since(sentAt) < delay {
+				return nil, nil
+			}
+
+			blockHash := header.Hash()
+			if reorgedTimes > 0 {
+				reorgedTimes--
+				blockHash = common.Hash{}
+			}
+
+			return &types.Receipt{
+				Status:      status,
+				TxHash:      hash,
+				BlockHash:   blockHash,
+				BlockNumber: header.Number,
+				Logs:        []*types.Log{},
+			}, nil
+		},
+	}
+}
+
+func TestWaitMined(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, newTestReceiptService(50*time.Millisecond, 0, types.ReceiptStatusSuccessful))
+
+	receipt, err := client.WaitMined(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, common.Big1, receipt.BlockNumber)
+}
+
+func TestWaitMinedReorged(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, newTestReceiptService(0, 2, types.ReceiptStatusSuccessful))
+
+	receipt, err := client.WaitMined(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, newTestHeader(1).Hash(), receipt.BlockHash)
+}
+
+func TestWaitMinedReverted(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, newTestReceiptService(0, 0, types.ReceiptStatusFailed))
+
+	receipt, err := client.WaitMined(context.Background(), tx)
+	require.ErrorContains(t, err, "transaction reverted")
+	require.NotNil(t, receipt)
+}
+
+func TestWaitMinedTimeout(t *testing.T) {
+	client := newTestEthClient(t, newTestReceiptService(time.Hour, 0, types.ReceiptStatusSuccessful))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitMined(ctx, newTestSignedTx(t, 0))
+	require.ErrorContains(t, err, "context deadline exceeded")
+}
+
+func TestWaitMinedPollInterval(t *testing.T) {
+	var (
+		mutex   sync.Mutex
+		polls   []time.Time
+		service = newTestReceiptService(300*time.Millisecond, 0, types.ReceiptStatusSuccessful)
+		receipt = service.getReceipt
+	)
+	service.getReceipt = func(hash common.Hash) (*types.Receipt, error) {
+		mutex.Lock()
+		polls = append(polls, time.Now())
+		mutex.Unlock()
+		return receipt(hash)
+	}
+	client := newTestEthClient(t, service)
+	client.ReceiptPollInterval = 50 * time.Millisecond
+
+	_, err := client.WaitMined(context.Background(), newTestSignedTx(t, 0))
+	require.Nil(t, err)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Greater(t, len(polls), 3)
+	for i := 1; i < len(polls); i++ {
+		// The backoff randomizes the interval by up to 50%.
+		require.Less(t, polls[i].Sub(polls[i-1]), 150*time.Millisecond)
+	}
+}
+
+func TestWaitMinedReceiptWaitTimeout(t *testing.T) {
+	client := newTestEthClient(t, newTestReceiptService(time.Hour, 0, types.ReceiptStatusSuccessful))
+	client.ReceiptWaitTimeout = 100 * time.Millisecond
+
+	_, err := client.WaitMined(context.Background(), newTestSignedTx(t, 0))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitMinedSubscription(t *testing.T) {
+	service := newTestReceiptService(100*time.Millisecond, 0, types.ReceiptStatusSuccessful)
+	service.newHeads = make(chan *types.Header)
+	client := newTestEthClient(t, service)
+	client.ReceiptPollInterval = time.Hour
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		service.newHeads <- newTestHeader(2)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := client.WaitMined(ctx, newTestSignedTx(t, 0))
+	require.Nil(t, err)
+	require.Equal(t, common.Big1, receipt.BlockNumber)
+}
+
+// newTestConfirmationsService creates a mocked service which mines a new block at each latest head query,
+// and includes the transaction in block 1. Once the head passes the given height, block 1 is reorged, and
+// the transaction is included in block 3 instead.
+func newTestConfirmationsService(reorgAt uint64) *testEthService {
+	var (
+		mutex     sync.Mutex
+		head      uint64 = 1
+		reorged   bool
+		inclusion = newTestHeader(1)
+	)
+	return &testEthService{
+		getHeaderByNumber: func(number rpc.BlockNumber) (*types.Header, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if number != rpc.LatestBlockNumber {
+				header := newTestHeader(uint64(number))
+				if reorged && number == 1 {
+					header.Extra = []byte("reorged")
+				}
+				return header, nil
+			}
+
+			head++
+			if head > reorgAt {
+				reorged = true
+			}
+			return newTestHeader(head), nil
+		},
+		getReceipt: func(hash common.Hash) (*types.Receipt, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			receipt := &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				BlockHash:   inclusion.Hash(),
+				BlockNumber: inclusion.Number,
+				Logs:        []*types.Log{},
+			}
+			// The first receipt fetched after the reorg is still the stale one.
+			if reorged {
+				inclusion = newTestHeader(3)
+			}
+			return receipt, nil
+		},
+	}
+}
+
+func TestWaitConfirmations(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, newTestConfirmationsService(math.MaxUint64))
+
+	receipt, err := client.WaitConfirmations(context.Background(), tx.Hash(), 5)
+	require.Nil(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, common.Big1, receipt.BlockNumber)
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	require.Nil(t, err)
+	require.Greater(t, head.Number.Uint64(), uint64(1+5))
+}
+
+func TestWaitConfirmationsReorged(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, newTestConfirmationsService(3))
+
+	receipt, err := client.WaitConfirmations(context.Background(), tx.Hash(), 5)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(3), receipt.BlockNumber)
+	require.Equal(t, newTestHeader(3).Hash(), receipt.BlockHash)
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	require.Nil(t, err)
+	require.Greater(t, head.Number.Uint64(), uint64(3+5))
+}
+
+func TestWaitConfirmationsTimeout(t *testing.T) {
+	client := newTestEthClient(t, newTestReceiptService(time.Hour, 0, types.ReceiptStatusSuccessful))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitConfirmations(ctx, newTestSignedTx(t, 0).Hash(), 1)
+	require.ErrorContains(t, err, "context deadline exceeded")
+}
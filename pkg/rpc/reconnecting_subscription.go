@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var errNewHeadSubscriptionDropped = errors.New("new head subscription dropped")
+
+// HeadersGap notifies the consumers of SubscribeNewHeadWithReconnect that the headers between From and
+// To (both inclusive) might have been missed while reconnecting, they can be backfilled by HeadersByRange.
+type HeadersGap struct {
+	From uint64
+	To   uint64
+}
+
+// SubscribeNewHeadWithReconnect subscribes the new chain heads through a dedicated websocket connection to
+// the given URL. Once the connection drops, it will be re-dialed with a backoff, and the subscription will
+// be replayed, then if the first header received after reconnecting doesn't follow the last delivered one,
+// a HeadersGap will be sent to the given gap channel before that header.
+func SubscribeNewHeadWithReconnect(
+	url string,
+	ch chan<- *types.Header,
+	gapCh chan<- *HeadersGap,
+) event.Subscription {
+	return subscribeNewHeadWithReconnect(
+		func(ctx context.Context) (*rpc.Client, error) { return rpc.DialContext(ctx, url) },
+		ch,
+		gapCh,
+	)
+}
+
+// subscribeNewHeadWithReconnect subscribes the new chain heads through the connections created by the given
+// dial function, a new connection will be dialed each time the subscription is re-established.
+func subscribeNewHeadWithReconnect(
+	dial func(ctx context.Context) (*rpc.Client, error),
+	ch chan<- *types.Header,
+	gapCh chan<- *HeadersGap,
+) event.Subscription {
+	var (
+		lastHeight uint64
+		delivered  bool
+	)
+
+	return SubscribeEvent("NewHead", func(ctx context.Context) (event.Subscription, error) {
+		client, err := dial(ctx)
+		if err != nil {
+			log.Error("Dial new head subscription connection error", "error", err)
+			return nil, err
+		}
+		defer client.Close()
+
+		headCh := make(chan *types.Header, chainHeadBufferSize)
+		sub, err := client.EthSubscribe(ctx, headCh, "newHeads")
+		if err != nil {
+			log.Error("Create new head subscription error", "error", err)
+			return nil, err
+		}
+		defer sub.Unsubscribe()
+
+		// Only the first header after reconnecting needs to be checked for a gap.
+		reconnected := delivered
+		for {
+			select {
+			case err := <-sub.Err():
+				// The subscription might also end without an error, e.g. the connection has been closed.
+				if err == nil {
+					err = errNewHeadSubscriptionDropped
+				}
+				log.Warn("New head subscription dropped", "lastHeight", lastHeight, "error", err)
+				return sub, err
+			case <-ctx.Done():
+				return sub, nil
+			case header := <-headCh:
+				height := header.Number.Uint64()
+				if reconnected && height > lastHeight+1 {
+					gap := &HeadersGap{From: lastHeight + 1, To: height - 1}
+					log.Info("Missed new heads while reconnecting", "from", gap.From, "to", gap.To)
+					select {
+					case gapCh <- gap:
+					case <-ctx.Done():
+						return sub, nil
+					}
+				}
+				reconnected = false
+
+				select {
+				case ch <- header:
+				case <-ctx.Done():
+					return sub, nil
+				}
+				lastHeight, delivered = height, true
+			}
+		}
+	})
+}
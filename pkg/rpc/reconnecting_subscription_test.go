@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// testNewHeadsService is a mocked `eth` namespace JSON-RPC service, which broadcasts the published
+// headers to all of its active `newHeads` subscriptions.
+type testNewHeadsService struct {
+	mutex sync.Mutex
+	subs  map[rpc.ID]chan *types.Header
+}
+
+// NewHeads implements the `newHeads` subscription.
+func (s *testNewHeadsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, errNotImplemented
+	}
+
+	sub := notifier.CreateSubscription()
+	ch := make(chan *types.Header, 16)
+	s.mutex.Lock()
+	s.subs[sub.ID] = ch
+	s.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			s.mutex.Lock()
+			delete(s.subs, sub.ID)
+			s.mutex.Unlock()
+		}()
+		for {
+			select {
+			case header := <-ch:
+				if err := notifier.Notify(sub.ID, header); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func (s *testNewHeadsService) publish(header *types.Header) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, ch := range s.subs {
+		ch <- header
+	}
+}
+
+func (s *testNewHeadsService) subscriptions() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.subs)
+}
+
+func TestSubscribeNewHeadWithReconnect(t *testing.T) {
+	service := &testNewHeadsService{subs: make(map[rpc.ID]chan *types.Header)}
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", service))
+	defer server.Stop()
+
+	var (
+		mutex   sync.Mutex
+		clients []*rpc.Client
+		ch      = make(chan *types.Header, 16)
+		gapCh   = make(chan *HeadersGap, 16)
+	)
+	sub := subscribeNewHeadWithReconnect(func(context.Context) (*rpc.Client, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		client := rpc.DialInProc(server)
+		clients = append(clients, client)
+		return client, nil
+	}, ch, gapCh)
+	defer sub.Unsubscribe()
+
+	receive := func() *types.Header {
+		select {
+		case header := <-ch:
+			return header
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for new head")
+			return nil
+		}
+	}
+
+	require.Eventually(t, func() bool { return service.subscriptions() == 1 }, time.Second, time.Millisecond)
+	for i := uint64(1); i <= 2; i++ {
+		service.publish(newTestHeader(i))
+		require.Equal(t, i, receive().Number.Uint64())
+	}
+
+	// Drop the connection, the headers 3 and 4 are produced while reconnecting.
+	mutex.Lock()
+	clients[0].Close()
+	mutex.Unlock()
+
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(clients) == 2 && service.subscriptions() == 1
+	}, time.Second, time.Millisecond)
+
+	service.publish(newTestHeader(5))
+	select {
+	case gap := <-gapCh:
+		require.Equal(t, &HeadersGap{From: 3, To: 4}, gap)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for headers gap")
+	}
+	require.Equal(t, uint64(5), receive().Number.Uint64())
+
+	// No more gaps for the following headers.
+	service.publish(newTestHeader(6))
+	require.Equal(t, uint64(6), receive().Number.Uint64())
+	require.Empty(t, gapCh)
+}
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrReplacementBudgetExhausted is returned by ReplacementTracker when a replacement would exceed the max
+// number of attempts or the max fee budget, so that the stuck transaction can be abandoned.
+var ErrReplacementBudgetExhausted = errors.New("transaction replacement budget exhausted")
+
+// ReplacementTracker tracks the fee-bumped replacements of a transaction at the same nonce, and bounds them
+// by a max number of replacement attempts and a max fee budget. The fee budget is compared against the
+// max fee a replacement can cost, i.e. `gas * gasFeeCap + blobGas * blobFeeCap`. A zero max attempts or
+// a nil max fee means no limit.
+type ReplacementTracker struct {
+	nonce       uint64
+	originalFee *big.Int
+	maxAttempts uint64
+	maxFee      *big.Int
+	attempts    uint64
+	feeIncrease *big.Int
+	mutex       sync.Mutex
+}
+
+// NewReplacementTracker creates a new ReplacementTracker instance for the given original transaction.
+func NewReplacementTracker(original *types.Transaction, maxAttempts uint64, maxFee *big.Int) *ReplacementTracker {
+	return &ReplacementTracker{
+		nonce:       original.Nonce(),
+		originalFee: maxTxFee(original),
+		maxAttempts: maxAttempts,
+		maxFee:      maxFee,
+		feeIncrease: new(big.Int),
+	}
+}
+
+// Track records the given replacement, it returns an error wrapping ErrReplacementBudgetExhausted without
+// recording it, if the replacement exceeds the max attempts or the max fee budget.
+func (t *ReplacementTracker) Track(replacement *types.Transaction) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if replacement.Nonce() != t.nonce {
+		return fmt.Errorf("replacement nonce mismatch: have %d, want %d", replacement.Nonce(), t.nonce)
+	}
+	if t.maxAttempts != 0 && t.attempts >= t.maxAttempts {
+		return fmt.Errorf("%w: %d attempts (nonce %d)", ErrReplacementBudgetExhausted, t.attempts, t.nonce)
+	}
+
+	fee := maxTxFee(replacement)
+	if t.maxFee != nil && fee.Cmp(t.maxFee) > 0 {
+		return fmt.Errorf(
+			"%w: max fee %s exceeds the budget %s (nonce %d)",
+			ErrReplacementBudgetExhausted,
+			fee,
+			t.maxFee,
+			t.nonce,
+		)
+	}
+
+	t.attempts++
+	t.feeIncrease = new(big.Int).Sub(fee, t.originalFee)
+
+	return nil
+}
+
+// Nonce returns the nonce of the tracked transaction.
+func (t *ReplacementTracker) Nonce() uint64 {
+	return t.nonce
+}
+
+// Attempts returns the number of the tracked replacements.
+func (t *ReplacementTracker) Attempts() uint64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.attempts
+}
+
+// FeeIncrease returns the total increase of the max fee of the latest replacement over the original
+// transaction.
+func (t *ReplacementTracker) FeeIncrease() *big.Int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return new(big.Int).Set(t.feeIncrease)
+}
+
+// maxTxFee returns the max fee the given transaction can cost.
+func maxTxFee(tx *types.Transaction) *big.Int {
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+	if tx.Type() == types.BlobTxType {
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(tx.BlobGas()), tx.BlobGasFeeCap()))
+	}
+
+	return fee
+}
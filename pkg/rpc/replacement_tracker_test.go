@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplacementTracker(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+	tx := newTestSignedBlobTx(t, opts, 3)
+
+	// 21000 * 200 + 131072 * 10
+	require.Equal(t, big.NewInt(5_510_720), maxTxFee(tx))
+
+	tracker := NewReplacementTracker(tx, 2, nil)
+	require.Equal(t, uint64(3), tracker.Nonce())
+
+	current := tx
+	for i := 0; i < 2; i++ {
+		current, err = BumpBlobTxFees(current, 100)
+		require.Nil(t, err)
+		require.Nil(t, tracker.Track(current))
+	}
+	require.Equal(t, uint64(2), tracker.Attempts())
+	require.Equal(t, new(big.Int).Sub(maxTxFee(current), maxTxFee(tx)), tracker.FeeIncrease())
+
+	// Out of attempts.
+	current, err = BumpBlobTxFees(current, 100)
+	require.Nil(t, err)
+	require.ErrorIs(t, tracker.Track(current), ErrReplacementBudgetExhausted)
+	require.Equal(t, uint64(2), tracker.Attempts())
+
+	// Out of the fee budget.
+	tracker = NewReplacementTracker(tx, 0, big.NewInt(12_000_000))
+	replacement, err := BumpBlobTxFees(tx, 100)
+	require.Nil(t, err)
+	require.Nil(t, tracker.Track(replacement))
+	replacement, err = BumpBlobTxFees(replacement, 100)
+	require.Nil(t, err)
+	require.ErrorIs(t, tracker.Track(replacement), ErrReplacementBudgetExhausted)
+	require.Equal(t, uint64(1), tracker.Attempts())
+
+	require.ErrorContains(t, tracker.Track(newTestSignedBlobTx(t, opts, 4)), "nonce mismatch")
+}
+
+func TestBlobTxManagerReplacementBudgetExhausted(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, common.Big1)
+	require.Nil(t, err)
+
+	var (
+		mutex  sync.Mutex
+		height uint64
+		sent   []*types.Transaction
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber: func(rpc.BlockNumber) (*types.Header, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			height++
+			return newTestHeader(height), nil
+		},
+		getTransactionCount: func(common.Address) (uint64, error) { return 1, nil },
+		sendRawTransaction: func(tx *types.Transaction) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			sent = append(sent, tx)
+			return nil
+		},
+		// Never mined.
+		getReceipt: func(common.Hash) (*types.Receipt, error) { return nil, nil },
+	})
+
+	m := NewBlobTxManager(client, opts.From, opts.Signer, &BlobTxManagerOpts{
+		ResubmitBlocks:  1,
+		PollingInterval: time.Millisecond,
+		MaxReplacements: 2,
+	})
+
+	tx := newTestSignedBlobTx(t, opts, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = m.Submit(ctx, tx).Wait(ctx)
+	require.ErrorIs(t, err, ErrReplacementBudgetExhausted)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, 3, len(sent))
+	_, ok := m.Pending(tx.Nonce())
+	require.False(t, ok)
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	defaultSendTxMaxRetries    uint64 = 5
+	defaultSendTxRetryInterval        = 1 * time.Second
+	// recoverableSendTxErrs are the errors which mean the same transaction may be accepted
+	// by the node in a later attempt. An underpriced replacement is not, since resending the same
+	// transaction never bumps its fees, which is left to BlobTxManager.
+	recoverableSendTxErrs = []string{
+		txpool.ErrAlreadyKnown.Error(),
+		"timeout",
+		"connection refused",
+		"connection reset",
+	}
+)
+
+// SendTxRetryOpts contains all options for retrying sending a transaction.
+type SendTxRetryOpts struct {
+	// MaxRetries is the maximum number of retries, default to 5.
+	MaxRetries uint64
+	// RetryInterval is the initial interval of the exponential backoff, default to 1s.
+	RetryInterval time.Duration
+}
+
+// SendTransactionWithRetry sends the given signed transaction, and retries with an exponential backoff
+// policy when a recoverable error is returned by the node. If the node reports that the transaction is
+// already known or its nonce is too low, it will be treated as a success when the transaction
+// can be found in the mempool or the chain.
+func (c *EthClient) SendTransactionWithRetry(
+	ctx context.Context,
+	tx *types.Transaction,
+	opts *SendTxRetryOpts,
+) error {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	var (
+		maxRetries    = defaultSendTxMaxRetries
+		retryInterval = defaultSendTxRetryInterval
+	)
+	if opts != nil {
+		if opts.MaxRetries != 0 {
+			maxRetries = opts.MaxRetries
+		}
+		if opts.RetryInterval != 0 {
+			retryInterval = opts.RetryInterval
+		}
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = retryInterval
+
+	return backoff.Retry(
+		func() error {
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+
+			err := c.SendTransaction(ctx, tx)
+			if err == nil {
+				return nil
+			}
+
+			if isTxAlreadyKnownErr(err) || isNonceTooLowErr(err) {
+				if _, _, findErr := c.TransactionByHash(ctx, tx.Hash()); findErr == nil {
+					log.Info("Transaction has already been sent", "hash", tx.Hash(), "reason", err)
+					return nil
+				}
+				// The nonce has been used by another transaction.
+				if isNonceTooLowErr(err) {
+					return backoff.Permanent(err)
+				}
+			}
+
+			if !isRecoverableSendTxErr(err) {
+				return backoff.Permanent(err)
+			}
+
+			log.Warn("Failed to send transaction, retrying", "hash", tx.Hash(), "error", err)
+			return err
+		},
+		backoff.WithContext(backoff.WithMaxRetries(expBackoff, maxRetries), ctx),
+	)
+}
+
+// isRecoverableSendTxErr returns true if the given error is a temporary error.
+func isRecoverableSendTxErr(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	for _, recoverableErr := range recoverableSendTxErrs {
+		if strings.Contains(err.Error(), recoverableErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTxAlreadyKnownErr returns true if the error signals that the transaction is already in the mempool.
+func isTxAlreadyKnownErr(err error) bool {
+	return strings.Contains(err.Error(), txpool.ErrAlreadyKnown.Error())
+}
+
+// isNonceTooLowErr returns true if the error signals that the transaction nonce is too low.
+func isNonceTooLowErr(err error) bool {
+	return strings.Contains(err.Error(), core.ErrNonceTooLow.Error())
+}
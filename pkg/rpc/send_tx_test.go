@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSendTxRetryOpts = &SendTxRetryOpts{MaxRetries: 3, RetryInterval: time.Millisecond}
+	errTestConnRefused  = errors.New("dial tcp: connection refused")
+)
+
+func TestSendTransactionWithRetryRecoverable(t *testing.T) {
+	var attempts int
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction: func(tx *types.Transaction) error {
+			if attempts++; attempts < 3 {
+				return errTestConnRefused
+			}
+			return nil
+		},
+	})
+
+	require.Nil(t, client.SendTransactionWithRetry(context.Background(), newTestSignedTx(t, 0), testSendTxRetryOpts))
+	require.Equal(t, 3, attempts)
+}
+
+func TestSendTransactionWithRetryMaxRetries(t *testing.T) {
+	var attempts int
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction: func(tx *types.Transaction) error {
+			attempts++
+			return errTestConnRefused
+		},
+	})
+
+	err := client.SendTransactionWithRetry(context.Background(), newTestSignedTx(t, 0), testSendTxRetryOpts)
+	require.ErrorContains(t, err, errTestConnRefused.Error())
+	require.Equal(t, int(testSendTxRetryOpts.MaxRetries)+1, attempts)
+}
+
+func TestSendTransactionWithRetryReplaceUnderpriced(t *testing.T) {
+	var attempts int
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction: func(tx *types.Transaction) error {
+			attempts++
+			return txpool.ErrReplaceUnderpriced
+		},
+	})
+
+	// Resending the same transaction can't replace the existing one.
+	err := client.SendTransactionWithRetry(context.Background(), newTestSignedTx(t, 0), testSendTxRetryOpts)
+	require.ErrorContains(t, err, txpool.ErrReplaceUnderpriced.Error())
+	require.Equal(t, 1, attempts)
+}
+
+func TestSendTransactionWithRetryUnrecoverable(t *testing.T) {
+	var attempts int
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction: func(tx *types.Transaction) error {
+			attempts++
+			return core.ErrInsufficientFunds
+		},
+	})
+
+	err := client.SendTransactionWithRetry(context.Background(), newTestSignedTx(t, 0), testSendTxRetryOpts)
+	require.ErrorContains(t, err, core.ErrInsufficientFunds.Error())
+	require.Equal(t, 1, attempts)
+}
+
+func TestSendTransactionWithRetryAlreadyKnown(t *testing.T) {
+	tx := newTestSignedTx(t, 0)
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction: func(*types.Transaction) error { return txpool.ErrAlreadyKnown },
+		getTransactionByHash: func(hash common.Hash) (*types.Transaction, error) {
+			if hash == tx.Hash() {
+				return tx, nil
+			}
+			return nil, nil
+		},
+	})
+
+	require.Nil(t, client.SendTransactionWithRetry(context.Background(), tx, testSendTxRetryOpts))
+}
+
+func TestSendTransactionWithRetryNonceTooLow(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		sendRawTransaction:   func(*types.Transaction) error { return core.ErrNonceTooLow },
+		getTransactionByHash: func(common.Hash) (*types.Transaction, error) { return nil, nil },
+	})
+
+	err := client.SendTransactionWithRetry(context.Background(), newTestSignedTx(t, 0), testSendTxRetryOpts)
+	require.ErrorContains(t, err, core.ErrNonceTooLow.Error())
+}
+
+func TestIsRecoverableSendTxErr(t *testing.T) {
+	require.False(t, isRecoverableSendTxErr(txpool.ErrReplaceUnderpriced))
+	require.True(t, isRecoverableSendTxErr(errTestConnRefused))
+	require.True(t, isRecoverableSendTxErr(txpool.ErrAlreadyKnown))
+	require.True(t, isRecoverableSendTxErr(context.DeadlineExceeded))
+	require.False(t, isRecoverableSendTxErr(core.ErrInsufficientFunds))
+	require.False(t, isRecoverableSendTxErr(errors.New("execution reverted")))
+}
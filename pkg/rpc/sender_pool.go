@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// ErrNoAvailableSender is returned by SenderPool when all of its accounts are backed off for the
+	// stuck transactions.
+	ErrNoAvailableSender = errors.New("no available sender account")
+	// defaultSenderBackoff is the default duration an account with a stuck transaction is backed off for.
+	defaultSenderBackoff = 2 * time.Minute
+	// defaultSenderNonceReconcileInterval is the default interval of reconciling the nonces tracked by
+	// SenderPool with the node's pending nonces, if the client has no NonceTracker.
+	defaultSenderNonceReconcileInterval = time.Minute
+)
+
+// poolSender is a sender account of SenderPool.
+type poolSender struct {
+	opts       *bind.TransactOpts
+	pending    uint64
+	stuckUntil time.Time
+	// sendMutex serializes the transactions of the account, so that each gets a unique nonce.
+	sendMutex sync.Mutex
+}
+
+// SenderPool holds multiple L1 sender accounts, and spreads the transactions across them to increase the
+// throughput, since the transactions of a single account are serialized by nonce. Each transaction is sent
+// by the account with the fewest pending transactions, the ties are broken in a round-robin manner. The
+// accounts with stuck transactions are backed off for a while. The nonces of each account are tracked by the
+// client's NonceTracker if it is set, or by the pool's own one otherwise.
+type SenderPool struct {
+	client  *EthClient
+	senders []*poolSender
+	nonces  *NonceTracker
+	backoff time.Duration
+	cursor  int
+	now     func() time.Time
+	mutex   sync.Mutex
+}
+
+// NewSenderPool creates a new SenderPool instance with the given signing keys, a zero backoff means the
+// default value, which is 2 minutes.
+func NewSenderPool(
+	client *EthClient,
+	chainID *big.Int,
+	keys []*ecdsa.PrivateKey,
+	backoff time.Duration,
+) (*SenderPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("empty sender keys")
+	}
+	if backoff == 0 {
+		backoff = defaultSenderBackoff
+	}
+
+	pool := &SenderPool{client: client, nonces: client.NonceTracker, backoff: backoff, now: time.Now}
+	if pool.nonces == nil {
+		pool.nonces = NewNonceTracker(defaultSenderNonceReconcileInterval)
+	}
+	seen := make(map[common.Address]struct{}, len(keys))
+	for _, key := range keys {
+		opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[opts.From]; ok {
+			return nil, fmt.Errorf("duplicate sender account: %s", opts.From)
+		}
+		seen[opts.From] = struct{}{}
+		pool.senders = append(pool.senders, &poolSender{opts: opts})
+	}
+
+	return pool, nil
+}
+
+// Accounts returns the sender accounts of the pool.
+func (p *SenderPool) Accounts() []common.Address {
+	accounts := make([]common.Address, 0, len(p.senders))
+	for _, sender := range p.senders {
+		accounts = append(accounts, sender.opts.From)
+	}
+
+	return accounts
+}
+
+// Pending returns the number of the pending transactions of the given account, which have been sent by the
+// pool but not marked as mined yet.
+func (p *SenderPool) Pending(account common.Address) uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if sender := p.sender(account); sender != nil {
+		return sender.pending
+	}
+	return 0
+}
+
+// MarkMined marks a pending transaction of the given account as mined.
+func (p *SenderPool) MarkMined(account common.Address) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if sender := p.sender(account); sender != nil && sender.pending > 0 {
+		sender.pending--
+	}
+}
+
+// MarkStuck marks the given account as having a stuck transaction, the account won't be handed out until
+// the backoff elapses.
+func (p *SenderPool) MarkStuck(account common.Address) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if sender := p.sender(account); sender != nil {
+		log.Warn("Backing off the sender account with a stuck transaction", "account", account, "backoff", p.backoff)
+		sender.stuckUntil = p.now().Add(p.backoff)
+	}
+}
+
+// TransactBlobTx sends the blob transaction by the next available account, see EthClient.TransactBlobTx.
+// The sender, signer and nonce in the given transact options are ignored.
+func (p *SenderPool) TransactBlobTx(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	input []byte,
+	sidecar *types.BlobTxSidecar,
+) (*types.Transaction, error) {
+	sender, err := p.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	sender.sendMutex.Lock()
+	defer sender.sendMutex.Unlock()
+
+	from := sender.opts.From
+	nonce, err := p.nonces.Next(opts.Context, from, p.client.PendingNonceAt)
+	if err != nil {
+		p.MarkMined(from)
+		return nil, err
+	}
+
+	senderOpts := *opts
+	senderOpts.From = from
+	senderOpts.Signer = sender.opts.Signer
+	senderOpts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := p.client.TransactBlobTx(&senderOpts, contract, input, sidecar)
+	if err != nil {
+		p.nonces.Release(from, nonce)
+		p.MarkMined(from)
+		return nil, err
+	}
+	p.nonces.MarkSent(from, nonce)
+
+	return tx, nil
+}
+
+// acquire hands out the available account with the fewest pending transactions, and counts a new pending
+// transaction for it.
+func (p *SenderPool) acquire() (*poolSender, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var (
+		now    = p.now()
+		picked = -1
+	)
+	for i := 0; i < len(p.senders); i++ {
+		idx := (p.cursor + i) % len(p.senders)
+		sender := p.senders[idx]
+		if now.Before(sender.stuckUntil) {
+			continue
+		}
+		if picked == -1 || sender.pending < p.senders[picked].pending {
+			picked = idx
+		}
+	}
+	if picked == -1 {
+		return nil, ErrNoAvailableSender
+	}
+
+	p.cursor = (picked + 1) % len(p.senders)
+	p.senders[picked].pending++
+
+	return p.senders[picked], nil
+}
+
+// sender returns the sender of the given account, the mutex must be held.
+func (p *SenderPool) sender(account common.Address) *poolSender {
+	for _, sender := range p.senders {
+		if sender.opts.From == account {
+			return sender
+		}
+	}
+
+	return nil
+}
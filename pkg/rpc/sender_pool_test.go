@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderPool(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	var (
+		mutex  sync.Mutex
+		nonces = make(map[common.Address][]uint64)
+	)
+	client := newTestEthClient(t, &testEthService{
+		getHeaderByNumber:    func(rpc.BlockNumber) (*types.Header, error) { return newTestHeader(1), nil },
+		blobBaseFee:          func() (*big.Int, error) { return common.Big1, nil },
+		maxPriorityFeePerGas: func() (*big.Int, error) { return common.Big1, nil },
+		getTransactionCount:  func(common.Address) (uint64, error) { return 0, nil },
+		fillTransaction:      fillTestTransaction,
+		sendRawTransaction: func(tx *types.Transaction) error {
+			sender, err := types.Sender(types.NewCancunSigner(common.Big1), tx)
+			require.Nil(t, err)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			nonces[sender] = append(nonces[sender], tx.Nonce())
+			return nil
+		},
+	})
+
+	_, err := NewSenderPool(client, common.Big1, nil, 0)
+	require.NotNil(t, err)
+	_, err = NewSenderPool(client, common.Big1, []*ecdsa.PrivateKey{keys[0], keys[0]}, 0)
+	require.ErrorContains(t, err, "duplicate")
+
+	pool, err := NewSenderPool(client, common.Big1, keys, time.Minute)
+	require.Nil(t, err)
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	accounts := pool.Accounts()
+
+	sidecar, err := MakeSidecar([]byte("blob"))
+	require.Nil(t, err)
+	transact := func() (*types.Transaction, error) {
+		return pool.TransactBlobTx(&bind.TransactOpts{GasLimit: 100_000}, common.HexToAddress("0x02"), nil, sidecar)
+	}
+
+	// The load is spread evenly across the accounts, with gap-free nonces of each account.
+	for i := 0; i < 6; i++ {
+		_, err := transact()
+		require.Nil(t, err)
+	}
+	for _, account := range accounts {
+		require.Equal(t, []uint64{0, 1}, nonces[account])
+		require.Equal(t, uint64(2), pool.Pending(account))
+	}
+
+	// The account with the fewest pending transactions is picked.
+	pool.MarkMined(accounts[2])
+	tx, err := transact()
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), tx.Nonce())
+	require.Equal(t, []uint64{0, 1, 2}, nonces[accounts[2]])
+
+	// The accounts with stuck transactions are backed off.
+	pool.MarkStuck(accounts[0])
+	pool.MarkStuck(accounts[1])
+	for i := 0; i < 2; i++ {
+		_, err := transact()
+		require.Nil(t, err)
+	}
+	require.Equal(t, []uint64{0, 1, 2, 3, 4}, nonces[accounts[2]])
+
+	pool.MarkStuck(accounts[2])
+	_, err = transact()
+	require.ErrorIs(t, err, ErrNoAvailableSender)
+
+	// The backed off accounts are available again after the backoff.
+	now = now.Add(time.Minute)
+	tx, err = transact()
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), tx.Nonce())
+	require.Equal(t, []uint64{0, 1, 2}, nonces[accounts[0]])
+}
@@ -2,8 +2,11 @@ package rpc
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -11,13 +14,18 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
+var (
+	resubscribeBackoffMax   = backoff.DefaultMaxInterval
+	blockProposedBufferSize = 1024
+)
+
 // SubscribeEvent creates a event subscription, will retry if the established subscription failed.
 func SubscribeEvent(
 	eventName string,
 	handler func(ctx context.Context) (event.Subscription, error),
 ) event.Subscription {
 	return event.ResubscribeErr(
-		backoff.DefaultMaxInterval,
+		resubscribeBackoffMax,
 		func(ctx context.Context, err error) (event.Subscription, error) {
 			if err != nil {
 				log.Warn("Failed to subscribe protocol event, try resubscribing", "event", eventName, "error", err)
@@ -64,6 +72,162 @@ func SubscribeBlockProposed(
 	})
 }
 
+// BlockProposedSubscriptionOpts represents the options of SubscribeBlockProposedWithCatchUp.
+type BlockProposedSubscriptionOpts struct {
+	// StartHeight is the L1 block height to catch up the events from, nil means only the new events
+	// will be delivered until an event is seen.
+	StartHeight    *uint64
+	BlockID        []*big.Int
+	AssignedProver []common.Address
+}
+
+// SubscribeBlockProposedWithCatchUp subscribes the protocol's BlockProposed events, and resubscribes
+// automatically when the subscription fails. After each (re)subscription, the events since the last seen
+// L1 block will be queried, so that the events missed while disconnected will still be delivered, and the
+// duplicated ones will be skipped. The first subscription is established before returning, so that the
+// endpoints which don't support subscriptions will fail fast. The returned subscription will be
+// unsubscribed once the given context is done.
+func SubscribeBlockProposedWithCatchUp(
+	ctx context.Context,
+	taikoL1 *bindings.TaikoL1Client,
+	opts *BlockProposedSubscriptionOpts,
+) (event.Subscription, <-chan *bindings.TaikoL1ClientBlockProposed, error) {
+	return subscribeBlockProposedWithCatchUp(ctx, &blockProposedSource{
+		watch: func(ctx context.Context, sink chan<- *bindings.TaikoL1ClientBlockProposed) (event.Subscription, error) {
+			return taikoL1.WatchBlockProposed(&bind.WatchOpts{Context: ctx}, sink, opts.BlockID, opts.AssignedProver)
+		},
+		filter: func(ctx context.Context, from uint64) ([]*bindings.TaikoL1ClientBlockProposed, error) {
+			iter, err := taikoL1.FilterBlockProposed(
+				&bind.FilterOpts{Context: ctx, Start: from},
+				opts.BlockID,
+				opts.AssignedProver,
+			)
+			if err != nil {
+				return nil, err
+			}
+			defer iter.Close()
+
+			var events []*bindings.TaikoL1ClientBlockProposed
+			for iter.Next() {
+				events = append(events, iter.Event)
+			}
+			return events, iter.Error()
+		},
+	}, opts.StartHeight)
+}
+
+// blockProposedSource watches the new BlockProposed events, and filters the historical ones.
+type blockProposedSource struct {
+	watch  func(ctx context.Context, sink chan<- *bindings.TaikoL1ClientBlockProposed) (event.Subscription, error)
+	filter func(ctx context.Context, from uint64) ([]*bindings.TaikoL1ClientBlockProposed, error)
+}
+
+// logPosition represents the position of a log in the chain.
+type logPosition struct {
+	blockNumber uint64
+	index       uint
+}
+
+// before checks whether the position is before the given one.
+func (p logPosition) before(other logPosition) bool {
+	return p.blockNumber < other.blockNumber || (p.blockNumber == other.blockNumber && p.index < other.index)
+}
+
+// subscribeBlockProposedWithCatchUp subscribes the BlockProposed events from the given source, see
+// SubscribeBlockProposedWithCatchUp.
+func subscribeBlockProposedWithCatchUp(
+	ctx context.Context,
+	source *blockProposedSource,
+	startHeight *uint64,
+) (event.Subscription, <-chan *bindings.TaikoL1ClientBlockProposed, error) {
+	var (
+		ch      = make(chan *bindings.TaikoL1ClientBlockProposed, blockProposedBufferSize)
+		sink    = make(chan *bindings.TaikoL1ClientBlockProposed, blockProposedBufferSize)
+		next    logPosition
+		catchUp = startHeight != nil
+	)
+	if catchUp {
+		next.blockNumber = *startHeight
+	}
+
+	first, err := source.watch(ctx, sink)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// deliver delivers the given event if it hasn't been delivered yet, a removed event rewinds the
+	// position, so that the events re-included after the reorg will be delivered again.
+	deliver := func(subCtx context.Context, e *bindings.TaikoL1ClientBlockProposed) {
+		pos := logPosition{blockNumber: e.Raw.BlockNumber, index: e.Raw.Index}
+		if e.Raw.Removed {
+			if pos.before(next) {
+				next = pos
+			}
+		} else {
+			if catchUp && pos.before(next) {
+				return
+			}
+			next, catchUp = logPosition{blockNumber: pos.blockNumber, index: pos.index + 1}, true
+		}
+
+		select {
+		case ch <- e:
+		case <-subCtx.Done():
+		case <-ctx.Done():
+		}
+	}
+
+	sub := SubscribeEvent("BlockProposed", func(subCtx context.Context) (event.Subscription, error) {
+		// Reuse the first subscription established before returning.
+		sub := first
+		first = nil
+		if sub == nil {
+			var err error
+			if sub, err = source.watch(subCtx, sink); err != nil {
+				log.Error("Create TaikoL1.BlockProposed subscription error", "error", err)
+				return nil, err
+			}
+		}
+
+		defer sub.Unsubscribe()
+
+		// Catch up the events missed while disconnected.
+		if catchUp {
+			events, err := source.filter(subCtx, next.blockNumber)
+			if err != nil {
+				log.Error("Failed to catch up TaikoL1.BlockProposed events", "from", next.blockNumber, "error", err)
+				return sub, err
+			}
+			for _, e := range events {
+				deliver(subCtx, e)
+			}
+		}
+
+		for {
+			select {
+			case e := <-sink:
+				deliver(subCtx, e)
+			case err := <-sub.Err():
+				return sub, err
+			case <-subCtx.Done():
+				return sub, nil
+			case <-ctx.Done():
+				return sub, nil
+			}
+		}
+	})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-sub.Err():
+		}
+	}()
+
+	return sub, ch, nil
+}
+
 // SubscribeTransitionProved subscribes the protocol's TransitionProved events.
 func SubscribeTransitionProved(
 	taikoL1 *bindings.TaikoL1Client,
@@ -2,7 +2,10 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
@@ -10,6 +13,94 @@ import (
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
+func init() {
+	resubscribeBackoffMax = 10 * time.Millisecond
+}
+
+// testBlockProposedSource is a simulated BlockProposed events source, the events added to the chain
+// are only delivered by the live subscription when pushed.
+type testBlockProposedSource struct {
+	mutex   sync.Mutex
+	chain   []*bindings.TaikoL1ClientBlockProposed
+	live    chan *bindings.TaikoL1ClientBlockProposed
+	drop    chan error
+	watches int
+	filters []uint64
+}
+
+func newTestBlockProposedSource() *testBlockProposedSource {
+	return &testBlockProposedSource{
+		live: make(chan *bindings.TaikoL1ClientBlockProposed),
+		drop: make(chan error),
+	}
+}
+
+func (s *testBlockProposedSource) source() *blockProposedSource {
+	return &blockProposedSource{
+		watch: func(ctx context.Context, sink chan<- *bindings.TaikoL1ClientBlockProposed) (event.Subscription, error) {
+			s.mutex.Lock()
+			s.watches++
+			s.mutex.Unlock()
+
+			return event.NewSubscription(func(quit <-chan struct{}) error {
+				for {
+					select {
+					case e := <-s.live:
+						sink <- e
+					case err := <-s.drop:
+						return err
+					case <-quit:
+						return nil
+					case <-ctx.Done():
+						return nil
+					}
+				}
+			}), nil
+		},
+		filter: func(_ context.Context, from uint64) ([]*bindings.TaikoL1ClientBlockProposed, error) {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+
+			s.filters = append(s.filters, from)
+			var events []*bindings.TaikoL1ClientBlockProposed
+			for _, e := range s.chain {
+				if e.Raw.BlockNumber >= from {
+					events = append(events, e)
+				}
+			}
+			return events, nil
+		},
+	}
+}
+
+// mine adds a new event to the chain, and pushes it through the live subscription if required.
+func (s *testBlockProposedSource) mine(blockNumber uint64, index uint, push bool) *bindings.TaikoL1ClientBlockProposed {
+	e := &bindings.TaikoL1ClientBlockProposed{
+		Raw: types.Log{BlockNumber: blockNumber, Index: index},
+	}
+	s.mutex.Lock()
+	s.chain = append(s.chain, e)
+	s.mutex.Unlock()
+
+	if push {
+		s.live <- e
+	}
+	return e
+}
+
+func receiveBlockProposed(
+	t *testing.T,
+	ch <-chan *bindings.TaikoL1ClientBlockProposed,
+) *bindings.TaikoL1ClientBlockProposed {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for BlockProposed event")
+		return nil
+	}
+}
+
 func TestSubscribeEvent(t *testing.T) {
 	require.NotNil(t, SubscribeEvent("test", func(_ context.Context) (event.Subscription, error) {
 		return event.NewSubscription(func(_ <-chan struct{}) error { return nil }), nil
@@ -50,3 +141,73 @@ func TestSubscribeChainHead(t *testing.T) {
 		make(chan *types.Header, 1024)),
 	)
 }
+
+func TestSubscribeBlockProposedWithCatchUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := newTestBlockProposedSource()
+	sub, ch, err := subscribeBlockProposedWithCatchUp(ctx, src.source(), nil)
+	require.Nil(t, err)
+
+	e1 := src.mine(1, 0, true)
+	require.Equal(t, e1, receiveBlockProposed(t, ch))
+	e2 := src.mine(2, 0, true)
+	require.Equal(t, e2, receiveBlockProposed(t, ch))
+
+	// Events proposed while disconnected should be caught up after resubscribing.
+	e3 := src.mine(2, 1, false)
+	e4 := src.mine(3, 0, false)
+	src.drop <- errors.New("connection reset")
+	require.Equal(t, e3, receiveBlockProposed(t, ch))
+	require.Equal(t, e4, receiveBlockProposed(t, ch))
+
+	// The duplicated events should be skipped.
+	src.live <- e4
+	e5 := src.mine(4, 0, true)
+	require.Equal(t, e5, receiveBlockProposed(t, ch))
+
+	src.mutex.Lock()
+	require.Equal(t, 2, src.watches)
+	require.Equal(t, []uint64{2}, src.filters)
+	src.mutex.Unlock()
+
+	cancel()
+	select {
+	case <-sub.Err():
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed after the context is done")
+	}
+}
+
+func TestSubscribeBlockProposedWithCatchUpStartHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := newTestBlockProposedSource()
+	src.mine(1, 0, false)
+	e2 := src.mine(2, 0, false)
+
+	startHeight := uint64(2)
+	_, ch, err := subscribeBlockProposedWithCatchUp(ctx, src.source(), &startHeight)
+	require.Nil(t, err)
+	require.Equal(t, e2, receiveBlockProposed(t, ch))
+
+	// A removed event should rewind the position, so that the re-included event will be delivered.
+	removed := *e2
+	removed.Raw.Removed = true
+	src.live <- &removed
+	require.True(t, receiveBlockProposed(t, ch).Raw.Removed)
+	src.live <- e2
+	require.Equal(t, e2, receiveBlockProposed(t, ch))
+}
+
+func TestSubscribeBlockProposedWithCatchUpFailFast(t *testing.T) {
+	errNotSupported := errors.New("notifications not supported")
+	_, _, err := subscribeBlockProposedWithCatchUp(context.Background(), &blockProposedSource{
+		watch: func(context.Context, chan<- *bindings.TaikoL1ClientBlockProposed) (event.Subscription, error) {
+			return nil, errNotSupported
+		},
+	}, nil)
+	require.ErrorIs(t, err, errNotSupported)
+}
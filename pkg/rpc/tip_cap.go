@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+)
+
+// TipCapStrategy decides the gasTipCap of a dynamic fee transaction, when it is not explicitly set
+// in the transact options.
+type TipCapStrategy interface {
+	TipCap(ctx context.Context, c *EthClient) (*big.Int, error)
+}
+
+// SuggestedTipCap is a TipCapStrategy which uses the node's suggested gasTipCap, it is the default
+// strategy of EthClient.
+type SuggestedTipCap struct{}
+
+// TipCap implements the TipCapStrategy interface.
+func (s *SuggestedTipCap) TipCap(ctx context.Context, c *EthClient) (*big.Int, error) {
+	return c.suggestGasTipCapOrFallback(ctx)
+}
+
+// FixedTipCap is a TipCapStrategy which always uses the given gasTipCap.
+type FixedTipCap struct {
+	GasTipCap *big.Int
+}
+
+// TipCap implements the TipCapStrategy interface.
+func (s *FixedTipCap) TipCap(_ context.Context, _ *EthClient) (*big.Int, error) {
+	return new(big.Int).Set(s.GasTipCap), nil
+}
+
+// SuggestedPlusPremiumTipCap is a TipCapStrategy which adds a fixed premium on top of the node's
+// suggested gasTipCap, to get the transactions included faster during contention.
+type SuggestedPlusPremiumTipCap struct {
+	Premium *big.Int
+}
+
+// TipCap implements the TipCapStrategy interface.
+func (s *SuggestedPlusPremiumTipCap) TipCap(ctx context.Context, c *EthClient) (*big.Int, error) {
+	gasTipCap, err := c.suggestGasTipCapOrFallback(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Add(gasTipCap, s.Premium), nil
+}
+
+// suggestGasTipCapOrFallback returns the node's suggested gasTipCap, or FallbackGasTipCap if the node
+// doesn't support the `eth_maxPriorityFeePerGas` RPC method.
+func (c *EthClient) suggestGasTipCapOrFallback(ctx context.Context) (*big.Int, error) {
+	gasTipCap, err := c.SuggestGasTipCap(ctx)
+	if err != nil {
+		if !IsMaxPriorityFeePerGasNotFoundError(err) {
+			return nil, err
+		}
+		return FallbackGasTipCap, nil
+	}
+
+	return gasTipCap, nil
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTipCapStrategies(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		maxPriorityFeePerGas: func() (*big.Int, error) { return big.NewInt(100), nil },
+	})
+
+	for _, tc := range []struct {
+		name     string
+		strategy TipCapStrategy
+		expected *big.Int
+	}{
+		{"default", nil, big.NewInt(100)},
+		{"suggested", &SuggestedTipCap{}, big.NewInt(100)},
+		{"fixed", &FixedTipCap{GasTipCap: big.NewInt(42)}, big.NewInt(42)},
+		{"suggestedPlusPremium", &SuggestedPlusPremiumTipCap{Premium: big.NewInt(50)}, big.NewInt(150)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client.TipCapStrategy = tc.strategy
+
+			gasTipCap, gasFeeCap, err := client.estimateGasFeeCaps(
+				&bind.TransactOpts{Context: context.Background()},
+				&types.Header{BaseFee: big.NewInt(10)},
+			)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, gasTipCap)
+			require.Equal(t, new(big.Int).Add(tc.expected, big.NewInt(20)), gasFeeCap)
+		})
+	}
+
+	// An explicitly set gasTipCap should be respected.
+	client.TipCapStrategy = &FixedTipCap{GasTipCap: big.NewInt(42)}
+	gasTipCap, _, err := client.estimateGasFeeCaps(
+		&bind.TransactOpts{Context: context.Background(), GasTipCap: big.NewInt(7)},
+		&types.Header{BaseFee: big.NewInt(10)},
+	)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(7), gasTipCap)
+}
+
+func TestTipCapStrategiesFallback(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		maxPriorityFeePerGas: func() (*big.Int, error) { return nil, errMaxPriorityFeePerGasNotFound },
+	})
+
+	gasTipCap, err := (&SuggestedTipCap{}).TipCap(context.Background(), client)
+	require.Nil(t, err)
+	require.Equal(t, FallbackGasTipCap, gasTipCap)
+
+	gasTipCap, err = (&SuggestedPlusPremiumTipCap{Premium: big.NewInt(1)}).TipCap(context.Background(), client)
+	require.Nil(t, err)
+	require.Equal(t, new(big.Int).Add(FallbackGasTipCap, common.Big1), gasTipCap)
+
+	// Other errors should not fall back.
+	errUnavailable := errors.New("unavailable")
+	client = newTestEthClient(t, &testEthService{
+		maxPriorityFeePerGas: func() (*big.Int, error) { return nil, errUnavailable },
+	})
+	_, err = (&SuggestedTipCap{}).TipCap(context.Background(), client)
+	require.ErrorContains(t, err, errUnavailable.Error())
+}
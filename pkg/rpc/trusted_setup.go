@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// kzgContext is the KZG context initialized with the custom trusted setup loaded by LoadTrustedSetup,
+// nil means the default trusted setup embedded in kzg4844 will be used.
+var kzgContext atomic.Pointer[gokzg4844.Context]
+
+// LoadTrustedSetup loads the KZG trusted setup from the given JSON file, in the format of the `g1_lagrange`
+// and `g2_monomial` points used by the Ethereum KZG ceremony, and uses it for all the following blob commitment
+// and proof computations and verifications, instead of the default mainnet trusted setup. Note that the custom
+// trusted setup is always used with the Go KZG backend, regardless of kzg4844.UseCKZG.
+func LoadTrustedSetup(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the trusted setup file: %w", err)
+	}
+
+	setup := new(gokzg4844.JSONTrustedSetup)
+	if err := json.Unmarshal(content, setup); err != nil {
+		return fmt.Errorf("failed to decode the trusted setup: %w", err)
+	}
+	if err := gokzg4844.CheckTrustedSetupIsWellFormed(setup); err != nil {
+		return fmt.Errorf("malformed trusted setup: %w", err)
+	}
+
+	ctx, err := gokzg4844.NewContext4096(setup)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the KZG context: %w", err)
+	}
+	kzgContext.Store(ctx)
+
+	return nil
+}
+
+// blobToCommitment computes the KZG commitment of the given blob with the loaded trusted setup.
+func blobToCommitment(blob kzg4844.Blob) (kzg4844.Commitment, error) {
+	ctx := kzgContext.Load()
+	if ctx == nil {
+		return kzg4844.BlobToCommitment(blob)
+	}
+
+	commitment, err := ctx.BlobToKZGCommitment(gokzg4844.Blob(blob), 0)
+	if err != nil {
+		return kzg4844.Commitment{}, err
+	}
+	return kzg4844.Commitment(commitment), nil
+}
+
+// computeBlobProof computes the KZG proof of the given blob against its commitment with the loaded
+// trusted setup.
+func computeBlobProof(blob kzg4844.Blob, commitment kzg4844.Commitment) (kzg4844.Proof, error) {
+	ctx := kzgContext.Load()
+	if ctx == nil {
+		return kzg4844.ComputeBlobProof(blob, commitment)
+	}
+
+	proof, err := ctx.ComputeBlobKZGProof(gokzg4844.Blob(blob), gokzg4844.KZGCommitment(commitment), 0)
+	if err != nil {
+		return kzg4844.Proof{}, err
+	}
+	return kzg4844.Proof(proof), nil
+}
+
+// verifyBlobProof verifies the KZG proof of the given blob against its commitment with the loaded
+// trusted setup.
+func verifyBlobProof(blob kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
+	ctx := kzgContext.Load()
+	if ctx == nil {
+		return kzg4844.VerifyBlobProof(blob, commitment, proof)
+	}
+
+	return ctx.VerifyBlobKZGProof(
+		gokzg4844.Blob(blob),
+		gokzg4844.KZGCommitment(commitment),
+		gokzg4844.KZGProof(proof),
+	)
+}
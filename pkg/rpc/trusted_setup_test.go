@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+// writeInsecureTrustedSetup generates a trusted setup with the given secret, and writes it to a JSON file.
+func writeInsecureTrustedSetup(t *testing.T, secret uint64) string {
+	// The generator of the multiplicative subgroup of order 2^32, the same one go-kzg-4844 uses.
+	var root fr.Element
+	_, err := root.SetString("10238227357739495823651030575849232062558860180284477541189508159991286009131")
+	require.Nil(t, err)
+	var omega fr.Element
+	omega.Exp(root, big.NewInt(1<<(32-12)))
+
+	var s, sN, n fr.Element
+	s.SetUint64(secret)
+	sN.Exp(s, big.NewInt(gokzg4844.ScalarsPerBlob))
+	sN.Sub(&sN, new(fr.Element).SetOne())
+	n.SetUint64(gokzg4844.ScalarsPerBlob)
+
+	// L_i(s) = ω^i * (s^n - 1) / (n * (s - ω^i))
+	_, _, g1, g2 := bls12381.Generators()
+	setup := new(gokzg4844.JSONTrustedSetup)
+	omegaI := new(fr.Element).SetOne()
+	for i := range setup.SetupG1Lagrange {
+		var denominator, lagrange fr.Element
+		denominator.Sub(&s, omegaI)
+		denominator.Mul(&denominator, &n)
+		lagrange.Div(&sN, &denominator)
+		lagrange.Mul(&lagrange, omegaI)
+
+		var point bls12381.G1Affine
+		point.ScalarMultiplication(&g1, lagrange.BigInt(new(big.Int)))
+		bytes := point.Bytes()
+		setup.SetupG1Lagrange[i] = hexutil.Encode(bytes[:])
+
+		omegaI.Mul(omegaI, &omega)
+	}
+
+	var alphaG2 bls12381.G2Affine
+	alphaG2.ScalarMultiplication(&g2, new(big.Int).SetUint64(secret))
+	for _, point := range []bls12381.G2Affine{g2, alphaG2} {
+		bytes := point.Bytes()
+		setup.SetupG2 = append(setup.SetupG2, hexutil.Encode(bytes[:]))
+	}
+
+	content, err := json.Marshal(setup)
+	require.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "trusted_setup.json")
+	require.Nil(t, os.WriteFile(path, content, 0600))
+
+	return path
+}
+
+func TestLoadTrustedSetup(t *testing.T) {
+	data := []byte("taiko-client custom trusted setup")
+	defaultSidecar, err := MakeSidecar(data)
+	require.Nil(t, err)
+
+	require.Nil(t, LoadTrustedSetup(writeInsecureTrustedSetup(t, 1337)))
+	defer kzgContext.Store(nil)
+
+	sidecar, err := MakeSidecar(data)
+	require.Nil(t, err)
+	require.Nil(t, VerifySidecar(sidecar))
+	require.NotEqual(t, defaultSidecar.Commitments[0], sidecar.Commitments[0])
+	require.NotNil(t, VerifySidecar(defaultSidecar))
+
+	// The commitment of a constant polynomial is independent of the secret.
+	var blob kzg4844.Blob
+	for i := 0; i < len(blob); i += 32 {
+		blob[i+31] = 7
+	}
+	commitment, err := blobToCommitment(blob)
+	require.Nil(t, err)
+	_, _, g1, _ := bls12381.Generators()
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&g1, big.NewInt(7))
+	require.Equal(t, expected.Bytes(), [48]byte(commitment))
+
+	// Back to the default trusted setup.
+	kzgContext.Store(nil)
+	require.Nil(t, VerifySidecar(defaultSidecar))
+	require.NotNil(t, VerifySidecar(sidecar))
+}
+
+func TestLoadTrustedSetupInvalid(t *testing.T) {
+	require.NotNil(t, LoadTrustedSetup(filepath.Join(t.TempDir(), "missing.json")))
+
+	path := filepath.Join(t.TempDir(), "trusted_setup.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"g1_lagrange": ["0x00"], "g2_monomial": []}`), 0600))
+	require.NotNil(t, LoadTrustedSetup(path))
+	require.Nil(t, kzgContext.Load())
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrTxTooLarge is returned by SplitTxList when a single transaction alone exceeds the size limit, so it
+// can't be proposed in any sub-list.
+var ErrTxTooLarge = errors.New("transaction exceeds the tx list size limit")
+
+// SplitTxList splits the given RLP-encoded transactions list at the transaction boundaries into the
+// RLP-encoded sub-lists, whose sizes don't exceed maxPerBlob bytes. The transactions are packed greedily in
+// their original order, so that each sub-list is a valid transactions list on its own. The size limit is
+// checked against the uncompressed sub-lists, which is conservative for the compressed payloads.
+func SplitTxList(txListBytes []byte, maxPerBlob int) ([][]byte, error) {
+	if maxPerBlob <= 0 {
+		return nil, fmt.Errorf("invalid tx list size limit: %d", maxPerBlob)
+	}
+
+	var txs types.Transactions
+	if err := rlp.DecodeBytes(txListBytes, &txs); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions list: %w", err)
+	}
+
+	var (
+		chunks    [][]byte
+		chunk     types.Transactions
+		chunkSize uint64
+		limit     = uint64(maxPerBlob)
+	)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		b, err := rlp.EncodeToBytes(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to encode transactions sub-list: %w", err)
+		}
+		chunks = append(chunks, b)
+		chunk, chunkSize = nil, 0
+		return nil
+	}
+
+	for _, tx := range txs {
+		b, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transaction %s: %w", tx.Hash(), err)
+		}
+		size := uint64(len(b))
+		if rlp.ListSize(size) > limit {
+			return nil, fmt.Errorf("%w: %s has %d bytes, limit %d", ErrTxTooLarge, tx.Hash(), size, limit)
+		}
+
+		if rlp.ListSize(chunkSize+size) > limit {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		chunk = append(chunk, tx)
+		chunkSize += size
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
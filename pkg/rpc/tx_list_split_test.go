@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTxWithData creates a signed transaction with the given size of calldata.
+func newTestTxWithData(t *testing.T, nonce uint64, size int) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(common.Big1), &types.DynamicFeeTx{
+		ChainID:   common.Big1,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       1_000_000,
+		To:        &common.Address{},
+		Value:     common.Big0,
+		Data:      make([]byte, size),
+	})
+	require.Nil(t, err)
+
+	return tx
+}
+
+func TestSplitTxList(t *testing.T) {
+	var txs types.Transactions
+	for i, size := range []int{100, 5000, 200, 3000, 50, 2500, 0, 5800} {
+		txs = append(txs, newTestTxWithData(t, uint64(i), size))
+	}
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+
+	chunks, err := SplitTxList(txListBytes, 6000)
+	require.Nil(t, err)
+	// [100, 5000, 200], [3000, 50, 2500, 0], [5800]
+	require.Len(t, chunks, 3)
+
+	var split types.Transactions
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, len(chunk), 6000)
+
+		var sub types.Transactions
+		require.Nil(t, rlp.DecodeBytes(chunk, &sub))
+		require.NotEmpty(t, sub)
+		split = append(split, sub...)
+	}
+	require.Equal(t, len(txs), len(split))
+	for i := range txs {
+		require.Equal(t, txs[i].Hash(), split[i].Hash())
+	}
+
+	// The whole list fits.
+	chunks, err = SplitTxList(txListBytes, len(txListBytes))
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{txListBytes}, chunks)
+}
+
+func TestSplitTxListEmpty(t *testing.T) {
+	txListBytes, err := rlp.EncodeToBytes(types.Transactions{})
+	require.Nil(t, err)
+
+	chunks, err := SplitTxList(txListBytes, 6000)
+	require.Nil(t, err)
+	require.Empty(t, chunks)
+}
+
+func TestSplitTxListTxTooLarge(t *testing.T) {
+	txListBytes, err := rlp.EncodeToBytes(types.Transactions{
+		newTestTxWithData(t, 0, 100),
+		newTestTxWithData(t, 1, 7000),
+	})
+	require.Nil(t, err)
+
+	_, err = SplitTxList(txListBytes, 6000)
+	require.ErrorIs(t, err, ErrTxTooLarge)
+
+	_, err = SplitTxList(txListBytes, 0)
+	require.NotNil(t, err)
+	_, err = SplitTxList([]byte{0x01}, 6000)
+	require.NotNil(t, err)
+}
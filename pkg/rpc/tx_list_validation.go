@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrInvalidTxListRLP is returned by ValidateTxListRLP when the given bytes are not a valid RLP-encoded
+// transactions list.
+var ErrInvalidTxListRLP = errors.New("invalid tx list RLP")
+
+// ValidateTxListRLP checks whether the given bytes can be decoded as an RLP list of transactions, so that
+// a malformed tx list won't waste a proposal and its bond. The returned error wraps ErrInvalidTxListRLP,
+// and describes which part of the input is malformed.
+func ValidateTxListRLP(data []byte) error {
+	return ValidateTxListRLPWithMaxSize(data, 0)
+}
+
+// ValidateTxListRLPWithMaxSize is like ValidateTxListRLP, and also checks the size of the given bytes
+// against the given max size, e.g. BlockMaxTxListBytes, zero means no limit.
+func ValidateTxListRLPWithMaxSize(data []byte, maxSize uint64) error {
+	if maxSize != 0 && uint64(len(data)) > maxSize {
+		return fmt.Errorf("%w: size %d exceeds max %d", ErrInvalidTxListRLP, len(data), maxSize)
+	}
+
+	kind, content, rest, err := rlp.Split(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTxListRLP, err)
+	}
+	if kind != rlp.List {
+		return fmt.Errorf("%w: not an RLP list", ErrInvalidTxListRLP)
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes after the list", ErrInvalidTxListRLP, len(rest))
+	}
+
+	for i := 0; len(content) != 0; i++ {
+		_, _, next, err := rlp.Split(content)
+		if err != nil {
+			return fmt.Errorf("%w: tx %d: %v", ErrInvalidTxListRLP, i, err)
+		}
+
+		var tx types.Transaction
+		if err := rlp.DecodeBytes(content[:len(content)-len(next)], &tx); err != nil {
+			return fmt.Errorf("%w: tx %d: %v", ErrInvalidTxListRLP, i, err)
+		}
+		content = next
+	}
+
+	return nil
+}
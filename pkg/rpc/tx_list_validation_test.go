@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTxListRLP(t *testing.T) {
+	var txs types.Transactions
+	for i, size := range []int{0, 100, 1000} {
+		txs = append(txs, newTestTxWithData(t, uint64(i), size))
+	}
+	txListBytes, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+	emptyTxListBytes, err := rlp.EncodeToBytes(types.Transactions{})
+	require.Nil(t, err)
+
+	// Valid lists.
+	require.Nil(t, ValidateTxListRLP(txListBytes))
+	require.Nil(t, ValidateTxListRLP(emptyTxListBytes))
+	require.Nil(t, ValidateTxListRLPWithMaxSize(txListBytes, uint64(len(txListBytes))))
+
+	// Truncated data.
+	for _, data := range [][]byte{nil, txListBytes[:1], txListBytes[:len(txListBytes)-1]} {
+		require.ErrorIs(t, ValidateTxListRLP(data), ErrInvalidTxListRLP)
+	}
+
+	// Oversized list.
+	err = ValidateTxListRLPWithMaxSize(txListBytes, uint64(len(txListBytes)-1))
+	require.ErrorIs(t, err, ErrInvalidTxListRLP)
+	require.ErrorContains(t, err, "exceeds max")
+
+	// Not a list.
+	notList, err := rlp.EncodeToBytes([]byte("txs"))
+	require.Nil(t, err)
+	require.ErrorContains(t, ValidateTxListRLP(notList), "not an RLP list")
+
+	// Trailing bytes.
+	require.ErrorContains(t, ValidateTxListRLP(append(txListBytes, 0x01)), "1 trailing bytes")
+
+	// A malformed transaction in the list.
+	malformed, err := rlp.EncodeToBytes([]interface{}{txs[0], []byte{0x02, 0x03}})
+	require.Nil(t, err)
+	err = ValidateTxListRLP(malformed)
+	require.ErrorIs(t, err, ErrInvalidTxListRLP)
+	require.ErrorContains(t, err, "tx 1")
+}
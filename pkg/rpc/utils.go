@@ -97,19 +97,19 @@ func CheckProverBalance(
 }
 
 // WaitReceipt keeps waiting until the given transaction has an execution
-// receipt to know whether it was reverted or not.
+// receipt to know whether it was reverted or not, the client's ReceiptPollInterval
+// and ReceiptWaitTimeout are respected, the timeout defaults to 1 minute.
 func WaitReceipt(
 	ctx context.Context,
 	client *EthClient,
 	tx *types.Transaction,
 ) (*types.Receipt, error) {
-	ticker := time.NewTicker(waitReceiptPollingInterval)
-	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, defaultWaitReceiptTimeout)
-
-	defer func() {
-		cancel()
-		ticker.Stop()
-	}()
+	timeout := defaultWaitReceiptTimeout
+	if client.ReceiptWaitTimeout != 0 {
+		timeout = client.ReceiptWaitTimeout
+	}
+	ctxWithTimeout, cancel := ctxWithTimeoutOrDefault(ctx, timeout)
+	defer cancel()
 
 	// If we are running tests, we don't need to wait for `waitL1OriginPollingInterval` seconds
 	// at first, just start fetching the receipt immediately.
@@ -117,25 +117,21 @@ func WaitReceipt(
 		<-time.After(waitL1OriginPollingInterval)
 	}
 
-	for ; true; <-ticker.C {
-		if ctxWithTimeout.Err() != nil {
-			return nil, ctxWithTimeout.Err()
-		}
-
-		receipt, err := client.TransactionReceipt(ctxWithTimeout, tx.Hash())
-		if err != nil {
+	var receipt *types.Receipt
+	if err := client.waitReceipt(ctxWithTimeout, func() (err error) {
+		if receipt, err = client.TransactionReceipt(ctxWithTimeout, tx.Hash()); err != nil {
 			log.Debug("Failed to fetch transaction receipt", "hash", tx.Hash(), "error", err)
-			continue
-		}
-
-		if receipt.Status != types.ReceiptStatusSuccessful {
-			return nil, fmt.Errorf("transaction reverted, hash: %s", tx.Hash())
 		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
 
-		return receipt, nil
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction reverted, hash: %s", tx.Hash())
 	}
 
-	return nil, fmt.Errorf("failed to find the receipt for transaction %s", tx.Hash())
+	return receipt, nil
 }
 
 // BlockProofStatus represents the proving status of the given L2 block.
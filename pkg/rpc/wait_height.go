@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/internal/utils"
+)
+
+var (
+	defaultHeightStallTimeout = time.Minute
+	// ErrHeightStalled is returned by WaitForL2Height when the node's block height makes no progress
+	// within the stall timeout.
+	ErrHeightStalled = errors.New("block height stalled")
+)
+
+// WaitForL2Height keeps polling the block number of the connected node every pollInterval, until it
+// reaches the given target height or the context is done. It returns immediately if the node is
+// already at or above the target, and returns an error wrapping ErrHeightStalled if the height has
+// not advanced for HeightStallTimeout.
+func (c *EthClient) WaitForL2Height(ctx context.Context, target uint64, pollInterval time.Duration) error {
+	if utils.IsNil(ctx) {
+		ctx = context.Background()
+	}
+
+	stallTimeout := defaultHeightStallTimeout
+	if c.HeightStallTimeout != 0 {
+		stallTimeout = c.HeightStallTimeout
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		lastHeight   uint64
+		lastProgress = time.Now()
+	)
+	for {
+		height, err := c.BlockNumber(ctx)
+		if err != nil {
+			// Transient RPC errors are tolerated, the stall timeout still applies.
+			log.Debug("Failed to fetch the block number, keep waiting", "target", target, "error", err)
+		} else {
+			if height >= target {
+				return nil
+			}
+			if height > lastHeight {
+				lastHeight, lastProgress = height, time.Now()
+			}
+			log.Debug("Waiting for the block height", "height", height, "target", target)
+		}
+
+		if time.Since(lastProgress) >= stallTimeout {
+			return fmt.Errorf(
+				"%w: height %d has not advanced for %s, target %d",
+				ErrHeightStalled,
+				lastHeight,
+				stallTimeout,
+				target,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
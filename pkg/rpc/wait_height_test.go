@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForL2Height(t *testing.T) {
+	var height atomic.Uint64
+	client := newTestEthClient(t, &testEthService{
+		blockNumber: func() (uint64, error) { return height.Load(), nil },
+	})
+
+	// The node's height advances over time.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				height.Add(1)
+			}
+		}
+	}()
+
+	require.Nil(t, client.WaitForL2Height(context.Background(), 10, time.Millisecond))
+	require.GreaterOrEqual(t, height.Load(), uint64(10))
+}
+
+func TestWaitForL2HeightAlreadyReached(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestEthClient(t, &testEthService{
+		blockNumber: func() (uint64, error) {
+			calls.Add(1)
+			return 10, nil
+		},
+	})
+
+	require.Nil(t, client.WaitForL2Height(context.Background(), 5, time.Hour))
+	require.Nil(t, client.WaitForL2Height(context.Background(), 10, time.Hour))
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestWaitForL2HeightStalled(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		blockNumber: func() (uint64, error) { return 3, nil },
+	})
+	client.HeightStallTimeout = 50 * time.Millisecond
+
+	require.ErrorIs(t, client.WaitForL2Height(context.Background(), 10, 5*time.Millisecond), ErrHeightStalled)
+}
+
+func TestWaitForL2HeightContextErr(t *testing.T) {
+	client := newTestEthClient(t, &testEthService{
+		blockNumber: func() (uint64, error) { return 3, nil },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, client.WaitForL2Height(ctx, 10, 5*time.Millisecond), context.DeadlineExceeded)
+}
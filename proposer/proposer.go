@@ -126,6 +126,7 @@ func (p *Proposer) InitFromConfig(ctx context.Context, cfg *Config) (err error)
 		cfg.MaxTierFeePriceBumps,
 		proverAssignmentTimeout,
 		requestProverServerTimeout,
+		cfg.L1ProposerPrivKey,
 	); err != nil {
 		return err
 	}
@@ -298,6 +299,11 @@ func (p *Proposer) ProposeTxList(
 	txListBytes []byte,
 	txNum uint,
 ) error {
+	// Make sure the tx list is well-formed before paying for the proposal.
+	if err := rpc.ValidateTxListRLP(txListBytes); err != nil {
+		return err
+	}
+
 	compressedTxListBytes, err := utils.Compress(txListBytes)
 	if err != nil {
 		return err
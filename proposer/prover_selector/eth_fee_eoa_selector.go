@@ -2,6 +2,9 @@ package selector
 
 import (
 	"context"
+	"crypto/ecdsa"
+	cryptorand "crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -40,6 +43,7 @@ type ETHFeeEOASelector struct {
 	maxTierFeePriceBumpIterations uint64
 	proposalExpiry                time.Duration
 	requestTimeout                time.Duration
+	proposerPrivateKey            *ecdsa.PrivateKey
 }
 
 // NewETHFeeEOASelector creates a new ETHFeeEOASelector instance.
@@ -54,6 +58,7 @@ func NewETHFeeEOASelector(
 	maxTierFeePriceBumpIterations uint64,
 	proposalExpiry time.Duration,
 	requestTimeout time.Duration,
+	proposerPrivateKey *ecdsa.PrivateKey,
 ) (*ETHFeeEOASelector, error) {
 	if len(proverEndpoints) == 0 {
 		return nil, errEmptyProverEndpoints
@@ -76,6 +81,7 @@ func NewETHFeeEOASelector(
 		maxTierFeePriceBumpIterations,
 		proposalExpiry,
 		requestTimeout,
+		proposerPrivateKey,
 	}, nil
 }
 
@@ -123,6 +129,7 @@ func (s *ETHFeeEOASelector) AssignProver(
 				s.assignmentHookAddress,
 				txListHash,
 				s.requestTimeout,
+				s.proposerPrivateKey,
 			)
 			if err != nil {
 				log.Warn("Failed to assign prover", "endpoint", endpoint, "error", err)
@@ -174,6 +181,7 @@ func assignProver(
 	assignmentHookAddress common.Address,
 	txListHash common.Hash,
 	timeout time.Duration,
+	proposerPrivateKey *ecdsa.PrivateKey,
 ) (*encoding.ProverAssignment, common.Address, error) {
 	log.Info(
 		"Attempting to assign prover",
@@ -182,6 +190,12 @@ func assignProver(
 		"txListHash", txListHash,
 	)
 
+	// A random nonce, so that the prover server can reject the replays of this request.
+	nonce := make([]byte, 16)
+	if _, err := cryptorand.Read(nonce); err != nil {
+		return nil, common.Address{}, err
+	}
+
 	// Send the HTTP request
 	var (
 		client  = resty.New()
@@ -190,6 +204,8 @@ func assignProver(
 			TierFees:   tierFees,
 			Expiry:     expiry,
 			TxListHash: txListHash,
+			Nonce:      common.Bytes2Hex(nonce),
+			Timestamp:  uint64(time.Now().Unix()),
 		}
 		result = server.ProposeBlockResponse{}
 	)
@@ -198,16 +214,32 @@ func assignProver(
 		return nil, common.Address{}, err
 	}
 
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
 	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	resp, err := client.R().
+	req := client.R().
 		SetContext(ctxTimeout).
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Accept", "application/json").
-		SetBody(reqBody).
-		SetResult(&result).
-		Post(requestURL)
+		SetHeader(server.IdempotencyKeyHeader, fmt.Sprintf("%s-%d", txListHash.Hex(), expiry)).
+		SetBody(body).
+		SetResult(&result)
+
+	// Sign the request body, so that the prover server can check the proposer against its allowlist.
+	if proposerPrivateKey != nil {
+		signature, err := server.SignRequest(body, proposerPrivateKey)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		req.SetHeader(server.SignatureHeader, signature)
+	}
+
+	resp, err := req.Post(requestURL)
 	if err != nil {
 		return nil, common.Address{}, err
 	}
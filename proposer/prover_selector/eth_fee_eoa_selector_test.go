@@ -42,6 +42,7 @@ func (s *ProverSelectorTestSuite) SetupTest() {
 		32,
 		1*time.Minute,
 		1*time.Minute,
+		nil,
 	)
 	s.Nil(err)
 }
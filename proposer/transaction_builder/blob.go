@@ -93,24 +93,18 @@ func (b *BlobTransactionBuilder) Build(
 		return nil, err
 	}
 
-	// ABI encode the TaikoL1.proposeBlock parameters.
-	encodedParams, err := encoding.EncodeBlockParams(&encoding.BlockParams{
+	// ABI encode the TaikoL1.proposeBlock call, the txList is sent in the blob.
+	data, err := rpc.EncodeProposeBlockInput(&encoding.BlockParams{
 		AssignedProver: assignedProver,
 		ExtraData:      rpc.StringToBytes32(b.extraData),
 		Coinbase:       b.l2SuggestedFeeRecipient,
 		ParentMetaHash: parentMetaHash,
 		HookCalls:      []encoding.HookCall{{Hook: b.assignmentHookAddress, Data: hookInputData}},
-	})
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Send the transaction to the L1 node.
-	data, err := encoding.TaikoL1ABI.Pack("proposeBlock", encodedParams, []byte{})
-	if err != nil {
-		return nil, encoding.TryParsingCustomError(err)
-	}
-
 	return &txmgr.TxCandidate{
 		TxData:   data,
 		Blobs:    []*eth.Blob{blob},
@@ -83,24 +83,18 @@ func (b *CalldataTransactionBuilder) Build(
 		return nil, err
 	}
 
-	// ABI encode the TaikoL1.proposeBlock parameters.
-	encodedParams, err := encoding.EncodeBlockParams(&encoding.BlockParams{
+	// ABI encode the TaikoL1.proposeBlock call.
+	data, err := rpc.EncodeProposeBlockInput(&encoding.BlockParams{
 		AssignedProver: assignedProver,
 		Coinbase:       b.l2SuggestedFeeRecipient,
 		ExtraData:      rpc.StringToBytes32(b.extraData),
 		ParentMetaHash: parentMetaHash,
 		HookCalls:      []encoding.HookCall{{Hook: b.assignmentHookAddress, Data: hookInputData}},
-	})
+	}, txListBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	// Send the transaction to the L1 node.
-	data, err := encoding.TaikoL1ABI.Pack("proposeBlock", encodedParams, txListBytes)
-	if err != nil {
-		return nil, encoding.TryParsingCustomError(err)
-	}
-
 	return &txmgr.TxCandidate{
 		TxData:   data,
 		Blobs:    nil,
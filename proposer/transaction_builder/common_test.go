@@ -38,6 +38,7 @@ func (s *TransactionBuilderTestSuite) SetupTest() {
 		32,
 		1*time.Minute,
 		1*time.Minute,
+		nil,
 	)
 	s.Nil(err)
 	s.calldataTxBuilder = NewCalldataTransactionBuilder(
@@ -0,0 +1,304 @@
+package capacitymanager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// defaultReapInterval is the default interval for reaping the expired capacity reservations.
+	defaultReapInterval = 12 * time.Second
+	// healthProbeInterval is the interval of re-consulting the health probe when waiting for capacity.
+	healthProbeInterval = time.Second
+)
+
+// Reservation represents an active capacity reservation.
+type Reservation struct {
+	ID         uint64
+	ReservedAt time.Time
+	// ExpiresAt is the time after which the reservation will be released automatically.
+	ExpiresAt time.Time
+	// Deadline is the proving deadline set by SetDeadline, zero if not set.
+	Deadline time.Time
+	// Metadata is the data attached to the reservation by SetMetadata, nil if not set.
+	Metadata any
+}
+
+// CapacityManager manages the prover capacity concurrent-safely, each reserved capacity slot
+// will be released automatically after the configured TTL, so that a prover which never
+// completes its work won't deadlock the capacity pool.
+type CapacityManager struct {
+	maxCapacity  uint64
+	ttl          time.Duration
+	reserved     map[uint64]time.Time
+	keys         map[string]uint64
+	reservedKeys map[uint64]string
+	metadata     map[uint64]any
+	deadlines    map[uint64]time.Time
+	// onDeadlineMissed is called when a reservation is released for missing its proving deadline.
+	onDeadlineMissed func(id uint64)
+	// healthProbe reports whether the proving backend is healthy, and the factor to scale the capacity by.
+	healthProbe func() (healthy bool, capacityFactor float64)
+	// released is closed and replaced whenever a slot is released, to wake up the WaitCapacity callers.
+	released     chan struct{}
+	nextID       uint64
+	reapInterval time.Duration
+	clock        func() time.Time
+	mutex        sync.Mutex
+}
+
+// New creates a new CapacityManager instance.
+func New(maxCapacity uint64, ttl time.Duration) *CapacityManager {
+	return &CapacityManager{
+		maxCapacity:  maxCapacity,
+		ttl:          ttl,
+		reserved:     make(map[uint64]time.Time),
+		keys:         make(map[string]uint64),
+		reservedKeys: make(map[uint64]string),
+		metadata:     make(map[uint64]any),
+		deadlines:    make(map[uint64]time.Time),
+		released:     make(chan struct{}),
+		reapInterval: defaultReapInterval,
+		clock:        time.Now,
+	}
+}
+
+// Start starts the background reaper, which releases the expired capacity reservations
+// periodically, until the given context is done.
+func (m *CapacityManager) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.reapInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.mutex.Lock()
+				m.reap()
+				m.mutex.Unlock()
+			}
+		}
+	}()
+}
+
+// ReadCapacity returns the max capacity and the currently used capacity, the max capacity is scaled by
+// the health probe if it is set, so the used capacity might exceed it.
+func (m *CapacityManager) ReadCapacity() (uint64, uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reap()
+
+	return m.capacity(), uint64(len(m.reserved))
+}
+
+// TakeOneCapacity reserves one capacity slot, and returns the reservation ID, the second
+// returned value will be false if there is no available capacity.
+func (m *CapacityManager) TakeOneCapacity() (uint64, bool) {
+	id, _, ok := m.TakeOneCapacityWithKey("")
+	return id, ok
+}
+
+// TakeOneCapacityWithKey reserves one capacity slot like TakeOneCapacity, but if there is still
+// a reservation made with the same non-empty idempotency key, the existing reservation ID will be
+// returned instead of consuming another slot, and isNew will be false. The key mapping shares
+// the TTL of the slot.
+func (m *CapacityManager) TakeOneCapacityWithKey(key string) (id uint64, isNew bool, ok bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reap()
+
+	if id, ok := m.keys[key]; ok && key != "" {
+		log.Debug("Capacity already reserved", "id", id, "key", key)
+		return id, false, true
+	}
+
+	if maxCapacity := m.capacity(); uint64(len(m.reserved)) >= maxCapacity {
+		log.Warn("Could not take one capacity", "maxCapacity", maxCapacity, "used", len(m.reserved))
+		return 0, false, false
+	}
+
+	m.nextID++
+	m.reserved[m.nextID] = m.clock()
+	if key != "" {
+		m.keys[key] = m.nextID
+		m.reservedKeys[m.nextID] = key
+	}
+
+	log.Debug("Took one capacity", "id", m.nextID, "key", key, "used", len(m.reserved), "maxCapacity", m.maxCapacity)
+
+	return m.nextID, true, true
+}
+
+// ReleaseOneCapacity releases the capacity slot with the given reservation ID, releasing
+// a slot which has already been released or reaped is a no-op, and will return false.
+func (m *CapacityManager) ReleaseOneCapacity(id uint64) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.reserved[id]; !ok {
+		return false
+	}
+	m.remove(id)
+
+	log.Debug("Released one capacity", "id", id, "used", len(m.reserved), "maxCapacity", m.maxCapacity)
+
+	return true
+}
+
+// WaitCapacity blocks until there is at least one available capacity slot, or the given context is done,
+// and returns the number of the available slots.
+func (m *CapacityManager) WaitCapacity(ctx context.Context) (uint64, error) {
+	for {
+		m.mutex.Lock()
+		m.reap()
+		maxCapacity := m.capacity()
+		available, released := maxCapacity-min(uint64(len(m.reserved)), maxCapacity), m.released
+		// The health probe can't notify the recovery, so it is re-consulted periodically.
+		var recheck <-chan time.Time
+		if m.healthProbe != nil {
+			recheck = time.After(healthProbeInterval)
+		}
+		m.mutex.Unlock()
+
+		if available > 0 {
+			return available, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-released:
+		case <-recheck:
+		}
+	}
+}
+
+// SetMetadata attaches the given metadata to the active reservation with the given ID, and returns
+// false if there is no such reservation.
+func (m *CapacityManager) SetMetadata(id uint64, metadata any) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.reserved[id]; !ok {
+		return false
+	}
+	m.metadata[id] = metadata
+
+	return true
+}
+
+// SetDeadline sets the proving deadline of the active reservation with the given ID, the reservation will
+// then be released once the deadline has passed, even if its TTL hasn't. Returns false if there is no
+// such reservation.
+func (m *CapacityManager) SetDeadline(id uint64, deadline time.Time) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.reserved[id]; !ok {
+		return false
+	}
+	m.deadlines[id] = deadline
+
+	return true
+}
+
+// OnDeadlineMissed sets the callback which will be called with the reservation ID, when a reservation is
+// released for missing its proving deadline. The callback is called with the mutex held, so it must not
+// block or call back into the CapacityManager.
+func (m *CapacityManager) OnDeadlineMissed(fn func(id uint64)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.onDeadlineMissed = fn
+}
+
+// SetHealthProbe sets the probe of the proving backend health, which is consulted whenever the capacity
+// is read or taken. If the probe reports unhealthy, no capacity is available, otherwise the max capacity
+// is scaled down by the reported capacity factor, e.g. 0.5 halves it, the factor is clamped to [0, 1].
+// The probe is called with the mutex held, so it must not block or call back into the CapacityManager.
+func (m *CapacityManager) SetHealthProbe(probe func() (healthy bool, capacityFactor float64)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.healthProbe = probe
+}
+
+// capacity returns the max capacity scaled by the health probe, the caller must hold the mutex.
+func (m *CapacityManager) capacity() uint64 {
+	if m.healthProbe == nil {
+		return m.maxCapacity
+	}
+
+	healthy, factor := m.healthProbe()
+	if !healthy {
+		return 0
+	}
+
+	return uint64(float64(m.maxCapacity) * max(0, min(factor, 1)))
+}
+
+// Reservations returns all the active reservations, in the order of their reservation IDs.
+func (m *CapacityManager) Reservations() []*Reservation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.reap()
+
+	reservations := make([]*Reservation, 0, len(m.reserved))
+	for id, reservedAt := range m.reserved {
+		reservations = append(reservations, &Reservation{
+			ID:         id,
+			ReservedAt: reservedAt,
+			ExpiresAt:  reservedAt.Add(m.ttl),
+			Deadline:   m.deadlines[id],
+			Metadata:   m.metadata[id],
+		})
+	}
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].ID < reservations[j].ID })
+
+	return reservations
+}
+
+// reap releases all the reservations which are older than the TTL, or whose proving deadlines have passed,
+// the caller must hold the mutex.
+func (m *CapacityManager) reap() {
+	now := m.clock()
+	for id, reservedAt := range m.reserved {
+		if deadline, ok := m.deadlines[id]; ok && now.After(deadline) {
+			m.remove(id)
+			log.Warn("Capacity reservation missed its proving deadline", "id", id, "deadline", deadline)
+			if m.onDeadlineMissed != nil {
+				m.onDeadlineMissed(id)
+			}
+			continue
+		}
+		if now.Sub(reservedAt) < m.ttl {
+			continue
+		}
+
+		m.remove(id)
+		log.Warn("Capacity reservation expired", "id", id, "reservedAt", reservedAt, "ttl", m.ttl)
+	}
+}
+
+// remove removes the reservation with the given ID and its idempotency key, the caller must hold the mutex.
+func (m *CapacityManager) remove(id uint64) {
+	delete(m.reserved, id)
+	delete(m.metadata, id)
+	delete(m.deadlines, id)
+
+	close(m.released)
+	m.released = make(chan struct{})
+	if key, ok := m.reservedKeys[id]; ok {
+		delete(m.keys, key)
+		delete(m.reservedKeys, id)
+	}
+}
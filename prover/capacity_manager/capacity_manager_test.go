@@ -0,0 +1,305 @@
+package capacitymanager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var testTTL = time.Minute
+
+type fakeClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type CapacityManagerTestSuite struct {
+	suite.Suite
+	m     *CapacityManager
+	clock *fakeClock
+}
+
+func (s *CapacityManagerTestSuite) SetupTest() {
+	s.clock = &fakeClock{now: time.Now()}
+	s.m = New(2, testTTL)
+	s.m.clock = s.clock.Now
+	s.m.reapInterval = time.Millisecond
+}
+
+func (s *CapacityManagerTestSuite) TestTakeAndReleaseCapacity() {
+	id1, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	id2, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	s.NotEqual(id1, id2)
+
+	_, ok = s.m.TakeOneCapacity()
+	s.False(ok)
+
+	maxCapacity, used := s.m.ReadCapacity()
+	s.Equal(uint64(2), maxCapacity)
+	s.Equal(uint64(2), used)
+
+	s.True(s.m.ReleaseOneCapacity(id1))
+	_, used = s.m.ReadCapacity()
+	s.Equal(uint64(1), used)
+
+	_, ok = s.m.TakeOneCapacity()
+	s.True(ok)
+}
+
+func (s *CapacityManagerTestSuite) TestReleaseOneCapacityIdempotent() {
+	id, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+
+	s.True(s.m.ReleaseOneCapacity(id))
+	s.False(s.m.ReleaseOneCapacity(id))
+	s.False(s.m.ReleaseOneCapacity(id + 1))
+
+	_, used := s.m.ReadCapacity()
+	s.Equal(uint64(0), used)
+}
+
+func (s *CapacityManagerTestSuite) TestCapacityExpired() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.m.Start(ctx)
+
+	id, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+
+	s.clock.Advance(testTTL / 2)
+	s.Never(func() bool {
+		s.m.mutex.Lock()
+		defer s.m.mutex.Unlock()
+		return len(s.m.reserved) == 0
+	}, 50*time.Millisecond, time.Millisecond)
+
+	s.clock.Advance(testTTL)
+	s.Eventually(func() bool {
+		s.m.mutex.Lock()
+		defer s.m.mutex.Unlock()
+		return len(s.m.reserved) == 0
+	}, time.Second, time.Millisecond)
+
+	// Releasing an expired slot is a no-op.
+	s.False(s.m.ReleaseOneCapacity(id))
+}
+
+func (s *CapacityManagerTestSuite) TestTakeOneCapacityWithKey() {
+	id1, isNew, ok := s.m.TakeOneCapacityWithKey("block-1")
+	s.True(ok)
+	s.True(isNew)
+	id2, isNew, ok := s.m.TakeOneCapacityWithKey("block-1")
+	s.True(ok)
+	s.False(isNew)
+	s.Equal(id1, id2)
+
+	_, used := s.m.ReadCapacity()
+	s.Equal(uint64(1), used)
+
+	// A different key consumes another slot.
+	id3, isNew, ok := s.m.TakeOneCapacityWithKey("block-2")
+	s.True(ok)
+	s.True(isNew)
+	s.NotEqual(id1, id3)
+
+	// Retrying with an existing key still succeeds when the pool is full.
+	_, ok = s.m.TakeOneCapacity()
+	s.False(ok)
+	id, isNew, ok := s.m.TakeOneCapacityWithKey("block-2")
+	s.True(ok)
+	s.False(isNew)
+	s.Equal(id3, id)
+
+	// Once released, the key consumes a new slot.
+	s.True(s.m.ReleaseOneCapacity(id1))
+	id4, isNew, ok := s.m.TakeOneCapacityWithKey("block-1")
+	s.True(ok)
+	s.True(isNew)
+	s.NotEqual(id1, id4)
+}
+
+func (s *CapacityManagerTestSuite) TestWaitCapacity() {
+	available, err := s.m.WaitCapacity(context.Background())
+	s.Nil(err)
+	s.Equal(uint64(2), available)
+
+	id1, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	id2, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.m.WaitCapacity(ctx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// Both releasing and reaping wake up the waiters.
+	time.AfterFunc(10*time.Millisecond, func() { s.m.ReleaseOneCapacity(id1) })
+	available, err = s.m.WaitCapacity(context.Background())
+	s.Nil(err)
+	s.Equal(uint64(1), available)
+
+	id1, ok = s.m.TakeOneCapacity()
+	s.True(ok)
+	s.clock.Advance(testTTL)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s.m.Start(ctx)
+	available, err = s.m.WaitCapacity(context.Background())
+	s.Nil(err)
+	s.Equal(uint64(2), available)
+	s.False(s.m.ReleaseOneCapacity(id1))
+	s.False(s.m.ReleaseOneCapacity(id2))
+}
+
+func (s *CapacityManagerTestSuite) TestDeadlineMissed() {
+	var missed []uint64
+	s.m.OnDeadlineMissed(func(id uint64) { missed = append(missed, id) })
+
+	id1, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	id2, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	s.True(s.m.SetDeadline(id1, s.clock.Now().Add(testTTL/2)))
+	s.False(s.m.SetDeadline(id2+1, s.clock.Now()))
+
+	s.clock.Advance(testTTL / 2)
+	_, used := s.m.ReadCapacity()
+	s.Equal(uint64(2), used)
+
+	// The reservation past its deadline is released before its TTL.
+	s.clock.Advance(time.Second)
+	_, used = s.m.ReadCapacity()
+	s.Equal(uint64(1), used)
+	s.Equal([]uint64{id1}, missed)
+	s.False(s.m.ReleaseOneCapacity(id1))
+	s.Empty(s.m.deadlines)
+
+	// An expired reservation without a deadline doesn't count as missed.
+	s.clock.Advance(testTTL)
+	_, used = s.m.ReadCapacity()
+	s.Zero(used)
+	s.Equal([]uint64{id1}, missed)
+}
+
+func (s *CapacityManagerTestSuite) TestReservations() {
+	s.Empty(s.m.Reservations())
+	s.False(s.m.SetMetadata(1, "metadata"))
+
+	reservedAt := s.clock.Now()
+	id1, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	s.clock.Advance(time.Second)
+	id2, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	s.True(s.m.SetMetadata(id2, "metadata"))
+
+	reservations := s.m.Reservations()
+	s.Len(reservations, 2)
+	s.Equal(&Reservation{ID: id1, ReservedAt: reservedAt, ExpiresAt: reservedAt.Add(testTTL)}, reservations[0])
+	s.Equal(id2, reservations[1].ID)
+	s.Equal("metadata", reservations[1].Metadata)
+
+	// The metadata is dropped once the reservation is released or expired.
+	s.True(s.m.ReleaseOneCapacity(id2))
+	s.False(s.m.SetMetadata(id2, "metadata"))
+	s.Len(s.m.metadata, 0)
+
+	s.clock.Advance(testTTL)
+	s.Empty(s.m.Reservations())
+}
+
+func (s *CapacityManagerTestSuite) TestTakeOneCapacityWithKeyExpired() {
+	id1, _, ok := s.m.TakeOneCapacityWithKey("block-1")
+	s.True(ok)
+
+	s.clock.Advance(testTTL)
+
+	id2, isNew, ok := s.m.TakeOneCapacityWithKey("block-1")
+	s.True(ok)
+	s.True(isNew)
+	s.NotEqual(id1, id2)
+	s.Len(s.m.keys, 1)
+	s.Len(s.m.reservedKeys, 1)
+}
+
+func (s *CapacityManagerTestSuite) TestHealthProbe() {
+	var (
+		healthy = true
+		factor  = 0.5
+	)
+	s.m = New(4, testTTL)
+	s.m.clock = s.clock.Now
+	s.m.SetHealthProbe(func() (bool, float64) { return healthy, factor })
+
+	// Half of the capacity is available.
+	id1, ok := s.m.TakeOneCapacity()
+	s.True(ok)
+	_, ok = s.m.TakeOneCapacity()
+	s.True(ok)
+	_, ok = s.m.TakeOneCapacity()
+	s.False(ok)
+	maxCapacity, used := s.m.ReadCapacity()
+	s.Equal(uint64(2), maxCapacity)
+	s.Equal(uint64(2), used)
+
+	// No capacity is available when the backend is unhealthy, even after a slot is released.
+	healthy = false
+	s.True(s.m.ReleaseOneCapacity(id1))
+	_, ok = s.m.TakeOneCapacity()
+	s.False(ok)
+	maxCapacity, _ = s.m.ReadCapacity()
+	s.Zero(maxCapacity)
+
+	// The factor is clamped to the configured capacity.
+	healthy, factor = true, 2
+	maxCapacity, _ = s.m.ReadCapacity()
+	s.Equal(uint64(4), maxCapacity)
+}
+
+func (s *CapacityManagerTestSuite) TestWaitCapacityHealthRecovered() {
+	var (
+		mutex   sync.Mutex
+		healthy bool
+	)
+	s.m.SetHealthProbe(func() (bool, float64) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return healthy, 1
+	})
+
+	time.AfterFunc(100*time.Millisecond, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		healthy = true
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	available, err := s.m.WaitCapacity(ctx)
+	s.Nil(err)
+	s.Equal(uint64(2), available)
+}
+
+func TestCapacityManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(CapacityManagerTestSuite))
+}
@@ -121,6 +121,7 @@ func (p *Prover) initProofSubmitters(
 			p.rpc,
 			producer,
 			p.proofGenerationCh,
+			p.cfg.TaikoL1Address,
 			p.cfg.TaikoL2Address,
 			p.cfg.Graffiti,
 			txmgr,
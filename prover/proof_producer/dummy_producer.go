@@ -2,24 +2,60 @@ package producer
 
 import (
 	"bytes"
+	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/taikoxyz/taiko-client/bindings"
 )
 
-// DummyProofProducer always returns a dummy proof.
-type DummyProofProducer struct{}
+// DummyProofProducer always returns a dummy proof, it can be used as a standalone ProofProducer
+// to replace a real proving backend in tests.
+type DummyProofProducer struct {
+	// ProofTier is the tier of the returned dummy proofs.
+	ProofTier uint16
+	// Delay is the duration to wait before returning each dummy proof, to simulate the proving time.
+	Delay time.Duration
+}
 
-// RequestProof returns a dummy proof to the result channel.
+// RequestProof implements the ProofProducer interface.
 func (o *DummyProofProducer) RequestProof(
+	ctx context.Context,
+	opts *ProofRequestOptions,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	header *types.Header,
+) (*ProofWithHeader, error) {
+	return o.requestProof(ctx, opts, blockID, meta, header, o.Tier())
+}
+
+// Tier implements the ProofProducer interface.
+func (o *DummyProofProducer) Tier() uint16 {
+	return o.ProofTier
+}
+
+// requestProof returns a dummy proof of the given tier after the configured delay.
+func (o *DummyProofProducer) requestProof(
+	ctx context.Context,
 	opts *ProofRequestOptions,
 	blockID *big.Int,
 	meta *bindings.TaikoDataBlockMetadata,
 	header *types.Header,
 	tier uint16,
 ) (*ProofWithHeader, error) {
+	if o.Delay > 0 {
+		timer := time.NewTimer(o.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
 	return &ProofWithHeader{
 		BlockID: blockID,
 		Meta:    meta,
@@ -1,6 +1,7 @@
 package producer
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -30,16 +31,16 @@ func TestDummyProducerRequestProof(t *testing.T) {
 	}
 
 	var (
-		producer        = DummyProofProducer{}
 		tier     uint16 = 1024
+		producer        = DummyProofProducer{ProofTier: tier}
 		blockID         = common.Big32
 	)
 	res, err := producer.RequestProof(
+		context.Background(),
 		&ProofRequestOptions{},
 		blockID,
 		&bindings.TaikoDataBlockMetadata{},
 		header,
-		tier,
 	)
 	require.Nil(t, err)
 
@@ -48,3 +49,29 @@ func TestDummyProducerRequestProof(t *testing.T) {
 	require.Equal(t, tier, res.Tier)
 	require.NotEmpty(t, res.Proof)
 }
+
+func TestDummyProducerRequestProofDelay(t *testing.T) {
+	var (
+		producer ProofProducer = &DummyProofProducer{ProofTier: 1, Delay: 100 * time.Millisecond}
+		header                 = &types.Header{Number: common.Big1, Difficulty: common.Big0}
+	)
+
+	startedAt := time.Now()
+	res, err := producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptions{},
+		common.Big1,
+		&bindings.TaikoDataBlockMetadata{},
+		header,
+	)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, time.Since(startedAt), 100*time.Millisecond)
+	require.Equal(t, producer.Tier(), res.Tier)
+	require.NotEmpty(t, res.Proof)
+
+	// The request should be aborted once the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = producer.RequestProof(ctx, &ProofRequestOptions{}, common.Big1, &bindings.TaikoDataBlockMetadata{}, header)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
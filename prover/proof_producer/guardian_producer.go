@@ -26,7 +26,7 @@ func NewGuardianProofProducer(returnLivenessBond bool) *GuardianProofProducer {
 
 // RequestProof implements the ProofProducer interface.
 func (g *GuardianProofProducer) RequestProof(
-	_ context.Context,
+	ctx context.Context,
 	opts *ProofRequestOptions,
 	blockID *big.Int,
 	meta *bindings.TaikoDataBlockMetadata,
@@ -51,7 +51,7 @@ func (g *GuardianProofProducer) RequestProof(
 		}, nil
 	}
 
-	return g.DummyProofProducer.RequestProof(opts, blockID, meta, header, g.Tier())
+	return g.DummyProofProducer.requestProof(ctx, opts, blockID, meta, header, g.Tier())
 }
 
 // Tier implements the ProofProducer interface.
@@ -16,7 +16,7 @@ type OptimisticProofProducer struct{ DummyProofProducer }
 
 // RequestProof implements the ProofProducer interface.
 func (o *OptimisticProofProducer) RequestProof(
-	_ context.Context,
+	ctx context.Context,
 	opts *ProofRequestOptions,
 	blockID *big.Int,
 	meta *bindings.TaikoDataBlockMetadata,
@@ -30,7 +30,7 @@ func (o *OptimisticProofProducer) RequestProof(
 		"hash", header.Hash(),
 	)
 
-	return o.DummyProofProducer.RequestProof(opts, blockID, meta, header, o.Tier())
+	return o.DummyProofProducer.requestProof(ctx, opts, blockID, meta, header, o.Tier())
 }
 
 // Tier implements the ProofProducer interface.
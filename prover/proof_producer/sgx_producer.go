@@ -84,7 +84,7 @@ func (s *SGXProofProducer) RequestProof(
 	)
 
 	if s.Dummy {
-		return s.DummyProofProducer.RequestProof(opts, blockID, meta, header, s.Tier())
+		return s.DummyProofProducer.requestProof(ctx, opts, blockID, meta, header, s.Tier())
 	}
 
 	proof, err := s.callProverDaemon(ctx, opts)
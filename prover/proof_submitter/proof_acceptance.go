@@ -0,0 +1,100 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+var (
+	// ErrProofReverted is returned by VerifyProofAccepted when the proof submission transaction reverted.
+	ErrProofReverted = errors.New("proof submission reverted")
+	// ErrProofTransitionMismatch is returned by VerifyProofAccepted when the TransitionProved event emitted by
+	// the proof submission is for another block or another transition.
+	ErrProofTransitionMismatch = errors.New("proved transition mismatch")
+	// ErrProofEventNotFound is returned by VerifyProofAccepted when the proof submission emitted no
+	// TransitionProved event.
+	ErrProofEventNotFound = errors.New("TransitionProved event not found")
+)
+
+// VerifyProofAccepted checks whether the proof submission with the given receipt has been accepted by TaikoL1,
+// i.e. the transaction succeeded and emitted a TransitionProved event for the given block, whose transition
+// matches the block in the L2 execution engine. The returned error wraps ErrProofReverted,
+// ErrProofTransitionMismatch or ErrProofEventNotFound if the proof is not accepted.
+func (s *ProofSubmitter) VerifyProofAccepted(
+	ctx context.Context,
+	receipt *types.Receipt,
+	blockID *big.Int,
+) (bool, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return false, fmt.Errorf("%w (tx: %s, blockID: %d)", ErrProofReverted, receipt.TxHash, blockID)
+	}
+
+	header, err := s.rpc.L2.HeaderByNumber(ctx, blockID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the L2 header (id: %d): %w", blockID, err)
+	}
+
+	if err := checkProvedTransition(
+		&s.rpc.TaikoL1.TaikoL1ClientFilterer,
+		s.taikoL1Address,
+		receipt,
+		blockID,
+		header,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkProvedTransition checks the TransitionProved events in the given receipt against the given L2 header,
+// only the events emitted by the given TaikoL1 address are checked, since any contract the proof submission
+// calls into can emit a log with the same signature.
+func checkProvedTransition(
+	filterer *bindings.TaikoL1ClientFilterer,
+	taikoL1Address common.Address,
+	receipt *types.Receipt,
+	blockID *big.Int,
+	header *types.Header,
+) error {
+	var mismatch error
+	for _, l := range receipt.Logs {
+		if l.Address != taikoL1Address {
+			continue
+		}
+		event, err := filterer.ParseTransitionProved(*l)
+		if err != nil {
+			// Not a TransitionProved event.
+			continue
+		}
+
+		if event.BlockId.Cmp(blockID) != 0 {
+			mismatch = fmt.Errorf("%w: proved block %d, expected %d", ErrProofTransitionMismatch, event.BlockId, blockID)
+			continue
+		}
+		if event.Tran.ParentHash != header.ParentHash ||
+			event.Tran.BlockHash != header.Hash() ||
+			event.Tran.StateRoot != header.Root {
+			return fmt.Errorf(
+				"%w (blockID: %d): proved blockHash %s, expected %s",
+				ErrProofTransitionMismatch,
+				blockID,
+				common.Hash(event.Tran.BlockHash),
+				header.Hash(),
+			)
+		}
+
+		return nil
+	}
+
+	if mismatch != nil {
+		return mismatch
+	}
+	return fmt.Errorf("%w (tx: %s, blockID: %d)", ErrProofEventNotFound, receipt.TxHash, blockID)
+}
@@ -0,0 +1,87 @@
+package submitter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+)
+
+// newTestTransitionProvedLog crafts a TransitionProved event log emitted by the given address, with the given
+// block ID and transition.
+func newTestTransitionProvedLog(
+	t *testing.T,
+	address common.Address,
+	blockID *big.Int,
+	tran bindings.TaikoDataTransition,
+) *types.Log {
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
+	require.Nil(t, err)
+
+	event := taikoL1ABI.Events["TransitionProved"]
+	data, err := event.Inputs.NonIndexed().Pack(tran, common.HexToAddress("0x01"), common.Big0, uint16(100))
+	require.Nil(t, err)
+
+	return &types.Log{Address: address, Topics: []common.Hash{event.ID, common.BigToHash(blockID)}, Data: data}
+}
+
+func TestCheckProvedTransition(t *testing.T) {
+	filterer, err := bindings.NewTaikoL1ClientFilterer(common.Address{}, nil)
+	require.Nil(t, err)
+
+	var (
+		taikoL1 = common.HexToAddress("0x05")
+		blockID = big.NewInt(10)
+		header  = &types.Header{
+			Number:     blockID,
+			ParentHash: common.HexToHash("0x01"),
+			Root:       common.HexToHash("0x02"),
+		}
+		tran = bindings.TaikoDataTransition{
+			ParentHash: header.ParentHash,
+			BlockHash:  header.Hash(),
+			StateRoot:  header.Root,
+		}
+		unrelated = &types.Log{Topics: []common.Hash{common.HexToHash("0x03")}}
+	)
+
+	// Accepted, the unrelated logs are skipped.
+	receipt := &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{unrelated, newTestTransitionProvedLog(t, taikoL1, blockID, tran)},
+	}
+	require.Nil(t, checkProvedTransition(filterer, taikoL1, receipt, blockID, header))
+
+	// Another block.
+	receipt.Logs = []*types.Log{newTestTransitionProvedLog(t, taikoL1, big.NewInt(11), tran)}
+	require.ErrorIs(t, checkProvedTransition(filterer, taikoL1, receipt, blockID, header), ErrProofTransitionMismatch)
+
+	// Another transition.
+	wrong := tran
+	wrong.BlockHash = common.HexToHash("0x04")
+	receipt.Logs = []*types.Log{newTestTransitionProvedLog(t, taikoL1, blockID, wrong)}
+	require.ErrorIs(t, checkProvedTransition(filterer, taikoL1, receipt, blockID, header), ErrProofTransitionMismatch)
+
+	// A look-alike event emitted by another contract.
+	receipt.Logs = []*types.Log{newTestTransitionProvedLog(t, common.HexToAddress("0x06"), blockID, tran)}
+	require.ErrorIs(t, checkProvedTransition(filterer, taikoL1, receipt, blockID, header), ErrProofEventNotFound)
+
+	// No event at all.
+	receipt.Logs = []*types.Log{unrelated}
+	require.ErrorIs(t, checkProvedTransition(filterer, taikoL1, receipt, blockID, header), ErrProofEventNotFound)
+}
+
+func TestVerifyProofAcceptedReverted(t *testing.T) {
+	accepted, err := new(ProofSubmitter).VerifyProofAccepted(
+		context.Background(),
+		&types.Receipt{Status: types.ReceiptStatusFailed},
+		common.Big1,
+	)
+	require.False(t, accepted)
+	require.ErrorIs(t, err, ErrProofReverted)
+}
@@ -30,6 +30,7 @@ type ProofSubmitter struct {
 	txBuilder       *transaction.ProveBlockTxBuilder
 	sender          *transaction.Sender
 	proverAddress   common.Address
+	taikoL1Address  common.Address
 	taikoL2Address  common.Address
 	graffiti        [32]byte
 }
@@ -39,6 +40,7 @@ func NewProofSubmitter(
 	rpcClient *rpc.Client,
 	proofProducer proofProducer.ProofProducer,
 	resultCh chan *proofProducer.ProofWithHeader,
+	taikoL1Address common.Address,
 	taikoL2Address common.Address,
 	graffiti string,
 	txmgr *txmgr.SimpleTxManager,
@@ -57,6 +59,7 @@ func NewProofSubmitter(
 		txBuilder:       builder,
 		sender:          transaction.NewSender(rpcClient, txmgr, 0),
 		proverAddress:   txmgr.From(),
+		taikoL1Address:  taikoL1Address,
 		taikoL2Address:  taikoL2Address,
 		graffiti:        rpc.StringToBytes32(graffiti),
 	}, nil
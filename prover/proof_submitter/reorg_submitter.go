@@ -0,0 +1,203 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var (
+	_ Submitter = (*ReorgAwareSubmitter)(nil)
+	// ErrProposalNotFound is returned by a ProposalFetcher when the given block is no longer proposed.
+	ErrProposalNotFound = errors.New("block proposal not found")
+	// reorgTrackingDepth is the number of the L1 blocks a submitted proof will be tracked for after its
+	// proposal, a deeper reorg is still handled since it comes without a common ancestor.
+	reorgTrackingDepth uint64 = 64
+)
+
+// ProposalFetcher fetches the canonical BlockProposed event of the given L2 block, it should return an
+// error wrapping ErrProposalNotFound if the block is no longer proposed.
+type ProposalFetcher func(ctx context.Context, blockID *big.Int) (*bindings.TaikoL1ClientBlockProposed, error)
+
+// ReorgAwareSubmitter wraps a Submitter, and re-checks the proposal of a block when its proof submission
+// reverts or the L1 chain reorgs after the submission. If the block has been proposed again in another
+// L1 block, a new proof will be requested against the canonical proposal, and if the block no longer
+// exists, the proof will be abandoned.
+type ReorgAwareSubmitter struct {
+	Submitter
+	fetchProposal ProposalFetcher
+	submitted     map[uint64]*bindings.TaikoDataBlockMetadata
+	mutex         sync.Mutex
+}
+
+// NewReorgAwareSubmitter creates a new ReorgAwareSubmitter instance.
+func NewReorgAwareSubmitter(submitter Submitter, fetchProposal ProposalFetcher) *ReorgAwareSubmitter {
+	return &ReorgAwareSubmitter{
+		Submitter:     submitter,
+		fetchProposal: fetchProposal,
+		submitted:     make(map[uint64]*bindings.TaikoDataBlockMetadata),
+	}
+}
+
+// NewProposalFetcher creates a ProposalFetcher which fetches the canonical proposals from TaikoL1.
+func NewProposalFetcher(cli *rpc.Client) ProposalFetcher {
+	return func(ctx context.Context, blockID *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		blockInfo, err := cli.GetL2BlockInfo(ctx, blockID)
+		if err != nil {
+			return nil, err
+		}
+		if blockInfo.Blk.BlockId != blockID.Uint64() || blockInfo.Blk.MetaHash == [32]byte{} {
+			return nil, fmt.Errorf("%w (id: %d)", ErrProposalNotFound, blockID)
+		}
+
+		end := blockInfo.Blk.ProposedIn
+		iter, err := cli.TaikoL1.FilterBlockProposed(
+			&bind.FilterOpts{Context: ctx, Start: blockInfo.Blk.ProposedIn, End: &end},
+			[]*big.Int{blockID},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		defer iter.Close()
+
+		for iter.Next() {
+			if iter.Event.Raw.Removed {
+				continue
+			}
+			return iter.Event, nil
+		}
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w (id: %d, proposedIn: %d)", ErrProposalNotFound, blockID, end)
+	}
+}
+
+// SubmitProof implements the Submitter interface.
+func (s *ReorgAwareSubmitter) SubmitProof(ctx context.Context, proofWithHeader *proofProducer.ProofWithHeader) error {
+	if err := s.Submitter.SubmitProof(ctx, proofWithHeader); err != nil {
+		log.Warn(
+			"Failed to submit proof, re-checking the block proposal",
+			"blockID", proofWithHeader.BlockID,
+			"error", err,
+		)
+		return s.recheck(ctx, proofWithHeader.BlockID, proofWithHeader.Meta, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.submitted[proofWithHeader.BlockID.Uint64()] = proofWithHeader.Meta
+
+	return nil
+}
+
+// Watch watches the given L1 chain head events until the context is done or the channel is closed,
+// and re-checks the proposals of the submitted proofs which have been affected by a reorg.
+func (s *ReorgAwareSubmitter) Watch(ctx context.Context, heads <-chan *rpc.ChainHeadEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-heads:
+			if !ok {
+				return
+			}
+			if event.Type == rpc.Reorg {
+				s.onReorg(ctx, event)
+			}
+			s.prune(event.Head.Number.Uint64())
+		}
+	}
+}
+
+// onReorg re-checks the proposals of the submitted proofs which were proposed after the common ancestor
+// of the given reorg event.
+func (s *ReorgAwareSubmitter) onReorg(ctx context.Context, event *rpc.ChainHeadEvent) {
+	s.mutex.Lock()
+	affected := make(map[uint64]*bindings.TaikoDataBlockMetadata)
+	for id, meta := range s.submitted {
+		if event.CommonAncestor == nil || proposedIn(meta) > event.CommonAncestor.Number.Uint64() {
+			affected[id] = meta
+		}
+	}
+	s.mutex.Unlock()
+
+	for id, meta := range affected {
+		log.Info("L1 reorg detected, re-checking the block proposal", "blockID", id, "l1Height", meta.L1Height)
+		if err := s.recheck(ctx, new(big.Int).SetUint64(id), meta, nil); err != nil {
+			log.Error("Failed to re-check the block proposal", "blockID", id, "error", err)
+		}
+	}
+}
+
+// recheck checks whether the given block is still proposed with the given metadata, if not, a new
+// proof will be requested against the canonical proposal. The given cause will be returned if the
+// proposal doesn't change.
+func (s *ReorgAwareSubmitter) recheck(
+	ctx context.Context,
+	blockID *big.Int,
+	meta *bindings.TaikoDataBlockMetadata,
+	cause error,
+) error {
+	proposal, err := s.fetchProposal(ctx, blockID)
+	if err != nil {
+		if errors.Is(err, ErrProposalNotFound) {
+			log.Info("Block proposal no longer exists, abandoning the proof", "blockID", blockID)
+			s.untrack(blockID)
+			return nil
+		}
+		return fmt.Errorf("failed to fetch the block proposal (id: %d): %w", blockID, err)
+	}
+
+	if proposal.Meta == *meta {
+		return cause
+	}
+
+	log.Info(
+		"Block proposal moved, requesting a new proof",
+		"blockID", blockID,
+		"oldL1Height", meta.L1Height,
+		"newL1Height", proposal.Meta.L1Height,
+		"newL1Hash", common.BytesToHash(proposal.Meta.L1Hash[:]),
+	)
+	s.untrack(blockID)
+
+	return s.Submitter.RequestProof(ctx, proposal)
+}
+
+// untrack stops tracking the submitted proof of the given block.
+func (s *ReorgAwareSubmitter) untrack(blockID *big.Int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.submitted, blockID.Uint64())
+}
+
+// prune stops tracking the submitted proofs which were proposed too long before the given L1 head.
+func (s *ReorgAwareSubmitter) prune(head uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, meta := range s.submitted {
+		if proposedIn(meta)+reorgTrackingDepth < head {
+			delete(s.submitted, id)
+		}
+	}
+}
+
+// proposedIn returns the number of the L1 block which the given block was proposed in.
+func proposedIn(meta *bindings.TaikoDataBlockMetadata) uint64 {
+	return meta.L1Height + 1
+}
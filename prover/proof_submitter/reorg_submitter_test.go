@@ -0,0 +1,160 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
+)
+
+var errTestProofReverted = errors.New("execution reverted")
+
+type testSubmitter struct {
+	submitErr error
+	requested []*bindings.TaikoL1ClientBlockProposed
+	mutex     sync.Mutex
+}
+
+func (s *testSubmitter) RequestProof(_ context.Context, event *bindings.TaikoL1ClientBlockProposed) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requested = append(s.requested, event)
+	return nil
+}
+
+func (s *testSubmitter) SubmitProof(context.Context, *proofProducer.ProofWithHeader) error {
+	return s.submitErr
+}
+
+func (s *testSubmitter) Producer() proofProducer.ProofProducer { return nil }
+
+func (s *testSubmitter) Tier() uint16 { return 0 }
+
+func (s *testSubmitter) requestedProofs() []*bindings.TaikoL1ClientBlockProposed {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*bindings.TaikoL1ClientBlockProposed{}, s.requested...)
+}
+
+func newTestProposal(blockID uint64, l1Height uint64) *bindings.TaikoL1ClientBlockProposed {
+	return &bindings.TaikoL1ClientBlockProposed{
+		BlockId: new(big.Int).SetUint64(blockID),
+		Meta: bindings.TaikoDataBlockMetadata{
+			Id:       blockID,
+			L1Height: l1Height,
+			L1Hash:   common.BigToHash(new(big.Int).SetUint64(l1Height)),
+		},
+	}
+}
+
+func newTestProof(proposal *bindings.TaikoL1ClientBlockProposed) *proofProducer.ProofWithHeader {
+	meta := proposal.Meta
+	return &proofProducer.ProofWithHeader{BlockID: proposal.BlockId, Meta: &meta}
+}
+
+func TestReorgAwareSubmitterResubmitOnReorg(t *testing.T) {
+	var (
+		inner    = &testSubmitter{}
+		original = newTestProposal(1, 10)
+		moved    = newTestProposal(1, 12)
+		current  = original
+		mutex    sync.Mutex
+	)
+	s := NewReorgAwareSubmitter(inner, func(context.Context, *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return current, nil
+	})
+	require.Nil(t, s.SubmitProof(context.Background(), newTestProof(original)))
+	require.Empty(t, inner.requestedProofs())
+
+	// The L1 chain reorgs from the block before the proposal, and the block is proposed again later.
+	mutex.Lock()
+	current = moved
+	mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads := make(chan *rpc.ChainHeadEvent, 1)
+	go s.Watch(ctx, heads)
+	heads <- &rpc.ChainHeadEvent{
+		Type:           rpc.Reorg,
+		Head:           &types.Header{Number: big.NewInt(13)},
+		CommonAncestor: &types.Header{Number: big.NewInt(10)},
+	}
+
+	require.Eventually(t, func() bool { return len(inner.requestedProofs()) == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, moved, inner.requestedProofs()[0])
+	require.Equal(t, uint64(12), inner.requestedProofs()[0].Meta.L1Height)
+}
+
+func TestReorgAwareSubmitterReorgAfterProposal(t *testing.T) {
+	inner := &testSubmitter{}
+	s := NewReorgAwareSubmitter(inner, func(context.Context, *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		return newTestProposal(1, 12), nil
+	})
+	require.Nil(t, s.SubmitProof(context.Background(), newTestProof(newTestProposal(1, 10))))
+
+	// The reorg doesn't revert the proposal block.
+	s.onReorg(context.Background(), &rpc.ChainHeadEvent{
+		Type:           rpc.Reorg,
+		Head:           &types.Header{Number: big.NewInt(13)},
+		CommonAncestor: &types.Header{Number: big.NewInt(11)},
+	})
+	require.Empty(t, inner.requestedProofs())
+}
+
+func TestReorgAwareSubmitterRevert(t *testing.T) {
+	inner := &testSubmitter{submitErr: errTestProofReverted}
+	original := newTestProposal(1, 10)
+
+	// Still proposed in the same place, the original error should be returned.
+	s := NewReorgAwareSubmitter(inner, func(context.Context, *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		return original, nil
+	})
+	require.ErrorIs(t, s.SubmitProof(context.Background(), newTestProof(original)), errTestProofReverted)
+	require.Empty(t, inner.requestedProofs())
+
+	// The proposal has moved, a new proof should be requested.
+	moved := newTestProposal(1, 11)
+	s.fetchProposal = func(context.Context, *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		return moved, nil
+	}
+	require.Nil(t, s.SubmitProof(context.Background(), newTestProof(original)))
+	require.Equal(t, []*bindings.TaikoL1ClientBlockProposed{moved}, inner.requestedProofs())
+}
+
+func TestReorgAwareSubmitterAbandon(t *testing.T) {
+	inner := &testSubmitter{submitErr: errTestProofReverted}
+	s := NewReorgAwareSubmitter(inner, func(_ context.Context, id *big.Int) (*bindings.TaikoL1ClientBlockProposed, error) {
+		return nil, fmt.Errorf("%w (id: %d)", ErrProposalNotFound, id)
+	})
+
+	require.Nil(t, s.SubmitProof(context.Background(), newTestProof(newTestProposal(1, 10))))
+	require.Empty(t, inner.requestedProofs())
+	require.Empty(t, s.submitted)
+}
+
+func TestReorgAwareSubmitterPrune(t *testing.T) {
+	inner := &testSubmitter{}
+	s := NewReorgAwareSubmitter(inner, nil)
+	require.Nil(t, s.SubmitProof(context.Background(), newTestProof(newTestProposal(1, 10))))
+
+	s.prune(11 + reorgTrackingDepth)
+	require.Len(t, s.submitted, 1)
+	s.prune(12 + reorgTrackingDepth)
+	require.Empty(t, s.submitted)
+}
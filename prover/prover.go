@@ -393,6 +393,11 @@ func (p *Prover) submitProofOp(proofWithHeader *proofProducer.ProofWithHeader) e
 		return err
 	}
 
+	// The assignment is completed, release its reserved capacity before the proving deadline.
+	if p.server != nil {
+		p.server.CompleteAssignment(proofWithHeader.Meta.BlobHash)
+	}
+
 	return nil
 }
 
@@ -530,10 +530,12 @@ func (s *ProverTestSuite) initProver(
 			TxNotInMempoolTimeout:     txmgr.DefaultBatcherFlagValues.TxNotInMempoolTimeout,
 		},
 	}))
-	p.server = s.NewTestProverServer(
+	var shutdownServer func()
+	p.server, shutdownServer = s.NewTestProverServer(
 		key,
 		proverServerURL,
 	)
+	s.T().Cleanup(shutdownServer)
 
 	p.guardianProverHeartbeater = guardianProverHeartbeater.New(
 		key,
@@ -2,22 +2,44 @@ package server
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/labstack/echo/v4"
 
+	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 )
 
 const (
 	rpcTimeout = 1 * time.Minute
+	// defaultWaitCapacityTimeout and maxWaitCapacityTimeout are the default and the max duration
+	// a capacity waiting request can be blocked for.
+	defaultWaitCapacityTimeout = 30 * time.Second
+	maxWaitCapacityTimeout     = 5 * time.Minute
+	// waitCapacityPollInterval is the interval of checking the proof submission channel capacity,
+	// when the capacity manager is not enabled.
+	waitCapacityPollInterval = time.Second
+	// IdempotencyKeyHeader is the request header of the assignment idempotency key, the retried
+	// assignment requests with the same key will reuse the reserved prover capacity.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+var (
+	errUnknownTier                 = errors.New("unknown tier")
+	errMissingProofVerificationGas = errors.New("missing proof verification gas")
+	// sgxBasedTiers are the tiers whose verifiers check the SGX signature of the proof, so their
+	// verification gas can't be estimated with a dummy proof, and must be configured instead.
+	sgxBasedTiers = []uint16{encoding.TierSgxID, encoding.TierSgxAndZkVMID}
 )
 
 // @title Taiko Prover Server API
@@ -32,11 +54,18 @@ const (
 // @license.url https://github.com/taikoxyz/taiko-client/blob/main/LICENSE.md
 
 // CreateAssignmentRequestBody represents a request body when handling assignment creation request.
+// If Expiry is not set, the prover server will decide the expiry based on the desired ProvingWindow
+// (in seconds), which is bounded by the server's MaxExpiry. Nonce and Timestamp (in seconds) are
+// required if the server has replay protection enabled. The field names are decoded case-insensitively,
+// so the requests of the clients sending the untagged field names are still accepted.
 type CreateAssignmentRequestBody struct {
-	FeeToken   common.Address
-	TierFees   []encoding.TierFee
-	Expiry     uint64
-	TxListHash common.Hash
+	FeeToken      common.Address     `json:"feeToken"`
+	TierFees      []encoding.TierFee `json:"tierFees"`
+	Expiry        uint64             `json:"expiry"`
+	ProvingWindow uint64             `json:"provingWindow"`
+	TxListHash    common.Hash        `json:"txListHash"`
+	Nonce         string             `json:"nonce"`
+	Timestamp     uint64             `json:"timestamp"`
 }
 
 // Status represents the current prover server status.
@@ -46,6 +75,9 @@ type Status struct {
 	MinSgxAndZkVMTierFee uint64 `json:"minSgxAndZkVMTierFee"`
 	MaxExpiry            uint64 `json:"maxExpiry"`
 	Prover               string `json:"prover"`
+	TotalCapacity        uint64 `json:"totalCapacity"`
+	UsedCapacity         uint64 `json:"usedCapacity"`
+	AvailableCapacity    uint64 `json:"availableCapacity"`
 }
 
 // GetStatus handles a query to the current prover server status.
@@ -57,22 +89,325 @@ type Status struct {
 //	@Success		200	{object} Status
 //	@Router			/status [get]
 func (s *ProverServer) GetStatus(c echo.Context) error {
+	total, used := s.capacity()
+
+	minTierFees := make(map[uint16]*big.Int)
+	for _, tier := range []uint16{encoding.TierOptimisticID, encoding.TierSgxID, encoding.TierSgxAndZkVMID} {
+		fee, err := s.minTierFee(c.Request().Context(), tier)
+		if err != nil {
+			s.logger.Error("Failed to get minimum tier fee", "tier", tier, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, err)
+		}
+		minTierFees[tier] = fee
+	}
+
 	return c.JSON(http.StatusOK, &Status{
-		MinOptimisticTierFee: s.minOptimisticTierFee.Uint64(),
-		MinSgxTierFee:        s.minSgxTierFee.Uint64(),
-		MinSgxAndZkVMTierFee: s.minSgxAndZkVMTierFee.Uint64(),
+		MinOptimisticTierFee: minTierFees[encoding.TierOptimisticID].Uint64(),
+		MinSgxTierFee:        minTierFees[encoding.TierSgxID].Uint64(),
+		MinSgxAndZkVMTierFee: minTierFees[encoding.TierSgxAndZkVMID].Uint64(),
 		MaxExpiry:            uint64(s.maxExpiry.Seconds()),
 		Prover:               s.proverAddress.Hex(),
+		TotalCapacity:        total,
+		UsedCapacity:         used,
+		AvailableCapacity:    total - min(used, total),
+	})
+}
+
+// SupportedTiers represents the proof tiers which the prover accepts assignments for.
+type SupportedTiers struct {
+	Tiers []uint16 `json:"tiers"`
+}
+
+// GetTiers handles a query to the proof tiers supported by the prover.
+//
+//	@Summary		Get the proof tiers supported by the prover
+//	@ID			   	get-tiers
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object} SupportedTiers
+//	@Router			/tiers [get]
+func (s *ProverServer) GetTiers(c echo.Context) error {
+	return c.JSON(http.StatusOK, &SupportedTiers{Tiers: s.supportedTiers})
+}
+
+// BondStatus represents the prover's on-chain bond status, the liveness bond of each assigned block is paid
+// from the prover's Taiko token balance, through the allowance approved to the assignment hook.
+type BondStatus struct {
+	Prover       common.Address `json:"prover"`
+	Balance      *big.Int       `json:"balance"`
+	Allowance    *big.Int       `json:"allowance"`
+	RequiredBond *big.Int       `json:"requiredBond"`
+	Bonded       bool           `json:"bonded"`
+}
+
+// GetBond handles a query to the prover's on-chain bond status, the result is cached for a short while.
+//
+//	@Summary		Get the prover's on-chain bond status
+//	@ID			   	get-bond
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object} BondStatus
+//	@Router			/bond [get]
+func (s *ProverServer) GetBond(c echo.Context) error {
+	if s.rpc == nil || s.rpc.TaikoToken == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "taiko token contract not configured")
+	}
+
+	status, err := s.bond(c.Request().Context())
+	if err != nil {
+		s.logger.Error("Failed to get the prover bond status", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// bond returns the prover's bond status, which will be cached for bondStatusCacheTTL.
+func (s *ProverServer) bond(ctx context.Context) (*BondStatus, error) {
+	s.bondMutex.Lock()
+	defer s.bondMutex.Unlock()
+
+	if s.bondStatus != nil && time.Since(s.bondCheckedAt) < bondStatusCacheTTL {
+		return s.bondStatus, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	balance, err := s.rpc.TaikoToken.BalanceOf(&bind.CallOpts{Context: ctx}, s.proverAddress)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := s.rpc.TaikoToken.Allowance(&bind.CallOpts{Context: ctx}, s.proverAddress, s.assignmentHookAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredBond := s.livenessBond
+	if requiredBond == nil {
+		requiredBond = common.Big0
+	}
+	s.bondStatus = &BondStatus{
+		Prover:       s.proverAddress,
+		Balance:      balance,
+		Allowance:    allowance,
+		RequiredBond: requiredBond,
+		Bonded:       balance.Cmp(requiredBond) >= 0 && allowance.Cmp(requiredBond) >= 0,
+	}
+	s.bondCheckedAt = time.Now()
+
+	return s.bondStatus, nil
+}
+
+// ActiveAssignment represents an in-progress prover assignment which is still holding a reserved capacity,
+// the assignment signature is never included. The assigned block ID is unknown until the block is proposed,
+// so MaxBlockID is the maximum block ID the assignment is valid for, and Deadline is the assignment expiry.
+type ActiveAssignment struct {
+	ReservationID uint64             `json:"reservationID"`
+	MaxBlockID    uint64             `json:"maxBlockID"`
+	TierFees      []encoding.TierFee `json:"tierFees"`
+	Deadline      uint64             `json:"deadline"`
+	ReservedAt    uint64             `json:"reservedAt"`
+}
+
+// assignmentInfo is the assignment data attached to a capacity reservation.
+type assignmentInfo struct {
+	txListHash common.Hash
+	maxBlockID uint64
+	tierFees   []encoding.TierFee
+	expiry     uint64
+}
+
+// GetAssignments handles a query to the in-progress prover assignments.
+//
+//	@Summary		Get the in-progress prover assignments
+//	@ID			   	get-assignments
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array} ActiveAssignment
+//	@Router			/assignments [get]
+func (s *ProverServer) GetAssignments(c echo.Context) error {
+	assignments := make([]*ActiveAssignment, 0)
+	if s.capacityManager == nil {
+		return c.JSON(http.StatusOK, assignments)
+	}
+
+	for _, reservation := range s.capacityManager.Reservations() {
+		// The reservation is still being signed.
+		info, ok := reservation.Metadata.(*assignmentInfo)
+		if !ok {
+			continue
+		}
+		assignments = append(assignments, &ActiveAssignment{
+			ReservationID: reservation.ID,
+			MaxBlockID:    info.maxBlockID,
+			TierFees:      info.tierFees,
+			Deadline:      info.expiry,
+			ReservedAt:    uint64(reservation.ReservedAt.Unix()),
+		})
+	}
+
+	return c.JSON(http.StatusOK, assignments)
+}
+
+// AvailableCapacity represents the number of the currently available capacity slots.
+type AvailableCapacity struct {
+	AvailableCapacity uint64 `json:"availableCapacity"`
+}
+
+// WaitCapacity handles a long-polling query which waits until there is available prover capacity,
+// the timeout query parameter is in seconds, and bounded by 5 minutes, default to 30 seconds.
+//
+//	@Summary		Wait until there is available prover capacity
+//	@ID			   	wait-capacity
+//	@Produce		json
+//	@Param			timeout	query	int	false	"max seconds to wait"
+//	@Success		200	{object} AvailableCapacity
+//	@Success		204	"no capacity available before the timeout"
+//	@Router			/wait-capacity [get]
+func (s *ProverServer) WaitCapacity(c echo.Context) error {
+	timeout := defaultWaitCapacityTimeout
+	if param := c.QueryParam("timeout"); param != "" {
+		seconds, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid timeout")
+		}
+		timeout = min(time.Duration(seconds)*time.Second, maxWaitCapacityTimeout)
+	}
+
+	// The waiter is cleaned up once the client disconnects, since the request context will be cancelled,
+	// and it shouldn't hold back the server shutdown either.
+	ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+	defer cancel()
+	stop := context.AfterFunc(s.ctx, cancel)
+	defer stop()
+
+	available, err := s.waitCapacity(ctx)
+	if err != nil {
+		if c.Request().Context().Err() != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return c.JSON(http.StatusOK, &AvailableCapacity{AvailableCapacity: available})
+}
+
+// waitCapacity blocks until there is available capacity or the given context is done, and returns the
+// number of the available slots.
+func (s *ProverServer) waitCapacity(ctx context.Context) (uint64, error) {
+	if s.capacityManager != nil {
+		return s.capacityManager.WaitCapacity(ctx)
+	}
+
+	ticker := time.NewTicker(waitCapacityPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if total, used := s.capacity(); total > used {
+			return total - used, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// capacity returns the total and currently used capacity, from the capacity manager if it is enabled,
+// otherwise from the proof submission channel.
+func (s *ProverServer) capacity() (uint64, uint64) {
+	if s.capacityManager != nil {
+		return s.capacityManager.ReadCapacity()
+	}
+	if s.proofSubmissionCh == nil {
+		return 0, 0
+	}
+
+	return uint64(cap(s.proofSubmissionCh)), uint64(len(s.proofSubmissionCh))
+}
+
+// EstimateProofTimeRequestBody represents a request body when handling proving time estimation request,
+// it carries the metadata of the block to prove.
+type EstimateProofTimeRequestBody struct {
+	BlockID uint64 `json:"blockId"`
+	GasUsed uint64 `json:"gasUsed"`
+	TxCount uint64 `json:"txCount"`
+	Tier    uint16 `json:"tier"`
+}
+
+// ProofTimeEstimate represents the estimated proving time of a block (in seconds). The queued blocks are
+// assumed to take as long to prove as the given one, so EstimatedTime is ProvingTime multiplied by
+// QueueDepth plus one.
+type ProofTimeEstimate struct {
+	ProvingTime   uint64 `json:"provingTime"`
+	QueueDepth    uint64 `json:"queueDepth"`
+	EstimatedTime uint64 `json:"estimatedTime"`
+}
+
+// EstimateProofTime handles a query to the estimated proving time of a block, derived from the configured
+// proving time model and the current queue depth.
+//
+//	@Summary		Estimate the proving time of a block
+//	@ID			   	estimate-proof-time
+//	@Param          body        body    EstimateProofTimeRequestBody   true    "block metadata"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object} ProofTimeEstimate
+//	@Failure		422		{string} string "unsupported tier"
+//	@Failure		501		{string} string "proving time estimation not supported"
+//	@Router			/estimate [post]
+func (s *ProverServer) EstimateProofTime(c echo.Context) error {
+	if s.proofTimeModel == nil {
+		return echo.NewHTTPError(http.StatusNotImplemented, "proving time estimation not supported")
+	}
+
+	req := new(EstimateProofTimeRequestBody)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err)
+	}
+	if req.Tier != 0 && !slices.Contains(s.supportedTiers, req.Tier) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "unsupported tier")
+	}
+
+	provingTime := s.proofTimeModel(req)
+	_, queueDepth := s.capacity()
+
+	return c.JSON(http.StatusOK, &ProofTimeEstimate{
+		ProvingTime:   uint64(provingTime.Seconds()),
+		QueueDepth:    queueDepth,
+		EstimatedTime: uint64((provingTime * time.Duration(queueDepth+1)).Seconds()),
 	})
 }
 
 // ProposeBlockResponse represents the JSON response which will be returned by
 // the ProposeBlock request handler.
 type ProposeBlockResponse struct {
-	SignedPayload []byte         `json:"signedPayload"`
-	Prover        common.Address `json:"prover"`
-	MaxBlockID    uint64         `json:"maxBlockID"`
-	MaxProposedIn uint64         `json:"maxProposedIn"`
+	SignedPayload []byte             `json:"signedPayload"`
+	Prover        common.Address     `json:"prover"`
+	MaxBlockID    uint64             `json:"maxBlockID"`
+	MaxProposedIn uint64             `json:"maxProposedIn"`
+	Expiry        uint64             `json:"expiry"`
+	TierFees      []encoding.TierFee `json:"tierFees"`
+}
+
+// ProofGasRejection represents the JSON response which will be returned by the ProposeBlock request
+// handler, when the estimated proof verification gas of a requested tier exceeds the prover's threshold.
+type ProofGasRejection struct {
+	Message      string `json:"message"`
+	Tier         uint16 `json:"tier"`
+	EstimatedGas uint64 `json:"estimatedGas"`
+	MaxGas       uint64 `json:"maxGas"`
+}
+
+// TierRejection represents the JSON response which will be returned by the ProposeBlock request
+// handler, when a requested tier is not supported by the prover.
+type TierRejection struct {
+	Message        string   `json:"message"`
+	Tier           uint16   `json:"tier"`
+	SupportedTiers []uint16 `json:"supportedTiers"`
 }
 
 // CreateAssignment handles a block proof assignment request, decides if this prover wants to
@@ -81,6 +416,8 @@ type ProposeBlockResponse struct {
 //
 //	@Summary		Try to accept a block proof assignment
 //	@Param          body        body    CreateAssignmentRequestBody   true    "assignment request body"
+//	@Param          Idempotency-Key    header    string    false    "assignment idempotency key"
+//	@Param          X-Signature    header    string    false    "proposer's signature over the request body"
 //	@Accept			json
 //	@Produce		json
 //	@Success		200		{object} ProposeBlockResponse
@@ -90,6 +427,11 @@ type ProposeBlockResponse struct {
 //	@Failure		422		{string} string	"proof fee too low"
 //	@Failure		422		{string} string "expiry too long"
 //	@Failure		422		{string} string "prover does not have capacity"
+//	@Failure		422		{string} string "replayed request nonce"
+//	@Failure		422		{object} ProofGasRejection
+//	@Failure		422		{object} TierRejection
+//	@Failure		401		{string} string "invalid request signature"
+//	@Failure		403		{string} string "request signer not allowed"
 //	@Router			/assignment [post]
 func (s *ProverServer) CreateAssignment(c echo.Context) error {
 	req := new(CreateAssignmentRequestBody)
@@ -97,10 +439,12 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return c.JSON(http.StatusUnprocessableEntity, err)
 	}
 
-	log.Info(
+	logger := s.logger.With("requestID", c.Response().Header().Get(echo.HeaderXRequestID))
+	logger.Info(
 		"Proof assignment request body",
 		"feeToken", req.FeeToken,
 		"expiry", req.Expiry,
+		"provingWindow", req.ProvingWindow,
 		"tierFees", req.TierFees,
 		"txListHash", req.TxListHash,
 		"currentUsedCapacity", len(s.proofSubmissionCh),
@@ -108,12 +452,30 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 
 	// 1. Check if the request body is valid.
 	if req.TxListHash == (common.Hash{}) {
-		log.Info("Invalid txList hash")
+		logger.Info("Invalid txList hash")
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid txList hash")
 	}
 	if req.FeeToken != (common.Address{}) {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "only receive ETH")
 	}
+	if s.replayGuard != nil {
+		if err := s.replayGuard.check(req.Nonce, req.Timestamp); err != nil {
+			logger.Warn("Rejected proof assignment request", "nonce", req.Nonce, "timestamp", req.Timestamp, "error", err)
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+	}
+
+	for _, tier := range req.TierFees {
+		if tier.Tier != encoding.TierGuardianID && !slices.Contains(s.supportedTiers, tier.Tier) {
+			logger.Warn("Unsupported tier", "tier", tier.Tier, "supportedTiers", s.supportedTiers, "proposerIP", c.RealIP())
+			s.recordRejection(rejectionUnsupportedTier)
+			return c.JSON(http.StatusUnprocessableEntity, &TierRejection{
+				Message:        "unsupported tier",
+				Tier:           tier.Tier,
+				SupportedTiers: s.supportedTiers,
+			})
+		}
+	}
 
 	// 2. Check if the prover has the required minimum on-chain ETH and Taiko token balance.
 	ok, err := s.checkMinEthAndToken(c.Request().Context())
@@ -136,7 +498,7 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 	if !ok {
-		log.Warn(
+		logger.Warn(
 			"Insufficient prover token balance, please get more tokens or wait for verification of the blocks you proved",
 			"prover", s.proverAddress,
 		)
@@ -149,34 +511,53 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 			continue
 		}
 
-		var minTierFee *big.Int
-		switch tier.Tier {
-		case encoding.TierOptimisticID:
-			minTierFee = s.minOptimisticTierFee
-		case encoding.TierSgxID:
-			minTierFee = s.minSgxTierFee
-		case encoding.TierSgxAndZkVMID:
-			minTierFee = s.minSgxAndZkVMTierFee
-		default:
-			log.Warn("Unknown tier", "tier", tier.Tier, "fee", tier.Fee, "proposerIP", c.RealIP())
-			return echo.NewHTTPError(http.StatusUnprocessableEntity, "unknown tier")
+		minTierFee, err := s.minTierFee(c.Request().Context(), tier.Tier)
+		if err != nil {
+			if errors.Is(err, errUnknownTier) {
+				logger.Warn("Unknown tier", "tier", tier.Tier, "fee", tier.Fee, "proposerIP", c.RealIP())
+				s.recordRejection(rejectionUnsupportedTier)
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "unknown tier")
+			}
+			logger.Error("Failed to get minimum tier fee", "tier", tier.Tier, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, err)
 		}
 
 		if tier.Fee.Cmp(minTierFee) < 0 {
-			log.Warn(
+			logger.Warn(
 				"Proof fee too low",
 				"tier", tier.Tier,
 				"fee", tier.Fee,
 				"minTierFee", minTierFee,
 				"proposerIP", c.RealIP(),
 			)
+			s.recordRejection(rejectionFeeTooLow)
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "proof fee too low")
 		}
 	}
 
-	// 5. Check if the expiry is too long.
+	// 5. Check if the estimated proof verification gas is acceptable for each tier.
+	rejection, err := s.checkProofVerificationGas(c.Request().Context(), req.TierFees)
+	if err != nil {
+		logger.Error("Failed to estimate proof verification gas", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+	if rejection != nil {
+		logger.Warn(
+			"Proof verification gas too high",
+			"tier", rejection.Tier,
+			"estimatedGas", rejection.EstimatedGas,
+			"maxGas", rejection.MaxGas,
+			"proposerIP", c.RealIP(),
+		)
+		return c.JSON(http.StatusUnprocessableEntity, rejection)
+	}
+
+	// 6. Check if the expiry is too long.
+	if req.Expiry == 0 {
+		req.Expiry = s.negotiateExpiry(req.ProvingWindow)
+	}
 	if req.Expiry > uint64(time.Now().Add(s.maxExpiry).Unix()) {
-		log.Warn(
+		logger.Warn(
 			"Expiry too long",
 			"requestExpiry", req.Expiry,
 			"srvMaxExpiry", s.maxExpiry,
@@ -185,46 +566,165 @@ func (s *ProverServer) CreateAssignment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "expiry too long")
 	}
 
-	// 6. Check if the prover has any capacity now.
+	// 7. Check if the prover has any capacity now.
 	if s.proofSubmissionCh != nil && len(s.proofSubmissionCh) == cap(s.proofSubmissionCh) {
-		log.Warn("Prover does not have capacity", "capacity", cap(s.proofSubmissionCh))
+		logger.Warn("Prover does not have capacity", "capacity", cap(s.proofSubmissionCh))
+		s.recordRejection(rejectionNoCapacity)
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, "prover does not have capacity")
 	}
+	var (
+		capacityID uint64
+		isNew      bool
+	)
+	if s.capacityManager != nil {
+		var ok bool
+		if capacityID, isNew, ok = s.capacityManager.TakeOneCapacityWithKey(
+			c.Request().Header.Get(IdempotencyKeyHeader),
+		); !ok {
+			maxCapacity, _ := s.capacityManager.ReadCapacity()
+			logger.Warn("Prover does not have capacity", "capacity", maxCapacity)
+			s.recordRejection(rejectionNoCapacity)
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "prover does not have capacity")
+		}
+	}
 
-	// 7. Encode and sign the prover assignment payload.
+	// 8. Encode and sign the prover assignment payload.
 	l1Head, err := s.rpc.L1.BlockNumber(c.Request().Context())
 	if err != nil {
-		log.Error("Failed to get L1 block head", "error", err)
+		logger.Error("Failed to get L1 block head", "error", err)
+		s.releaseCapacity(capacityID, isNew)
 		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
 	}
+	signed, err := s.signAssignment(req.TxListHash, req.FeeToken, req.Expiry, l1Head+s.maxSlippage, req.TierFees)
+	if err != nil {
+		logger.Error("Failed to sign proverAssignment payload data", "error", err)
+		s.releaseCapacity(capacityID, isNew)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	// The request might be aborted while shutting down the server, release the reserved capacity then.
+	if err := c.Request().Context().Err(); err != nil {
+		logger.Warn("Proof assignment request aborted", "error", err)
+		s.releaseCapacity(capacityID, isNew)
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err)
+	}
+
+	// 9. Return the signed payload.
+	if s.capacityManager != nil {
+		s.capacityManager.SetMetadata(capacityID, &assignmentInfo{
+			txListHash: req.TxListHash,
+			maxBlockID: l1Head + s.maxSlippage,
+			tierFees:   req.TierFees,
+			expiry:     req.Expiry,
+		})
+		s.capacityManager.SetDeadline(capacityID, time.Unix(int64(req.Expiry), 0))
+	}
+	s.recordAcceptance(req.TierFees)
+	return c.JSON(http.StatusOK, &ProposeBlockResponse{
+		SignedPayload: signed,
+		Prover:        s.proverAddress,
+		MaxBlockID:    l1Head + s.maxSlippage,
+		MaxProposedIn: s.maxProposedIn,
+		Expiry:        req.Expiry,
+		TierFees:      req.TierFees,
+	})
+}
+
+// negotiateExpiry returns the assignment expiry for the given desired proving window (in seconds),
+// the window is bounded by the server's MaxExpiry, and MaxExpiry will be used if no window is given.
+func (s *ProverServer) negotiateExpiry(provingWindow uint64) uint64 {
+	window := s.maxExpiry
+	if provingWindow != 0 && provingWindow < uint64(s.maxExpiry.Seconds()) {
+		window = time.Duration(provingWindow) * time.Second
+	}
+
+	return uint64(time.Now().Add(window).Unix())
+}
+
+// signAssignment encodes the prover assignment payload in the `AssignmentHook` format and signs
+// it with the prover's private key.
+func (s *ProverServer) signAssignment(
+	txListHash common.Hash,
+	feeToken common.Address,
+	expiry uint64,
+	maxBlockID uint64,
+	tierFees []encoding.TierFee,
+) ([]byte, error) {
 	encoded, err := encoding.EncodeProverAssignmentPayload(
 		s.protocolConfigs.ChainId,
 		s.taikoL1Address,
 		s.assignmentHookAddress,
-		req.TxListHash,
-		req.FeeToken,
-		req.Expiry,
-		l1Head+s.maxSlippage,
+		txListHash,
+		feeToken,
+		expiry,
+		maxBlockID,
 		s.maxProposedIn,
-		req.TierFees,
+		tierFees,
 	)
 	if err != nil {
-		log.Error("Failed to encode proverAssignment payload data", "error", err)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+		return nil, err
+	}
+
+	return crypto.Sign(crypto.Keccak256Hash(encoded).Bytes(), s.proverPrivateKey)
+}
+
+// minTierFee returns the minimum proof fee of the given tier, the dynamic minimum proof fee
+// will be used if the MinProofFeeFunc is set.
+func (s *ProverServer) minTierFee(ctx context.Context, tier uint16) (*big.Int, error) {
+	var minTierFee *big.Int
+	switch tier {
+	case encoding.TierOptimisticID:
+		minTierFee = s.minOptimisticTierFee
+	case encoding.TierSgxID:
+		minTierFee = s.minSgxTierFee
+	case encoding.TierSgxAndZkVMID:
+		minTierFee = s.minSgxAndZkVMTierFee
+	default:
+		return nil, errUnknownTier
+	}
+
+	if s.minProofFeeFunc == nil {
+		return minTierFee, nil
 	}
 
-	signed, err := crypto.Sign(crypto.Keccak256Hash(encoded).Bytes(), s.proverPrivateKey)
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	fee, err := s.minProofFeeFunc(ctx, tier)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return nil, err
+	}
+	if fee == nil {
+		return minTierFee, nil
 	}
 
-	// 8. Return the signed payload.
-	return c.JSON(http.StatusOK, &ProposeBlockResponse{
-		SignedPayload: signed,
-		Prover:        s.proverAddress,
-		MaxBlockID:    l1Head + s.maxSlippage,
-		MaxProposedIn: s.maxProposedIn,
-	})
+	return fee, nil
+}
+
+// releaseCapacity releases the reserved capacity with the given ID, if the capacity manager is enabled.
+// Only a reservation newly made by the current request will be released, since a retried request with
+// the same idempotency key gets the reservation of the earlier assignment, which is still outstanding.
+func (s *ProverServer) releaseCapacity(capacityID uint64, isNew bool) {
+	if s.capacityManager != nil && isNew {
+		s.capacityManager.ReleaseOneCapacity(capacityID)
+	}
+}
+
+// CompleteAssignment releases the capacity reserved for the assignment of the given txList hash, once
+// its proof has been submitted, so that it won't be reaped as a missed proving deadline. Returns false
+// if there is no such active assignment.
+func (s *ProverServer) CompleteAssignment(txListHash common.Hash) bool {
+	if s.capacityManager == nil {
+		return false
+	}
+
+	for _, reservation := range s.capacityManager.Reservations() {
+		if info, ok := reservation.Metadata.(*assignmentInfo); ok && info.txListHash == txListHash {
+			return s.capacityManager.ReleaseOneCapacity(reservation.ID)
+		}
+	}
+
+	return false
 }
 
 // checkMinEthAndToken checks if the prover has the required minimum on-chain ETH and Taiko token balance.
@@ -238,14 +738,14 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	log.Info(
+	s.logger.Info(
 		"Prover's ETH balance",
 		"balance", ethBalance,
 		"address", s.proverAddress.Hex(),
 	)
 
 	if ethBalance.Cmp(s.minEthBalance) <= 0 {
-		log.Warn(
+		s.logger.Warn(
 			"Prover does not have required minimum on-chain ETH balance",
 			"providedProver", s.proverAddress.Hex(),
 			"ethBalance", ethBalance,
@@ -260,14 +760,14 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	log.Info(
+	s.logger.Info(
 		"Prover's Taiko token balance",
 		"balance", balance.String(),
 		"address", s.proverAddress.Hex(),
 	)
 
 	if balance.Cmp(s.minTaikoTokenBalance) <= 0 {
-		log.Warn(
+		s.logger.Warn(
 			"Prover does not have required on-chain Taiko token balance",
 			"providedProver", s.proverAddress.Hex(),
 			"taikoTokenBalance", balance,
@@ -278,3 +778,116 @@ func (s *ProverServer) checkMinEthAndToken(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+// checkProofVerificationGas estimates the proof verification gas of each given tier, and returns a
+// rejection if any of them exceeds the prover's MaxProofVerificationGas.
+func (s *ProverServer) checkProofVerificationGas(
+	ctx context.Context,
+	tierFees []encoding.TierFee,
+) (*ProofGasRejection, error) {
+	if s.maxVerifyProofGas == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	for _, tier := range tierFees {
+		if tier.Tier == encoding.TierGuardianID {
+			continue
+		}
+
+		var (
+			gas uint64
+			err error
+		)
+		if s.verifyProofGasFunc != nil {
+			gas, err = s.verifyProofGasFunc(ctx, tier.Tier)
+		} else {
+			gas, err = s.estimateProofVerificationGas(ctx, tier.Tier)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if gas > s.maxVerifyProofGas {
+			return &ProofGasRejection{
+				Message:      "proof verification gas too high",
+				Tier:         tier.Tier,
+				EstimatedGas: gas,
+				MaxGas:       s.maxVerifyProofGas,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// estimateProofVerificationGas returns the gas of verifying a proof of the given tier, the configured
+// ProofVerificationGas will be used if there is one, otherwise it's estimated on chain, and the estimated
+// gas will be cached for proofVerificationGasCacheTTL.
+func (s *ProverServer) estimateProofVerificationGas(ctx context.Context, tierID uint16) (uint64, error) {
+	if gas, ok := s.verifyProofGas[tierID]; ok {
+		return gas, nil
+	}
+
+	s.verifyProofGasMutex.Lock()
+	defer s.verifyProofGasMutex.Unlock()
+
+	if cached, ok := s.verifyProofGasCache[tierID]; ok && time.Since(cached.estimatedAt) < proofVerificationGasCacheTTL {
+		return cached.gas, nil
+	}
+
+	gas, err := s.estimateProofVerificationGasOnChain(ctx, tierID)
+	if err != nil {
+		return 0, err
+	}
+	s.verifyProofGasCache[tierID] = &estimatedGas{gas: gas, estimatedAt: time.Now()}
+
+	return gas, nil
+}
+
+// estimateProofVerificationGasOnChain estimates the gas of verifying a proof of the given tier, by
+// estimating a `verifyProof` call with an empty proof from TaikoL1 to the tier's verifier contract,
+// the tiers without a verifier contract cost no verification gas.
+func (s *ProverServer) estimateProofVerificationGasOnChain(ctx context.Context, tierID uint16) (uint64, error) {
+	tiers, err := s.rpc.GetTiers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tier := range tiers {
+		if tier.ID != tierID {
+			continue
+		}
+		if tier.VerifierName == [32]byte{} {
+			return 0, nil
+		}
+
+		verifier, err := s.rpc.TaikoL1.Resolve0(&bind.CallOpts{Context: ctx}, tier.VerifierName, true)
+		if err != nil {
+			return 0, err
+		}
+		if verifier == (common.Address{}) {
+			return 0, nil
+		}
+
+		verifierABI, err := bindings.SgxVerifierMetaData.GetAbi()
+		if err != nil {
+			return 0, err
+		}
+		data, err := verifierABI.Pack(
+			"verifyProof",
+			bindings.IVerifierContext{Prover: s.proverAddress, MsgSender: s.proverAddress},
+			bindings.TaikoDataTransition{},
+			bindings.TaikoDataTierProof{Tier: tierID},
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		return s.rpc.L1.EstimateGas(ctx, ethereum.CallMsg{From: s.taikoL1Address, To: &verifier, Data: data})
+	}
+
+	return 0, errUnknownTier
+}
@@ -1,15 +1,34 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
+	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
 
+	"github.com/taikoxyz/taiko-client/bindings"
 	"github.com/taikoxyz/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	capacitymanager "github.com/taikoxyz/taiko-client/prover/capacity_manager"
+	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
 func (s *ProverServerTestSuite) TestGetStatusSuccess() {
@@ -27,6 +46,27 @@ func (s *ProverServerTestSuite) TestGetStatusSuccess() {
 	s.Equal(s.s.minSgxTierFee.Uint64(), status.MinSgxTierFee)
 	s.Equal(uint64(s.s.maxExpiry.Seconds()), status.MaxExpiry)
 	s.NotEmpty(status.Prover)
+	s.Equal(uint64(cap(s.s.proofSubmissionCh)), status.TotalCapacity)
+	s.Equal(uint64(len(s.s.proofSubmissionCh)), status.UsedCapacity)
+	s.Equal(status.TotalCapacity-status.UsedCapacity, status.AvailableCapacity)
+}
+
+func (s *ProverServerTestSuite) TestGetStatusCapacity() {
+	s.s.proofSubmissionCh <- proofProducer.ProofRequestBody{}
+
+	res := s.sendReq("/status")
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	status := new(Status)
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.Nil(json.Unmarshal(b, &status))
+
+	s.Equal(uint64(1024), status.TotalCapacity)
+	s.Equal(uint64(1), status.UsedCapacity)
+	s.Equal(uint64(1023), status.AvailableCapacity)
 }
 
 func (s *ProverServerTestSuite) TestProposeBlockSuccess() {
@@ -48,3 +88,754 @@ func (s *ProverServerTestSuite) TestProposeBlockSuccess() {
 	s.Nil(err)
 	s.Contains(string(b), "signedPayload")
 }
+
+func (s *ProverServerTestSuite) TestProposeBlockOverloaded() {
+	var healthy atomic.Bool
+	capacityManager := s.s.capacityManager
+	s.s.capacityManager = capacitymanager.New(2, time.Minute)
+	s.s.capacityManager.SetHealthProbe(func() (bool, float64) { return healthy.Load(), 0.5 })
+	defer func() { s.s.capacityManager = capacityManager }()
+
+	propose := func() *http.Response {
+		data, err := json.Marshal(CreateAssignmentRequestBody{
+			FeeToken: (common.Address{}),
+			TierFees: []encoding.TierFee{
+				{Tier: encoding.TierOptimisticID, Fee: common.Big256},
+				{Tier: encoding.TierSgxID, Fee: common.Big256},
+			},
+			Expiry:     uint64(time.Now().Add(time.Minute).Unix()),
+			TxListHash: common.BigToHash(common.Big1),
+		})
+		s.Nil(err)
+		res, err := http.Post(s.testServer.URL+"/assignment", "application/json", strings.NewReader(string(data)))
+		s.Nil(err)
+		s.Nil(res.Body.Close())
+		return res
+	}
+
+	// The assignments are rejected while the proving backend is unhealthy.
+	s.Equal(http.StatusUnprocessableEntity, propose().StatusCode)
+
+	// Only half of the capacity is available while the proving backend is overloaded.
+	healthy.Store(true)
+	s.Equal(http.StatusOK, propose().StatusCode)
+	s.Equal(http.StatusUnprocessableEntity, propose().StatusCode)
+}
+
+func (s *ProverServerTestSuite) TestGetAssignments() {
+	capacityManager := s.s.capacityManager
+	s.s.capacityManager = capacitymanager.New(2, time.Minute)
+	defer func() { s.s.capacityManager = capacityManager }()
+
+	tierFees := []encoding.TierFee{
+		{Tier: encoding.TierOptimisticID, Fee: common.Big256},
+		{Tier: encoding.TierSgxID, Fee: common.Big256},
+	}
+	expiry := uint64(time.Now().Add(time.Minute).Unix())
+	data, err := json.Marshal(CreateAssignmentRequestBody{
+		FeeToken:   (common.Address{}),
+		TierFees:   tierFees,
+		Expiry:     expiry,
+		TxListHash: common.BigToHash(common.Big1),
+	})
+	s.Nil(err)
+	res, err := http.Post(s.testServer.URL+"/assignment", "application/json", strings.NewReader(string(data)))
+	s.Nil(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+	proposeBlockRes := new(ProposeBlockResponse)
+	s.Nil(json.NewDecoder(res.Body).Decode(proposeBlockRes))
+	s.Nil(res.Body.Close())
+
+	res = s.sendReq("/assignments")
+	s.Equal(http.StatusOK, res.StatusCode)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	s.Nil(err)
+	s.NotContains(string(b), "signedPayload")
+
+	var assignments []*ActiveAssignment
+	s.Nil(json.Unmarshal(b, &assignments))
+	s.Len(assignments, 1)
+	s.Equal(proposeBlockRes.MaxBlockID, assignments[0].MaxBlockID)
+	s.Equal(expiry, assignments[0].Deadline)
+	s.Equal(tierFees, assignments[0].TierFees)
+	s.NotZero(assignments[0].ReservedAt)
+}
+
+func TestGetAssignments(t *testing.T) {
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 2
+	})
+
+	getAssignments := func() []*ActiveAssignment {
+		res, err := http.Get(testServer.URL + "/assignments")
+		require.Nil(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var assignments []*ActiveAssignment
+		require.Nil(t, json.NewDecoder(res.Body).Decode(&assignments))
+		return assignments
+	}
+	require.Empty(t, getAssignments())
+
+	// A reservation which hasn't been signed yet is not listed.
+	pendingID, ok := srv.capacityManager.TakeOneCapacity()
+	require.True(t, ok)
+	require.Empty(t, getAssignments())
+
+	id, ok := srv.capacityManager.TakeOneCapacity()
+	require.True(t, ok)
+	tierFees := []encoding.TierFee{{Tier: encoding.TierOptimisticID, Fee: common.Big2}}
+	require.True(t, srv.capacityManager.SetMetadata(id, &assignmentInfo{
+		maxBlockID: 10,
+		tierFees:   tierFees,
+		expiry:     100,
+	}))
+
+	assignments := getAssignments()
+	require.Len(t, assignments, 1)
+	require.Equal(t, id, assignments[0].ReservationID)
+	require.Equal(t, uint64(10), assignments[0].MaxBlockID)
+	require.Equal(t, tierFees, assignments[0].TierFees)
+	require.Equal(t, uint64(100), assignments[0].Deadline)
+	require.NotZero(t, assignments[0].ReservedAt)
+
+	// Released assignments are removed from the list.
+	require.True(t, srv.capacityManager.ReleaseOneCapacity(id))
+	require.True(t, srv.capacityManager.ReleaseOneCapacity(pendingID))
+	require.Empty(t, getAssignments())
+}
+
+func TestReleaseCapacityRetriedRequest(t *testing.T) {
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 1
+	})
+
+	id, isNew, ok := srv.capacityManager.TakeOneCapacityWithKey("block-1")
+	require.True(t, ok)
+	require.True(t, isNew)
+
+	// A failed retry must not release the reservation of the earlier assignment.
+	retriedID, isNew, ok := srv.capacityManager.TakeOneCapacityWithKey("block-1")
+	require.True(t, ok)
+	require.Equal(t, id, retriedID)
+	srv.releaseCapacity(retriedID, isNew)
+	_, used := srv.capacity()
+	require.Equal(t, uint64(1), used)
+
+	srv.releaseCapacity(id, true)
+	_, used = srv.capacity()
+	require.Zero(t, used)
+}
+
+func TestWaitCapacity(t *testing.T) {
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 1
+	})
+
+	waitCapacity := func(timeout string) (int, *AvailableCapacity) {
+		res, err := http.Get(testServer.URL + "/wait-capacity?timeout=" + timeout)
+		require.Nil(t, err)
+		defer res.Body.Close()
+
+		available := new(AvailableCapacity)
+		if res.StatusCode == http.StatusOK {
+			require.Nil(t, json.NewDecoder(res.Body).Decode(available))
+		}
+		return res.StatusCode, available
+	}
+
+	// Returns immediately when there is available capacity.
+	status, available := waitCapacity("10")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, uint64(1), available.AvailableCapacity)
+
+	id, ok := srv.capacityManager.TakeOneCapacity()
+	require.True(t, ok)
+
+	status, _ = waitCapacity("1")
+	require.Equal(t, http.StatusNoContent, status)
+	status, _ = waitCapacity("invalid")
+	require.Equal(t, http.StatusBadRequest, status)
+
+	// The slot is released in the middle of the waiting.
+	time.AfterFunc(100*time.Millisecond, func() { srv.capacityManager.ReleaseOneCapacity(id) })
+	start := time.Now()
+	status, available = waitCapacity("10")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, uint64(1), available.AvailableCapacity)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestGetStatusDynamicMinProofFee(t *testing.T) {
+	var fee atomic.Uint64
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.MinProofFeeFunc = func(_ context.Context, tier uint16) (*big.Int, error) {
+			if tier != encoding.TierOptimisticID {
+				return nil, nil
+			}
+			return new(big.Int).SetUint64(fee.Add(100)), nil
+		}
+	})
+
+	for _, expected := range []uint64{100, 200, 300} {
+		res, err := http.Get(testServer.URL + "/status")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		status := new(Status)
+		b, err := io.ReadAll(res.Body)
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		require.Nil(t, json.Unmarshal(b, &status))
+
+		require.Equal(t, expected, status.MinOptimisticTierFee)
+		require.Equal(t, uint64(1), status.MinSgxTierFee)
+		require.Equal(t, uint64(1), status.MinSgxAndZkVMTierFee)
+	}
+}
+
+func TestSignAssignment(t *testing.T) {
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.TaikoL1Address = common.BigToAddress(common.Big1)
+		opts.AssignmentHookAddress = common.BigToAddress(common.Big2)
+		opts.ProtocolConfigs = &bindings.TaikoDataConfig{ChainId: 167001}
+	})
+
+	var (
+		txListHash = common.BigToHash(common.Big1)
+		expiry     = srv.negotiateExpiry(0)
+		maxBlockID = uint64(100)
+		tierFees   = []encoding.TierFee{
+			{Tier: encoding.TierOptimisticID, Fee: common.Big256},
+			{Tier: encoding.TierSgxID, Fee: common.Big256},
+		}
+	)
+
+	signed, err := srv.signAssignment(txListHash, common.Address{}, expiry, maxBlockID, tierFees)
+	require.Nil(t, err)
+
+	payload, err := encoding.EncodeProverAssignmentPayload(
+		167001,
+		common.BigToAddress(common.Big1),
+		common.BigToAddress(common.Big2),
+		txListHash,
+		common.Address{},
+		expiry,
+		maxBlockID,
+		srv.maxProposedIn,
+		tierFees,
+	)
+	require.Nil(t, err)
+
+	pubKey, err := crypto.SigToPub(crypto.Keccak256Hash(payload).Bytes(), signed)
+	require.Nil(t, err)
+	require.Equal(t, srv.proverAddress, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestNegotiateExpiry(t *testing.T) {
+	srv := &ProverServer{maxExpiry: time.Hour}
+
+	now := uint64(time.Now().Unix())
+	require.InDelta(t, now+60, srv.negotiateExpiry(60), 1)
+	require.InDelta(t, now+3600, srv.negotiateExpiry(0), 1)
+	require.InDelta(t, now+3600, srv.negotiateExpiry(2*3600), 1)
+}
+
+func TestCreateAssignmentStaleRequest(t *testing.T) {
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.MaxRequestSkew = time.Minute
+	})
+
+	for _, body := range []*CreateAssignmentRequestBody{
+		{TxListHash: common.BigToHash(common.Big1), Timestamp: uint64(time.Now().Unix())},
+		{
+			TxListHash: common.BigToHash(common.Big1),
+			Nonce:      "0x01",
+			Timestamp:  uint64(time.Now().Add(-time.Hour).Unix()),
+		},
+	} {
+		b, err := json.Marshal(body)
+		require.Nil(t, err)
+
+		res, err := http.Post(testServer.URL+"/assignment", echo.MIMEApplicationJSON, strings.NewReader(string(b)))
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	}
+}
+
+func TestCheckProofVerificationGas(t *testing.T) {
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.MaxProofVerificationGas = 300_000
+		opts.ProofVerificationGasFunc = func(_ context.Context, tier uint16) (uint64, error) {
+			if tier == encoding.TierSgxAndZkVMID {
+				return 500_000, nil
+			}
+			return 200_000, nil
+		}
+	})
+
+	// A low estimated gas should be accepted.
+	rejection, err := srv.checkProofVerificationGas(context.Background(), []encoding.TierFee{
+		{Tier: encoding.TierOptimisticID, Fee: common.Big1},
+		{Tier: encoding.TierSgxID, Fee: common.Big1},
+	})
+	require.Nil(t, err)
+	require.Nil(t, rejection)
+
+	// A high estimated gas should be rejected, with the estimated gas.
+	rejection, err = srv.checkProofVerificationGas(context.Background(), []encoding.TierFee{
+		{Tier: encoding.TierSgxID, Fee: common.Big1},
+		{Tier: encoding.TierSgxAndZkVMID, Fee: common.Big1},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, rejection)
+	require.Equal(t, encoding.TierSgxAndZkVMID, rejection.Tier)
+	require.Equal(t, uint64(500_000), rejection.EstimatedGas)
+	require.Equal(t, uint64(300_000), rejection.MaxGas)
+
+	// The check is disabled without a threshold.
+	srv.maxVerifyProofGas = 0
+	rejection, err = srv.checkProofVerificationGas(context.Background(), []encoding.TierFee{
+		{Tier: encoding.TierSgxAndZkVMID, Fee: common.Big1},
+	})
+	require.Nil(t, err)
+	require.Nil(t, rejection)
+}
+
+// testVerifierService is a mocked `eth` namespace JSON-RPC service, serving the TaikoL1 `resolve` and the
+// TierProvider calls, and estimating the `verifyProof` calls against the tier verifiers.
+type testVerifierService struct {
+	tierProvider common.Address
+	tiers        map[uint16]string
+	verifiers    map[string]common.Address
+	// estimateGas estimates the `verifyProof` call against the given verifier.
+	estimateGas func(verifier common.Address) (uint64, error)
+	calls       atomic.Int64
+	estimates   atomic.Int64
+}
+
+// ChainId implements the `eth_chainId` RPC method.
+func (s *testVerifierService) ChainId() (*hexutil.Big, error) { // nolint: revive,stylecheck
+	return (*hexutil.Big)(common.Big1), nil
+}
+
+// Call implements the `eth_call` RPC method.
+func (s *testVerifierService) Call(args map[string]interface{}, _ gethRPC.BlockNumberOrHash) (hexutil.Bytes, error) {
+	s.calls.Add(1)
+
+	taikoL1ABI, err := bindings.TaikoL1ClientMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	tierProviderABI, err := bindings.TierProviderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	input := common.FromHex(args["input"].(string))
+	for _, method := range []abi.Method{
+		taikoL1ABI.Methods["resolve0"],
+		tierProviderABI.Methods["getTierIds"],
+		tierProviderABI.Methods["getTier"],
+	} {
+		if !bytes.HasPrefix(input, method.ID) {
+			continue
+		}
+		params, err := method.Inputs.Unpack(input[4:])
+		if err != nil {
+			return nil, err
+		}
+
+		switch method.Name {
+		case "resolve0":
+			name := params[0].([32]byte)
+			if name == rpc.StringToBytes32("tier_provider") {
+				return method.Outputs.Pack(s.tierProvider)
+			}
+			return method.Outputs.Pack(s.verifiers[string(bytes.TrimRight(name[:], "\x00"))])
+		case "getTierIds":
+			var ids []uint16
+			for id := range s.tiers {
+				ids = append(ids, id)
+			}
+			return method.Outputs.Pack(ids)
+		default:
+			return method.Outputs.Pack(bindings.ITierProviderTier{
+				VerifierName:   rpc.StringToBytes32(s.tiers[params[0].(uint16)]),
+				ValidityBond:   common.Big0,
+				ContestBond:    common.Big0,
+				CooldownWindow: common.Big0,
+			})
+		}
+	}
+
+	return nil, errors.New("unexpected call")
+}
+
+// EstimateGas implements the `eth_estimateGas` RPC method.
+func (s *testVerifierService) EstimateGas(
+	args map[string]interface{},
+	_ *gethRPC.BlockNumberOrHash,
+) (hexutil.Uint64, error) {
+	s.estimates.Add(1)
+
+	gas, err := s.estimateGas(common.HexToAddress(args["to"].(string)))
+	return hexutil.Uint64(gas), err
+}
+
+func TestEstimateProofVerificationGas(t *testing.T) {
+	var (
+		optimisticVerifier = common.HexToAddress("0x0a")
+		sgxVerifier        = common.HexToAddress("0x0b")
+		service            = &testVerifierService{
+			tierProvider: common.HexToAddress("0x01"),
+			tiers: map[uint16]string{
+				encoding.TierOptimisticID: "tier_optimistic",
+				encoding.TierSgxID:        "tier_sgx",
+			},
+			verifiers: map[string]common.Address{"tier_optimistic": optimisticVerifier, "tier_sgx": sgxVerifier},
+			// The SGX verifier rejects a dummy proof.
+			estimateGas: func(verifier common.Address) (uint64, error) {
+				if verifier == sgxVerifier {
+					return 0, errors.New("execution reverted: SGX_INVALID_PROOF")
+				}
+				return 50_000, nil
+			},
+		}
+	)
+
+	server := gethRPC.NewServer()
+	require.Nil(t, server.RegisterName("eth", service))
+	backend := httptest.NewServer(server)
+	t.Cleanup(func() {
+		backend.Close()
+		server.Stop()
+	})
+
+	l1, err := rpc.NewEthClient(context.Background(), backend.URL, 0)
+	require.Nil(t, err)
+	taikoL1, err := bindings.NewTaikoL1Client(common.HexToAddress("0x02"), l1)
+	require.Nil(t, err)
+
+	newServer := func(verificationGas map[uint16]uint64) (*ProverServer, error) {
+		privKey, err := crypto.GenerateKey()
+		require.Nil(t, err)
+
+		return New(&NewProverServerOpts{
+			ProverPrivateKey:        privKey,
+			MaxExpiry:               time.Hour,
+			MaxProofVerificationGas: 300_000,
+			ProofVerificationGas:    verificationGas,
+			SupportedTiers:          []uint16{encoding.TierOptimisticID, encoding.TierSgxID},
+			RPC:                     &rpc.Client{L1: l1, TaikoL1: taikoL1},
+		})
+	}
+
+	// The SGX verification gas must be configured.
+	_, err = newServer(nil)
+	require.ErrorIs(t, err, errMissingProofVerificationGas)
+
+	srv, err := newServer(map[uint16]uint64{encoding.TierSgxID: 250_000})
+	require.Nil(t, err)
+	tierFees := []encoding.TierFee{
+		{Tier: encoding.TierOptimisticID, Fee: common.Big1},
+		{Tier: encoding.TierSgxID, Fee: common.Big1},
+	}
+	for i := 0; i < 2; i++ {
+		rejection, err := srv.checkProofVerificationGas(context.Background(), tierFees)
+		require.Nil(t, err)
+		require.Nil(t, rejection)
+	}
+
+	// Only the optimistic tier is estimated on chain, and the estimation is cached.
+	require.Equal(t, int64(1), service.estimates.Load())
+	calls := service.calls.Load()
+	_, err = srv.checkProofVerificationGas(context.Background(), tierFees)
+	require.Nil(t, err)
+	require.Equal(t, calls, service.calls.Load())
+
+	// The configured verification gas is checked against the threshold as well.
+	srv, err = newServer(map[uint16]uint64{encoding.TierSgxID: 400_000})
+	require.Nil(t, err)
+	rejection, err := srv.checkProofVerificationGas(context.Background(), tierFees)
+	require.Nil(t, err)
+	require.NotNil(t, rejection)
+	require.Equal(t, encoding.TierSgxID, rejection.Tier)
+	require.Equal(t, uint64(400_000), rejection.EstimatedGas)
+}
+
+func TestGetStatusHealthProbe(t *testing.T) {
+	var healthy atomic.Bool
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 4
+		opts.HealthProbe = func() (bool, float64) { return healthy.Load(), 0.5 }
+	})
+
+	getStatus := func() *Status {
+		res, err := http.Get(testServer.URL + "/status")
+		require.Nil(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		status := new(Status)
+		require.Nil(t, json.NewDecoder(res.Body).Decode(status))
+		return status
+	}
+
+	// No capacity is advertised, and no assignment can take a slot, while the backend reports unhealthy.
+	require.Zero(t, getStatus().TotalCapacity)
+	_, ok := srv.capacityManager.TakeOneCapacity()
+	require.False(t, ok)
+
+	// The capacity is halved while the backend reports overloaded.
+	healthy.Store(true)
+	for i := 0; i < 3; i++ {
+		_, ok = srv.capacityManager.TakeOneCapacity()
+		require.Equal(t, i < 2, ok)
+	}
+	status := getStatus()
+	require.Equal(t, uint64(2), status.TotalCapacity)
+	require.Equal(t, uint64(2), status.UsedCapacity)
+	require.Zero(t, status.AvailableCapacity)
+
+	// The used capacity might exceed the scaled down capacity.
+	healthy.Store(false)
+	status = getStatus()
+	require.Zero(t, status.TotalCapacity)
+	require.Equal(t, uint64(2), status.UsedCapacity)
+	require.Zero(t, status.AvailableCapacity)
+}
+
+func TestCreateAssignmentUnsupportedTier(t *testing.T) {
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.SupportedTiers = []uint16{encoding.TierSgxID}
+	})
+
+	res, err := http.Get(testServer.URL + "/tiers")
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var tiers SupportedTiers
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&tiers))
+	require.Equal(t, []uint16{encoding.TierSgxID}, tiers.Tiers)
+
+	b, err := json.Marshal(&CreateAssignmentRequestBody{
+		TxListHash: common.BigToHash(common.Big1),
+		TierFees: []encoding.TierFee{
+			{Tier: encoding.TierSgxID, Fee: common.Big1},
+			{Tier: encoding.TierSgxAndZkVMID, Fee: common.Big1},
+		},
+	})
+	require.Nil(t, err)
+
+	res, err = http.Post(testServer.URL+"/assignment", echo.MIMEApplicationJSON, strings.NewReader(string(b)))
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+
+	var rejection TierRejection
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&rejection))
+	require.Equal(t, encoding.TierSgxAndZkVMID, rejection.Tier)
+	require.Equal(t, []uint16{encoding.TierSgxID}, rejection.SupportedTiers)
+}
+
+func TestNewUnknownSupportedTier(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	_, err = New(&NewProverServerOpts{
+		ProverPrivateKey: privKey,
+		MaxExpiry:        time.Hour,
+		SupportedTiers:   []uint16{encoding.TierSgxID, 12345},
+	})
+	require.ErrorIs(t, err, errUnknownTier)
+
+	srv, _ := newTestServer(t, nil)
+	require.Equal(t, defaultSupportedTiers, srv.supportedTiers)
+}
+
+// testTokenBackend is a contract backend serving the TaikoToken `balanceOf` and `allowance` calls.
+type testTokenBackend struct {
+	bind.ContractBackend
+	balance   *big.Int
+	allowance *big.Int
+	calls     atomic.Int64
+}
+
+func (b *testTokenBackend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (b *testTokenBackend) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	b.calls.Add(1)
+
+	tokenABI, err := bindings.TaikoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(call.Data, tokenABI.Methods["balanceOf"].ID):
+		return common.LeftPadBytes(b.balance.Bytes(), 32), nil
+	case bytes.HasPrefix(call.Data, tokenABI.Methods["allowance"].ID):
+		return common.LeftPadBytes(b.allowance.Bytes(), 32), nil
+	}
+	return nil, errors.New("unexpected call")
+}
+
+func TestGetBond(t *testing.T) {
+	backend := &testTokenBackend{balance: big.NewInt(500), allowance: big.NewInt(1000)}
+	taikoToken, err := bindings.NewTaikoToken(common.HexToAddress("0x01"), backend)
+	require.Nil(t, err)
+
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 1
+		opts.LivenessBond = big.NewInt(250)
+		opts.RPC = &rpc.Client{TaikoToken: taikoToken}
+	})
+
+	getBond := func() *BondStatus {
+		res, err := http.Get(testServer.URL + "/bond")
+		require.Nil(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		status := new(BondStatus)
+		require.Nil(t, json.NewDecoder(res.Body).Decode(status))
+		return status
+	}
+
+	status := getBond()
+	require.Equal(t, srv.proverAddress, status.Prover)
+	require.Equal(t, big.NewInt(500), status.Balance)
+	require.Equal(t, big.NewInt(1000), status.Allowance)
+	require.Equal(t, big.NewInt(250), status.RequiredBond)
+	require.True(t, status.Bonded)
+	require.Equal(t, int64(2), backend.calls.Load())
+
+	// The cached result should be returned within the TTL.
+	backend.balance = big.NewInt(100)
+	require.True(t, getBond().Bonded)
+	require.Equal(t, int64(2), backend.calls.Load())
+
+	// Expire the cache, the balance is no longer enough for the liveness bond.
+	srv.bondMutex.Lock()
+	srv.bondCheckedAt = time.Now().Add(-bondStatusCacheTTL)
+	srv.bondMutex.Unlock()
+	status = getBond()
+	require.Equal(t, big.NewInt(100), status.Balance)
+	require.False(t, status.Bonded)
+	require.Equal(t, int64(4), backend.calls.Load())
+}
+
+func TestAssignmentJSONRoundTrip(t *testing.T) {
+	req := &CreateAssignmentRequestBody{
+		FeeToken:      common.BigToAddress(common.Big3),
+		TierFees:      []encoding.TierFee{{Tier: encoding.TierOptimisticID, Fee: common.Big256}},
+		Expiry:        uint64(time.Now().Add(time.Hour).Unix()),
+		ProvingWindow: 600,
+		TxListHash:    common.BigToHash(common.Big1),
+		Nonce:         "nonce",
+		Timestamp:     uint64(time.Now().Unix()),
+	}
+	data, err := json.Marshal(req)
+	require.Nil(t, err)
+	require.Contains(t, string(data), `"txListHash":"`+req.TxListHash.Hex()+`"`)
+
+	decoded := new(CreateAssignmentRequestBody)
+	require.Nil(t, json.Unmarshal(data, decoded))
+	require.Equal(t, req, decoded)
+
+	// The untagged field names sent by the older clients.
+	legacy := new(CreateAssignmentRequestBody)
+	require.Nil(t, json.Unmarshal([]byte(`{"TxListHash":"`+req.TxListHash.Hex()+`","Expiry":1}`), legacy))
+	require.Equal(t, req.TxListHash, legacy.TxListHash)
+	require.Equal(t, uint64(1), legacy.Expiry)
+
+	// The signature should still be verifiable after the response round trip.
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.TaikoL1Address = common.BigToAddress(common.Big1)
+		opts.AssignmentHookAddress = common.BigToAddress(common.Big2)
+		opts.ProtocolConfigs = &bindings.TaikoDataConfig{ChainId: 167001}
+	})
+
+	signed, err := srv.signAssignment(req.TxListHash, req.FeeToken, req.Expiry, 100, req.TierFees)
+	require.Nil(t, err)
+	data, err = json.Marshal(&ProposeBlockResponse{
+		SignedPayload: signed,
+		Prover:        srv.proverAddress,
+		MaxBlockID:    100,
+		MaxProposedIn: srv.maxProposedIn,
+		Expiry:        req.Expiry,
+		TierFees:      req.TierFees,
+	})
+	require.Nil(t, err)
+
+	res := new(ProposeBlockResponse)
+	require.Nil(t, json.Unmarshal(data, res))
+	payload, err := encoding.EncodeProverAssignmentPayload(
+		167001,
+		common.BigToAddress(common.Big1),
+		common.BigToAddress(common.Big2),
+		req.TxListHash,
+		req.FeeToken,
+		res.Expiry,
+		res.MaxBlockID,
+		res.MaxProposedIn,
+		res.TierFees,
+	)
+	require.Nil(t, err)
+	pubKey, err := crypto.SigToPub(crypto.Keccak256Hash(payload).Bytes(), res.SignedPayload)
+	require.Nil(t, err)
+	require.Equal(t, res.Prover, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestEstimateProofTime(t *testing.T) {
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 4
+		opts.ProofTimeModel = NewLinearProofTimeModel(10*time.Second, 2*time.Second, time.Second)
+	})
+
+	estimate := func(body *EstimateProofTimeRequestBody) *http.Response {
+		b, err := json.Marshal(body)
+		require.Nil(t, err)
+		res, err := http.Post(testServer.URL+"/estimate", echo.MIMEApplicationJSON, bytes.NewReader(b))
+		require.Nil(t, err)
+		return res
+	}
+	block := &EstimateProofTimeRequestBody{BlockID: 1, GasUsed: 3_000_000, TxCount: 4, Tier: encoding.TierSgxID}
+
+	// 10s + 3 * 2s + 4 * 1s, with an empty queue.
+	res := estimate(block)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	result := new(ProofTimeEstimate)
+	require.Nil(t, json.NewDecoder(res.Body).Decode(result))
+	require.Equal(t, &ProofTimeEstimate{ProvingTime: 20, QueueDepth: 0, EstimatedTime: 20}, result)
+
+	// The queued blocks delay the proving.
+	for i := 0; i < 2; i++ {
+		_, ok := srv.capacityManager.TakeOneCapacity()
+		require.True(t, ok)
+	}
+	res = estimate(block)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	result = new(ProofTimeEstimate)
+	require.Nil(t, json.NewDecoder(res.Body).Decode(result))
+	require.Equal(t, &ProofTimeEstimate{ProvingTime: 20, QueueDepth: 2, EstimatedTime: 60}, result)
+
+	// An unsupported tier.
+	res = estimate(&EstimateProofTimeRequestBody{BlockID: 1, Tier: 500})
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+
+	// No proving time model is configured.
+	srv.proofTimeModel = nil
+	res = estimate(block)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+// The reasons of the rejected assignment requests recorded in the metrics.
+const (
+	rejectionFeeTooLow       = "feeTooLow"
+	rejectionUnsupportedTier = "unsupportedTier"
+	rejectionNoCapacity      = "noCapacity"
+)
+
+// recordAcceptance records an accepted assignment request and its quoted tier fees (in gwei) to the
+// metrics registry, the tiers without a fee (i.e. the guardian tier) are skipped.
+func (s *ProverServer) recordAcceptance(tierFees []encoding.TierFee) {
+	metrics.GetOrRegisterCounter("prover/server/assignment/accepted", s.metricsRegistry).Inc(1)
+	for _, tier := range tierFees {
+		if tier.Fee == nil {
+			continue
+		}
+		metrics.GetOrRegisterHistogram(
+			fmt.Sprintf("prover/server/assignment/tier/%d/fee", tier.Tier),
+			s.metricsRegistry,
+			metrics.NewExpDecaySample(1028, 0.015),
+		).Update(new(big.Int).Div(tier.Fee, big.NewInt(params.GWei)).Int64())
+	}
+}
+
+// recordMissedDeadline records an assignment whose reserved capacity was released for missing its proving
+// deadline to the metrics registry.
+func (s *ProverServer) recordMissedDeadline() {
+	metrics.GetOrRegisterCounter("prover/server/assignment/deadline/missed", s.metricsRegistry).Inc(1)
+}
+
+// recordRejection records an assignment request rejected for the given reason to the metrics registry.
+func (s *ProverServer) recordRejection(reason string) {
+	metrics.GetOrRegisterCounter(
+		fmt.Sprintf("prover/server/assignment/rejected/%s", reason),
+		s.metricsRegistry,
+	).Inc(1)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
+)
+
+func TestAssignmentRejectionMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	registry := metrics.NewRegistry()
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.SupportedTiers = []uint16{encoding.TierSgxID}
+		opts.MetricsRegistry = registry
+	})
+
+	b, err := json.Marshal(&CreateAssignmentRequestBody{
+		TxListHash: common.BigToHash(common.Big1),
+		TierFees:   []encoding.TierFee{{Tier: encoding.TierOptimisticID, Fee: common.Big1}},
+	})
+	require.Nil(t, err)
+
+	for i := 0; i < 2; i++ {
+		res, err := http.Post(testServer.URL+"/assignment", echo.MIMEApplicationJSON, strings.NewReader(string(b)))
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	}
+
+	rejected, ok := registry.Get("prover/server/assignment/rejected/unsupportedTier").(metrics.Counter)
+	require.True(t, ok)
+	require.Equal(t, int64(2), rejected.Snapshot().Count())
+	require.Nil(t, registry.Get("prover/server/assignment/rejected/feeTooLow"))
+	require.Nil(t, registry.Get("prover/server/assignment/accepted"))
+
+	// The counters should be exposed by the metrics endpoint.
+	res, err := http.Get(testServer.URL + "/metrics")
+	require.Nil(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.Nil(t, err)
+	require.Contains(t, string(body), "prover_server_assignment_rejected_unsupportedTier 2")
+}
+
+func TestRecordAcceptance(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	srv := &ProverServer{metricsRegistry: metrics.NewRegistry()}
+	srv.recordAcceptance([]encoding.TierFee{
+		{Tier: encoding.TierSgxID, Fee: new(big.Int).Mul(big.NewInt(3), big.NewInt(params.GWei))},
+	})
+	srv.recordAcceptance([]encoding.TierFee{
+		{Tier: encoding.TierSgxID, Fee: new(big.Int).Mul(big.NewInt(5), big.NewInt(params.GWei))},
+		// The guardian tier fee is not checked, so it might be missing.
+		{Tier: encoding.TierGuardianID},
+	})
+
+	accepted, ok := srv.metricsRegistry.Get("prover/server/assignment/accepted").(metrics.Counter)
+	require.True(t, ok)
+	require.Equal(t, int64(2), accepted.Snapshot().Count())
+	require.Nil(t, srv.metricsRegistry.Get(fmt.Sprintf("prover/server/assignment/tier/%d/fee", encoding.TierGuardianID)))
+
+	fees, ok := srv.metricsRegistry.Get("prover/server/assignment/tier/200/fee").(metrics.Histogram)
+	require.True(t, ok)
+	require.Equal(t, int64(2), fees.Snapshot().Count())
+	require.Equal(t, int64(3), fees.Snapshot().Min())
+	require.Equal(t, int64(5), fees.Snapshot().Max())
+}
+
+func TestMissedDeadlineMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	registry := metrics.NewRegistry()
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Capacity = 1
+		opts.MetricsRegistry = registry
+	})
+
+	// The assignment whose proof is submitted on time doesn't count as missed.
+	txListHash := common.HexToHash("0x01")
+	id, ok := srv.capacityManager.TakeOneCapacity()
+	require.True(t, ok)
+	require.True(t, srv.capacityManager.SetMetadata(id, &assignmentInfo{txListHash: txListHash}))
+	require.True(t, srv.capacityManager.SetDeadline(id, time.Now().Add(time.Hour)))
+	require.False(t, srv.CompleteAssignment(common.HexToHash("0x02")))
+	require.True(t, srv.CompleteAssignment(txListHash))
+	require.False(t, srv.CompleteAssignment(txListHash))
+	_, used := srv.capacity()
+	require.Zero(t, used)
+	require.Nil(t, registry.Get("prover/server/assignment/deadline/missed"))
+
+	id, ok = srv.capacityManager.TakeOneCapacity()
+	require.True(t, ok)
+	require.True(t, srv.capacityManager.SetMetadata(id, &assignmentInfo{txListHash: txListHash}))
+	require.True(t, srv.capacityManager.SetDeadline(id, time.Now().Add(-time.Second)))
+
+	// The slot of the assignment which has missed its deadline should be freed, even before the TTL.
+	_, used = srv.capacity()
+	require.Zero(t, used)
+	missed, ok := registry.Get("prover/server/assignment/deadline/missed").(metrics.Counter)
+	require.True(t, ok)
+	require.Equal(t, int64(1), missed.Snapshot().Count())
+	require.False(t, srv.CompleteAssignment(txListHash))
+}
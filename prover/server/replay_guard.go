@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+var (
+	defaultReplayCacheSize = 10_000
+	errMissingNonce        = errors.New("missing request nonce")
+	errStaleTimestamp      = errors.New("request timestamp out of allowed window")
+	errReplayedNonce       = errors.New("replayed request nonce")
+)
+
+// replayGuard rejects the replayed requests, it remembers the recently seen request nonces in a bounded
+// LRU cache, and rejects the requests whose timestamps are outside the allowed skew window, so that a
+// nonce only needs to be remembered as long as its timestamp is still acceptable.
+type replayGuard struct {
+	maxSkew time.Duration
+	seen    lru.BasicLRU[string, time.Time]
+	clock   func() time.Time
+	mutex   sync.Mutex
+}
+
+// newReplayGuard creates a new replayGuard instance, a zero cache size means the default size.
+func newReplayGuard(maxSkew time.Duration, cacheSize int) *replayGuard {
+	if cacheSize == 0 {
+		cacheSize = defaultReplayCacheSize
+	}
+
+	return &replayGuard{
+		maxSkew: maxSkew,
+		seen:    lru.NewBasicLRU[string, time.Time](cacheSize),
+		clock:   time.Now,
+	}
+}
+
+// check checks the given request nonce and timestamp (in seconds), and marks the nonce as seen if the
+// request is accepted.
+func (g *replayGuard) check(nonce string, timestamp uint64) error {
+	if nonce == "" {
+		return errMissingNonce
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := g.clock()
+	requestedAt := time.Unix(int64(timestamp), 0)
+	if requestedAt.Before(now.Add(-g.maxSkew)) || requestedAt.After(now.Add(g.maxSkew)) {
+		return errStaleTimestamp
+	}
+
+	// A nonce seen before the skew window can't be replayed anymore, since its timestamp is
+	// already out of the window.
+	if seenAt, ok := g.seen.Get(nonce); ok && now.Sub(seenAt) <= 2*g.maxSkew {
+		return errReplayedNonce
+	}
+	g.seen.Add(nonce, now)
+
+	return nil
+}
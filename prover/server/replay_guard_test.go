@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayGuardReplayedNonce(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	g := newReplayGuard(time.Minute, 0)
+	g.clock = func() time.Time { return now }
+
+	require.Nil(t, g.check("0x01", uint64(now.Unix())))
+	require.ErrorIs(t, g.check("0x01", uint64(now.Unix())), errReplayedNonce)
+	require.Nil(t, g.check("0x02", uint64(now.Unix())))
+
+	// The replayed request should still be rejected within the skew window.
+	now = now.Add(30 * time.Second)
+	require.ErrorIs(t, g.check("0x01", uint64(now.Unix())), errReplayedNonce)
+}
+
+func TestReplayGuardStaleTimestamp(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	g := newReplayGuard(time.Minute, 0)
+	g.clock = func() time.Time { return now }
+
+	require.ErrorIs(t, g.check("0x01", uint64(now.Add(-2*time.Minute).Unix())), errStaleTimestamp)
+	require.ErrorIs(t, g.check("0x02", uint64(now.Add(2*time.Minute).Unix())), errStaleTimestamp)
+	require.ErrorIs(t, g.check("", uint64(now.Unix())), errMissingNonce)
+
+	// The rejected requests should not mark their nonces as seen.
+	require.Nil(t, g.check("0x01", uint64(now.Unix())))
+}
+
+func TestReplayGuardBoundedCache(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	g := newReplayGuard(time.Minute, 2)
+	g.clock = func() time.Time { return now }
+
+	for _, nonce := range []string{"0x01", "0x02", "0x03"} {
+		require.Nil(t, g.check(nonce, uint64(now.Unix())))
+	}
+	require.Equal(t, 2, g.seen.Len())
+}
@@ -3,21 +3,46 @@ package server
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
 	"net/http"
-	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
 
 	"github.com/taikoxyz/taiko-client/bindings"
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
+	capacitymanager "github.com/taikoxyz/taiko-client/prover/capacity_manager"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
 
+var (
+	healthCheckCacheTTL = 5 * time.Second
+	healthCheckTimeout  = 3 * time.Second
+	// bondStatusCacheTTL is the duration the queried bond status is cached for, about one L1 slot.
+	bondStatusCacheTTL = 12 * time.Second
+	// proofVerificationGasCacheTTL is the duration the estimated proof verification gas is cached for,
+	// since the verifier contracts rarely change.
+	proofVerificationGasCacheTTL = 10 * time.Minute
+	// defaultSupportedTiers are the tiers which have a minimum proof fee configured.
+	defaultSupportedTiers = []uint16{encoding.TierOptimisticID, encoding.TierSgxID, encoding.TierSgxAndZkVMID}
+)
+
 // @title Taiko Prover Server API
 // @version 1.0
 // @termsOfService http://swagger.io/terms/
@@ -48,6 +73,32 @@ type ProverServer struct {
 	rpc                   *rpc.Client
 	protocolConfigs       *bindings.TaikoDataConfig
 	livenessBond          *big.Int
+	capacityManager       *capacitymanager.CapacityManager
+	minProofFeeFunc       func(ctx context.Context, tier uint16) (*big.Int, error)
+	maxVerifyProofGas     uint64
+	verifyProofGasFunc    func(ctx context.Context, tier uint16) (uint64, error)
+	verifyProofGas        map[uint16]uint64
+	verifyProofGasCache   map[uint16]*estimatedGas
+	verifyProofGasMutex   sync.Mutex
+	proofTimeModel        ProofTimeModel
+	supportedTiers        []uint16
+	allowedSigners        map[common.Address]struct{}
+	pathPrefix            string
+	metricsRegistry       metrics.Registry
+	healthCheck           func(ctx context.Context) error
+	healthCheckedAt       time.Time
+	healthErr             error
+	healthMutex           sync.Mutex
+	bondStatus            *BondStatus
+	bondCheckedAt         time.Time
+	bondMutex             sync.Mutex
+	logger                log.Logger
+	rateLimit             RateLimitConfig
+	cors                  CORSConfig
+	replayGuard           *replayGuard
+	tlsConfig             *tls.Config
+	ctx                   context.Context
+	cancel                context.CancelFunc
 }
 
 // NewProverServerOpts contains all configurations for creating a prover server instance.
@@ -67,6 +118,112 @@ type NewProverServerOpts struct {
 	RPC                   *rpc.Client
 	ProtocolConfigs       *bindings.TaikoDataConfig
 	LivenessBond          *big.Int
+	// MinProofFeeFunc returns the dynamic minimum proof fee of the given tier, if it is nil or returns
+	// a nil fee, the static minimum tier fee will be used.
+	MinProofFeeFunc func(ctx context.Context, tier uint16) (*big.Int, error)
+	// MaxProofVerificationGas is the max estimated L1 gas of verifying a proof, the assignment requests
+	// will be rejected if the estimated gas of any requested tier exceeds it, zero means no limit.
+	MaxProofVerificationGas uint64
+	// ProofVerificationGasFunc estimates the L1 gas of verifying a proof of the given tier, defaults to
+	// the configured ProofVerificationGas, or estimating a `verifyProof` call against the tier's verifier
+	// contract through the RPC client for the tiers not configured.
+	ProofVerificationGasFunc func(ctx context.Context, tier uint16) (uint64, error)
+	// ProofVerificationGas is the L1 gas of verifying a proof of each tier, used by the default
+	// ProofVerificationGasFunc. Since the SGX based verifiers reject a dummy proof, the supported SGX
+	// based tiers must be configured if MaxProofVerificationGas is set without ProofVerificationGasFunc.
+	ProofVerificationGas map[uint16]uint64
+	// ProofTimeModel estimates the proving time of a block, which is served by the /estimate endpoint,
+	// the endpoint responds 501 if it is nil.
+	ProofTimeModel ProofTimeModel
+	// Capacity is the max number of the assignments which can be reserved at the same time,
+	// zero means the capacity manager is disabled.
+	Capacity uint64
+	// CapacityReleaseTimeout is the duration after which a reserved capacity will be released
+	// automatically, defaults to MaxExpiry.
+	CapacityReleaseTimeout time.Duration
+	// HealthProbe reports whether the proving backend is healthy, and the factor the capacity is scaled by
+	// when it is overloaded, e.g. 0.5 halves the available capacity. The new assignments are rejected while
+	// it reports unhealthy. It is consulted by the capacity manager, so it only takes effect if Capacity
+	// is set, and it must not block.
+	HealthProbe func() (healthy bool, capacityFactor float64)
+	// Logger is the logger used by the prover server, for both the request logs and the
+	// handler logs, defaults to the root logger.
+	Logger log.Logger
+	// MaxRequestSkew is the max allowed difference between an assignment request's timestamp and
+	// the server's clock, if it is set, each assignment request must carry a unique nonce and a
+	// timestamp within the window, otherwise it will be rejected as a replay.
+	MaxRequestSkew time.Duration
+	// ReplayCacheSize is the max number of the recently seen request nonces to remember, defaults
+	// to 10000.
+	ReplayCacheSize int
+	// RateLimit is the per-client rate limiting configuration, the rate limiting is disabled
+	// if RequestsPerSecond is zero.
+	RateLimit RateLimitConfig
+	// CORS is the cross-origin resource sharing configuration, the CORS headers are not set if
+	// AllowOrigins is empty.
+	CORS CORSConfig
+	// TLSCertFile and TLSKeyFile are the paths of the PEM encoded TLS certificate and private key, if
+	// they are set, the server will be started with HTTPS.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSConfig is the TLS configuration of the server, if it is set, the server will be started with
+	// HTTPS, the certificate loaded from TLSCertFile and TLSKeyFile will be appended to it.
+	TLSConfig *tls.Config
+	// SupportedTiers is the list of the proof tiers which the prover accepts assignments for, the assignment
+	// requests for any other (non-guardian) tier will be rejected, defaults to all the tiers with a minimum
+	// proof fee configured, i.e. optimistic, SGX and SGX + zkVM.
+	SupportedTiers []uint16
+	// AllowedSigners is the allowlist of the proposers which may request assignments, if it is set, each
+	// assignment request must be signed by one of them, with the signature sent in the SignatureHeader.
+	AllowedSigners []common.Address
+	// PathPrefix is the base path which all routes are registered under, e.g. "/prover/v1", when the
+	// server is deployed behind a gateway, defaults to the root path.
+	PathPrefix string
+	// MetricsRegistry is the registry which the assignment metrics are recorded in, and exposed by the
+	// /metrics endpoint, defaults to the default metrics registry.
+	MetricsRegistry metrics.Registry
+}
+
+// estimatedGas is an estimated proof verification gas, and when it was estimated.
+type estimatedGas struct {
+	gas         uint64
+	estimatedAt time.Time
+}
+
+// ProofTimeModel estimates the time of proving the block with the given metadata, excluding the time the
+// block waits in the proving queue.
+type ProofTimeModel func(block *EstimateProofTimeRequestBody) time.Duration
+
+// NewLinearProofTimeModel creates a ProofTimeModel which grows linearly with the gas used and the number of
+// the transactions of the block, on top of the given base proving time.
+func NewLinearProofTimeModel(base, perMillionGas, perTx time.Duration) ProofTimeModel {
+	return func(block *EstimateProofTimeRequestBody) time.Duration {
+		return base +
+			time.Duration(float64(perMillionGas)*float64(block.GasUsed)/1_000_000) +
+			perTx*time.Duration(block.TxCount)
+	}
+}
+
+// RateLimitConfig contains the token bucket configurations of the per-client rate limiting,
+// the clients are identified by their IP addresses.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained number of requests allowed per second for each client.
+	RequestsPerSecond float64
+	// Burst is the max number of requests allowed at once for each client, defaults to
+	// the ceiling of RequestsPerSecond.
+	Burst int
+}
+
+// CORSConfig contains the cross-origin resource sharing configurations, which allow the web dashboards
+// to query the prover server from the browsers.
+type CORSConfig struct {
+	// AllowOrigins is the list of the origins which may access the prover server, "*" means any origin.
+	AllowOrigins []string
+	// AllowMethods is the list of the methods allowed when accessing the prover server, defaults to
+	// GET, HEAD, PUT, PATCH, POST and DELETE.
+	AllowMethods []string
+	// AllowHeaders is the list of the request headers allowed when accessing the prover server.
+	AllowHeaders []string
 }
 
 // New creates a new prover server instance.
@@ -89,8 +246,83 @@ func New(opts *NewProverServerOpts) (*ProverServer, error) {
 		rpc:                   opts.RPC,
 		protocolConfigs:       opts.ProtocolConfigs,
 		livenessBond:          opts.LivenessBond,
+		minProofFeeFunc:       opts.MinProofFeeFunc,
+		maxVerifyProofGas:     opts.MaxProofVerificationGas,
+		verifyProofGasFunc:    opts.ProofVerificationGasFunc,
+		verifyProofGas:        opts.ProofVerificationGas,
+		verifyProofGasCache:   make(map[uint16]*estimatedGas),
+		proofTimeModel:        opts.ProofTimeModel,
+		logger:                opts.Logger,
+		rateLimit:             opts.RateLimit,
+		cors:                  opts.CORS,
+		supportedTiers:        opts.SupportedTiers,
+		pathPrefix:            "/" + strings.Trim(opts.PathPrefix, "/"),
+		metricsRegistry:       opts.MetricsRegistry,
 	}
 
+	if srv.logger == nil {
+		srv.logger = log.Root()
+	}
+	srv.logger = srv.logger.With("prover", srv.proverAddress)
+
+	if opts.Capacity != 0 {
+		releaseTimeout := opts.CapacityReleaseTimeout
+		if releaseTimeout == 0 {
+			releaseTimeout = opts.MaxExpiry
+		}
+		srv.capacityManager = capacitymanager.New(opts.Capacity, releaseTimeout)
+		srv.capacityManager.OnDeadlineMissed(func(uint64) { srv.recordMissedDeadline() })
+		if opts.HealthProbe != nil {
+			srv.capacityManager.SetHealthProbe(opts.HealthProbe)
+		}
+	}
+	if len(srv.supportedTiers) == 0 {
+		srv.supportedTiers = defaultSupportedTiers
+	}
+	for _, tier := range srv.supportedTiers {
+		if !slices.Contains(defaultSupportedTiers, tier) {
+			return nil, fmt.Errorf("%w: %d", errUnknownTier, tier)
+		}
+		if srv.maxVerifyProofGas == 0 || srv.verifyProofGasFunc != nil || !slices.Contains(sgxBasedTiers, tier) {
+			continue
+		}
+		if _, ok := srv.verifyProofGas[tier]; !ok {
+			return nil, fmt.Errorf("%w: tier %d", errMissingProofVerificationGas, tier)
+		}
+	}
+	if len(opts.AllowedSigners) != 0 {
+		srv.allowedSigners = make(map[common.Address]struct{}, len(opts.AllowedSigners))
+		for _, signer := range opts.AllowedSigners {
+			srv.allowedSigners[signer] = struct{}{}
+		}
+	}
+	if opts.MaxRequestSkew != 0 {
+		srv.replayGuard = newReplayGuard(opts.MaxRequestSkew, opts.ReplayCacheSize)
+	}
+	if opts.TLSConfig != nil {
+		srv.tlsConfig = opts.TLSConfig.Clone()
+	}
+	if opts.TLSCertFile != "" || opts.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.TLSCertFile, opts.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		if srv.tlsConfig == nil {
+			srv.tlsConfig = new(tls.Config)
+		}
+		srv.tlsConfig.Certificates = append(srv.tlsConfig.Certificates, cert)
+	}
+	if opts.RPC != nil {
+		srv.healthCheck = srv.checkRPCConnectivity
+	}
+	if srv.metricsRegistry == nil {
+		srv.metricsRegistry = metrics.DefaultRegistry
+	}
+	if srv.pathPrefix == "/" {
+		srv.pathPrefix = ""
+	}
+	srv.ctx, srv.cancel = context.WithCancel(context.Background())
+
 	srv.echo.HideBanner = true
 	srv.configureMiddleware()
 	srv.configureRoutes()
@@ -98,28 +330,108 @@ func New(opts *NewProverServerOpts) (*ProverServer, error) {
 	return srv, nil
 }
 
-// Start starts the HTTP server.
+// Start starts the HTTP server, or the HTTPS server if the TLS is enabled.
 func (s *ProverServer) Start(address string) error {
+	if s.TLSEnabled() {
+		return s.StartTLS(address)
+	}
+
+	if s.capacityManager != nil {
+		s.capacityManager.Start(s.ctx)
+	}
 	return s.echo.Start(address)
 }
 
-// Shutdown shuts down the HTTP server.
+// StartTLS starts the HTTPS server with the configured TLS configuration.
+func (s *ProverServer) StartTLS(address string) error {
+	if !s.TLSEnabled() {
+		return errors.New("TLS is not configured")
+	}
+
+	if s.capacityManager != nil {
+		s.capacityManager.Start(s.ctx)
+	}
+	s.echo.TLSServer.Addr = address
+	s.echo.TLSServer.TLSConfig = s.tlsConfig
+	return s.echo.StartServer(s.echo.TLSServer)
+}
+
+// TLSEnabled returns whether the server will be started with HTTPS.
+func (s *ProverServer) TLSEnabled() bool {
+	return s.tlsConfig != nil
+}
+
+// PathPrefix returns the base path which all routes are registered under, it is empty if the routes
+// are registered under the root path.
+func (s *ProverServer) PathPrefix() string {
+	return s.pathPrefix
+}
+
+// Shutdown shuts down the HTTP server, it stops accepting new connections and waits for the in-flight
+// requests until the given context is done, the remaining requests will then be aborted, and their
+// reserved capacity will be released.
 func (s *ProverServer) Shutdown(ctx context.Context) error {
-	return s.echo.Shutdown(ctx)
+	s.cancel()
+	if err := s.echo.Shutdown(ctx); err != nil {
+		s.logger.Warn("Failed to drain the in-flight requests, closing the prover server", "error", err)
+		if closeErr := s.echo.Close(); closeErr != nil {
+			s.logger.Error("Failed to close the prover server", "error", closeErr)
+		}
+		return err
+	}
+	return nil
 }
 
-// Health endpoints for probes.
+// Health endpoints for probes, returns 503 if the L1 / L2 RPC backends are unreachable.
 func (s *ProverServer) Health(c echo.Context) error {
+	if err := s.checkHealth(c.Request().Context()); err != nil {
+		s.logger.Warn("Prover server health check failed", "error", err)
+		return c.NoContent(http.StatusServiceUnavailable)
+	}
 	return c.NoContent(http.StatusOK)
 }
 
+// checkHealth checks the RPC connectivity, the result will be cached for healthCheckCacheTTL,
+// to avoid hammering the nodes by frequent probes.
+func (s *ProverServer) checkHealth(ctx context.Context) error {
+	if s.healthCheck == nil {
+		return nil
+	}
+
+	s.healthMutex.Lock()
+	defer s.healthMutex.Unlock()
+
+	if !s.healthCheckedAt.IsZero() && time.Since(s.healthCheckedAt) < healthCheckCacheTTL {
+		return s.healthErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	s.healthErr = s.healthCheck(ctx)
+	s.healthCheckedAt = time.Now()
+
+	return s.healthErr
+}
+
+// checkRPCConnectivity performs a lightweight liveness check on the L1 / L2 RPC backends.
+func (s *ProverServer) checkRPCConnectivity(ctx context.Context) error {
+	if _, err := s.rpc.L1.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("failed to connect to L1 node: %w", err)
+	}
+	if _, err := s.rpc.L2.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("failed to connect to L2 node: %w", err)
+	}
+	return nil
+}
+
 // LogSkipper implements the `middleware.Skipper` interface.
 func LogSkipper(c echo.Context) bool {
 	switch c.Request().URL.Path {
 	case "/healthz":
 		return true
 	default:
-		return true
+		return false
 	}
 }
 
@@ -127,20 +439,95 @@ func LogSkipper(c echo.Context) bool {
 func (s *ProverServer) configureMiddleware() {
 	s.echo.Use(middleware.RequestID())
 
-	s.echo.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Skipper: LogSkipper,
-		Format: `{"time":"${time_rfc3339_nano}","level":"INFO","message":{"id":"${id}","remote_ip":"${remote_ip}",` +
-			`"host":"${host}","method":"${method}","uri":"${uri}","user_agent":"${user_agent}",` +
-			`"response_status":${status},"error":"${error}","latency":${latency},"latency_human":"${latency_human}",` +
-			`"bytes_in":${bytes_in},"bytes_out":${bytes_out}}}` + "\n",
-		Output: os.Stdout,
+	s.echo.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		Skipper:      LogSkipper,
+		LogRequestID: true,
+		LogRemoteIP:  true,
+		LogHost:      true,
+		LogMethod:    true,
+		LogURI:       true,
+		LogUserAgent: true,
+		LogStatus:    true,
+		LogError:     true,
+		LogLatency:   true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			s.logger.Info(
+				"Prover server request",
+				"requestID", v.RequestID,
+				"remoteIP", v.RemoteIP,
+				"host", v.Host,
+				"method", v.Method,
+				"uri", v.URI,
+				"userAgent", v.UserAgent,
+				"status", v.Status,
+				"error", v.Error,
+				"latency", v.Latency,
+			)
+			return nil
+		},
 	}))
+
+	if len(s.cors.AllowOrigins) != 0 {
+		s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins: s.cors.AllowOrigins,
+			AllowMethods: s.cors.AllowMethods,
+			AllowHeaders: s.cors.AllowHeaders,
+		}))
+	}
+
+	if s.rateLimit.RequestsPerSecond > 0 {
+		s.echo.Use(s.rateLimiter())
+	}
+}
+
+// rateLimiter creates a token bucket rate limiter middleware keyed by the client IP, which responds
+// HTTP 429 with a Retry-After header when a client exceeds the limit, the health checks are not limited.
+func (s *ProverServer) rateLimiter() echo.MiddlewareFunc {
+	burst := s.rateLimit.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(s.rateLimit.RequestsPerSecond))
+	}
+	retryAfter := strconv.Itoa(int(math.Ceil(1 / s.rateLimit.RequestsPerSecond)))
+
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Skipper: func(c echo.Context) bool {
+			switch strings.TrimPrefix(c.Request().URL.Path, s.pathPrefix) {
+			case "", "/", "/healthz":
+				return true
+			default:
+				return false
+			}
+		},
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  rate.Limit(s.rateLimit.RequestsPerSecond),
+			Burst: burst,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return c.RealIP(), nil
+		},
+		DenyHandler: func(c echo.Context, identifier string, _ error) error {
+			s.logger.Warn("Prover server rate limit exceeded", "client", identifier, "uri", c.Request().RequestURI)
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfter)
+			return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+		},
+	})
 }
 
 // configureRoutes contains all routes which will be used by prover server.
 func (s *ProverServer) configureRoutes() {
-	s.echo.GET("/", s.Health)
-	s.echo.GET("/healthz", s.Health)
-	s.echo.GET("/status", s.GetStatus)
-	s.echo.POST("/assignment", s.CreateAssignment)
+	g := s.echo.Group(s.pathPrefix)
+	g.GET("/", s.Health)
+	g.GET("/healthz", s.Health)
+	g.GET("/status", s.GetStatus)
+	g.GET("/tiers", s.GetTiers)
+	g.GET("/assignments", s.GetAssignments)
+	g.GET("/wait-capacity", s.WaitCapacity)
+	g.GET("/bond", s.GetBond)
+	g.POST("/estimate", s.EstimateProofTime)
+	g.GET("/metrics", echo.WrapHandler(prometheus.Handler(s.metricsRegistry)))
+	if s.allowedSigners != nil {
+		g.POST("/assignment", s.CreateAssignment, s.verifySigner())
+	} else {
+		g.POST("/assignment", s.CreateAssignment)
+	}
 }
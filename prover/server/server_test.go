@@ -1,12 +1,18 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,8 +21,12 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-resty/resty/v2"
+	"github.com/labstack/echo/v4"
 	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/taikoxyz/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-client/pkg/rpc"
 	proofProducer "github.com/taikoxyz/taiko-client/prover/proof_producer"
 )
@@ -124,3 +134,219 @@ func (s *ProverServerTestSuite) sendReq(path string) *http.Response {
 	s.Nil(err)
 	return res
 }
+
+// newTestServer creates a new prover server with a random key, the minimum tier fees of 1 wei and the max expiry
+// of an hour, the given function can be used to change the options, and starts a test HTTP server serving it.
+func newTestServer(t *testing.T, configure func(opts *NewProverServerOpts)) (*ProverServer, *httptest.Server) {
+	privKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	opts := &NewProverServerOpts{
+		ProverPrivateKey:     privKey,
+		MinOptimisticTierFee: common.Big1,
+		MinSgxTierFee:        common.Big1,
+		MinSgxAndZkVMTierFee: common.Big1,
+		MaxExpiry:            time.Hour,
+	}
+	if configure != nil {
+		configure(opts)
+	}
+
+	srv, err := New(opts)
+	require.Nil(t, err)
+
+	testServer := httptest.NewServer(srv.echo)
+	t.Cleanup(testServer.Close)
+
+	return srv, testServer
+}
+
+func TestShutdownDrainsInflightRequests(t *testing.T) {
+	var (
+		started  = make(chan struct{})
+		finished atomic.Bool
+	)
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.MinProofFeeFunc = func(_ context.Context, tier uint16) (*big.Int, error) {
+			if tier == encoding.TierOptimisticID {
+				close(started)
+				time.Sleep(500 * time.Millisecond)
+				finished.Store(true)
+			}
+			return nil, nil
+		}
+	})
+
+	port, err := freeport.GetFreePort()
+	require.Nil(t, err)
+
+	go func() {
+		if err := srv.Start(fmt.Sprintf(":%v", port)); !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Failed to start prover server", "error", err)
+		}
+	}()
+
+	// Wait till the server fully started.
+	require.Nil(t, backoff.Retry(func() error {
+		_, err := resty.New().R().Get(fmt.Sprintf("http://localhost:%v/healthz", port))
+		return err
+	}, backoff.NewExponentialBackOff()))
+
+	done := make(chan int)
+	go func() {
+		res, err := resty.New().R().Get(fmt.Sprintf("http://localhost:%v/status", port))
+		if err != nil {
+			log.Error("Failed to get prover server status", "error", err)
+			close(done)
+			return
+		}
+		done <- res.StatusCode()
+	}()
+
+	<-started
+	require.Nil(t, srv.Shutdown(context.Background()))
+
+	// The in-flight request should be drained before Shutdown returns.
+	require.True(t, finished.Load())
+	require.Equal(t, http.StatusOK, <-done)
+}
+
+func TestHealthRPCDown(t *testing.T) {
+	srv, testServer := newTestServer(t, nil)
+
+	var checked int
+	srv.healthCheck = func(context.Context) error {
+		checked++
+		return errors.New("connection refused")
+	}
+
+	for i := 0; i < 3; i++ {
+		res, err := http.Get(testServer.URL + "/healthz")
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	}
+
+	// The health check result should be cached.
+	require.Equal(t, 1, checked)
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.Logger = log.NewLogger(log.JSONHandler(&buf))
+	})
+	srv.healthCheck = func(context.Context) error { return errors.New("connection refused") }
+
+	res, err := http.Get(testServer.URL + "/healthz")
+	require.Nil(t, err)
+	require.Nil(t, res.Body.Close())
+
+	b, err := json.Marshal(&CreateAssignmentRequestBody{
+		TierFees: []encoding.TierFee{{Tier: encoding.TierSgxID, Fee: common.Big256}},
+	})
+	require.Nil(t, err)
+	res, err = http.Post(testServer.URL+"/assignment", echo.MIMEApplicationJSON, bytes.NewReader(b))
+	require.Nil(t, err)
+	require.Nil(t, res.Body.Close())
+	require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	requestID := res.Header.Get(echo.HeaderXRequestID)
+	require.NotEmpty(t, requestID)
+
+	records := map[string]map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		require.Nil(t, json.Unmarshal([]byte(line), &record))
+		require.Equal(t, srv.proverAddress.Hex(), record["prover"])
+		records[record["msg"].(string)] = record
+	}
+
+	// The health checks are not logged by the request logger.
+	request, ok := records["Prover server request"]
+	require.True(t, ok)
+	require.Equal(t, requestID, request["requestID"])
+	require.Equal(t, "/assignment", request["uri"])
+	require.Equal(t, float64(http.StatusUnprocessableEntity), request["status"])
+
+	body, ok := records["Proof assignment request body"]
+	require.True(t, ok)
+	require.Equal(t, requestID, body["requestID"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"Tier": float64(encoding.TierSgxID), "Fee": float64(256)},
+	}, body["tierFees"])
+}
+
+func TestRateLimit(t *testing.T) {
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.RateLimit = RateLimitConfig{RequestsPerSecond: 0.5, Burst: 2}
+	})
+
+	get := func(path string, clientIP string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+path, nil)
+		require.Nil(t, err)
+		req.Header.Set(echo.HeaderXRealIP, clientIP)
+		res, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		return res
+	}
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, get("/status", "10.0.0.1").StatusCode)
+	}
+	res := get("/status", "10.0.0.1")
+	require.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.Equal(t, "2", res.Header.Get(echo.HeaderRetryAfter))
+
+	// Other clients and the health checks should not be limited.
+	require.Equal(t, http.StatusOK, get("/status", "10.0.0.2").StatusCode)
+	require.Equal(t, http.StatusOK, get("/healthz", "10.0.0.1").StatusCode)
+}
+
+func TestCORS(t *testing.T) {
+	for _, tc := range []struct {
+		cors     CORSConfig
+		expected string
+	}{
+		{CORSConfig{}, ""},
+		{CORSConfig{AllowOrigins: []string{"https://dashboard.example"}}, "https://dashboard.example"},
+	} {
+		_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+			opts.CORS = tc.cors
+		})
+
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+"/status", nil)
+		require.Nil(t, err)
+		req.Header.Set(echo.HeaderOrigin, "https://dashboard.example")
+		res, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, tc.expected, res.Header.Get(echo.HeaderAccessControlAllowOrigin))
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	srv, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.RateLimit = RateLimitConfig{RequestsPerSecond: 0.5, Burst: 2}
+		opts.PathPrefix = "prover/v1/"
+	})
+	require.Equal(t, "/prover/v1", srv.PathPrefix())
+
+	get := func(path string) int {
+		res, err := http.Get(testServer.URL + path)
+		require.Nil(t, err)
+		require.Nil(t, res.Body.Close())
+		return res.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, get("/prover/v1/status"))
+	require.Equal(t, http.StatusNotFound, get("/status"))
+
+	// The health checks under the prefix should not be rate limited.
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, get("/prover/v1/healthz"))
+	}
+	require.Equal(t, http.StatusNotFound, get("/healthz"))
+}
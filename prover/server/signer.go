@@ -0,0 +1,80 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+)
+
+// SignatureHeader is the request header of the proposer's signature over the request body, which is
+// required if the prover server has an allowlist of the request signers.
+const SignatureHeader = "X-Signature"
+
+var (
+	errMissingSignature = errors.New("missing request signature")
+	errInvalidSignature = errors.New("invalid request signature")
+	errSignerNotAllowed = errors.New("request signer not allowed")
+)
+
+// SignRequest signs the given request body with the given private key, and returns the hex encoded
+// signature which should be sent in the SignatureHeader.
+func SignRequest(body []byte, key *ecdsa.PrivateKey) (string, error) {
+	sig, err := crypto.Sign(crypto.Keccak256(body), key)
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.Encode(sig), nil
+}
+
+// RecoverRequestSigner recovers the address which signed the given request body.
+func RecoverRequestSigner(body []byte, signature string) (common.Address, error) {
+	if signature == "" {
+		return common.Address{}, errMissingSignature
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return common.Address{}, errInvalidSignature
+	}
+	pubKey, err := crypto.SigToPub(crypto.Keccak256(body), sig)
+	if err != nil {
+		return common.Address{}, errInvalidSignature
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// verifySigner creates a middleware which recovers the signer of the request body from the
+// SignatureHeader, and rejects the request with 401 if the signature is missing or invalid, or with
+// 403 if the signer is not in the allowlist.
+func (s *ProverServer) verifySigner() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			signer, err := RecoverRequestSigner(body, c.Request().Header.Get(SignatureHeader))
+			if err != nil {
+				s.logger.Warn("Rejected unsigned request", "uri", c.Request().RequestURI, "error", err)
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			}
+			if _, ok := s.allowedSigners[signer]; !ok {
+				s.logger.Warn("Rejected request from disallowed signer", "uri", c.Request().RequestURI, "signer", signer)
+				return echo.NewHTTPError(http.StatusForbidden, errSignerNotAllowed.Error())
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignRequest(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	body := []byte(`{"txListHash":"0x01"}`)
+	sig, err := SignRequest(body, key)
+	require.Nil(t, err)
+
+	signer, err := RecoverRequestSigner(body, sig)
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// A modified body should recover to another address.
+	signer, err = RecoverRequestSigner([]byte(`{"txListHash":"0x02"}`), sig)
+	require.Nil(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	_, err = RecoverRequestSigner(body, "")
+	require.ErrorIs(t, err, errMissingSignature)
+	_, err = RecoverRequestSigner(body, "0x1234")
+	require.ErrorIs(t, err, errInvalidSignature)
+}
+
+func TestVerifySigner(t *testing.T) {
+	var (
+		allowedKey, _    = crypto.GenerateKey()
+		disallowedKey, _ = crypto.GenerateKey()
+	)
+	srv, _ := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.AllowedSigners = []common.Address{crypto.PubkeyToAddress(allowedKey.PublicKey)}
+	})
+
+	// The handler should see the same request body which has been verified.
+	e := echo.New()
+	e.POST("/", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, body)
+	}, srv.verifySigner())
+
+	testServer := httptest.NewServer(e)
+	defer testServer.Close()
+
+	body := []byte(`{"txListHash":"0x01"}`)
+	send := func(signature string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, testServer.URL, bytes.NewReader(body))
+		require.Nil(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		return res
+	}
+
+	// A valid signer should be accepted.
+	sig, err := SignRequest(body, allowedKey)
+	require.Nil(t, err)
+	res := send(sig)
+	received, err := io.ReadAll(res.Body)
+	require.Nil(t, err)
+	require.Nil(t, res.Body.Close())
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, body, received)
+
+	// A missing or bad signature should be unauthorized.
+	for _, sig := range []string{"", "0xdeadbeef"} {
+		res = send(sig)
+		require.Nil(t, res.Body.Close())
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	}
+
+	// A disallowed signer should be forbidden.
+	sig, err = SignRequest(body, disallowedKey)
+	require.Nil(t, err)
+	res = send(sig)
+	require.Nil(t, res.Body.Close())
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+}
+
+func TestCreateAssignmentSignedRequest(t *testing.T) {
+	proposerKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	_, testServer := newTestServer(t, func(opts *NewProverServerOpts) {
+		opts.AllowedSigners = []common.Address{crypto.PubkeyToAddress(proposerKey.PublicKey)}
+	})
+
+	// An unsigned request should be rejected before reaching the handler.
+	body := []byte(`{"TxListHash":"0x0000000000000000000000000000000000000000000000000000000000000000"}`)
+	res, err := http.Post(testServer.URL+"/assignment", echo.MIMEApplicationJSON, bytes.NewReader(body))
+	require.Nil(t, err)
+	require.Nil(t, res.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	// A signed request should reach the handler, which then rejects the empty txList hash.
+	sig, err := SignRequest(body, proposerKey)
+	require.Nil(t, err)
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/assignment", bytes.NewReader(body))
+	require.Nil(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(SignatureHeader, sig)
+	res, err = http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	require.Nil(t, res.Body.Close())
+	require.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+}